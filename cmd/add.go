@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -11,20 +13,33 @@ import (
 	"github.com/chenasraf/cospend-cli/internal/cache"
 	"github.com/chenasraf/cospend-cli/internal/config"
 	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/chenasraf/cospend-cli/internal/prompt"
 	"github.com/spf13/cobra"
 )
 
 var (
-	category      string
-	paidBy        string
-	paidFor       []string
-	convertTo     string
-	paymentMethod string
-	comment       string
-	addDate       string
-	repeat        string
+	category       string
+	paidBy         string
+	paidFor        []string
+	convertTo      string
+	paymentMethod  string
+	comment        string
+	commentFile    string
+	allowZero      bool
+	allowNegative  bool
+	reimbursement  bool
+	addDate        string
+	repeat         string
+	activeOnly     bool
+	addInteractive bool
+	addCopyLast    bool
+	addNoDups      bool
 )
 
+// duplicateWindow is how recently an identical bill must have been added for
+// --no-duplicates to skip a new one
+const duplicateWindow = time.Minute
+
 // NewAddCommand creates the add command
 func NewAddCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -34,19 +49,36 @@ func NewAddCommand() *cobra.Command {
 
 Examples:
   cospend add "Groceries" 25.50 -p myproject
-  cospend add "Dinner" 45.00 -p myproject -c restaurant -b alice -f bob -f charlie`,
-		Args: cobra.ExactArgs(2),
+  cospend add "Dinner" 45.00 -p myproject -c restaurant -b alice -f bob -f charlie
+  cospend add "Groceries" 82.40 -p myproject --comment-file receipt.txt
+  cospend add "Coffee" 4 -p myproject --copy-last
+  cospend add --interactive -p myproject`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			// The wizard prompts for anything that's missing
+			if addInteractive {
+				return cobra.MaximumNArgs(2)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		RunE: runAdd,
 	}
 
-	cmd.Flags().StringVarP(&category, "category", "c", "", "Category by ID or name")
+	cmd.Flags().StringVarP(&category, "category", "c", "", "Category by ID or name (defaults to the default-category config)")
 	cmd.Flags().StringVarP(&paidBy, "by", "b", "", "Paying member username (defaults to authenticated user)")
 	cmd.Flags().StringArrayVarP(&paidFor, "for", "f", nil, "Owed member username (repeatable; defaults to payer only)")
 	cmd.Flags().StringVarP(&convertTo, "convert", "C", "", "Currency to convert to")
-	cmd.Flags().StringVarP(&paymentMethod, "method", "m", "", "Payment method by ID or name")
+	cmd.Flags().StringVarP(&paymentMethod, "method", "m", "", "Payment method by ID or name (defaults to the default-method config)")
 	cmd.Flags().StringVarP(&comment, "comment", "o", "", "Additional details about the bill")
+	cmd.Flags().StringVar(&commentFile, "comment-file", "", "Read the comment from a file (- for stdin)")
 	cmd.Flags().StringVarP(&addDate, "date", "d", "", "Date of expense (YYYY-MM-DD, MM-DD, or relative like -1d, +2w)")
 	cmd.Flags().StringVarP(&repeat, "repeat", "r", "", "Repeat frequency: d (daily), w (weekly), b (biweekly), s (semi-monthly), m (monthly), y (yearly)")
+	cmd.Flags().BoolVar(&activeOnly, "active-only", false, "Fail if the payer or an owed member is deactivated")
+	cmd.Flags().BoolVar(&allowZero, "allow-zero", false, "Allow an amount of zero")
+	cmd.Flags().BoolVar(&allowNegative, "allow-negative", false, "Allow a negative amount, e.g. for refunds")
+	cmd.Flags().BoolVar(&reimbursement, "reimbursement", false, "Mark the bill as a reimbursement (Cospend's built-in category)")
+	cmd.Flags().BoolVarP(&addInteractive, "interactive", "i", false, "Prompt for each field, using any given flags as defaults")
+	cmd.Flags().BoolVar(&addCopyLast, "copy-last", false, "Copy the owed members, category, and payment method of the payer's latest bill")
+	cmd.Flags().BoolVar(&addNoDups, "no-duplicates", false, "Skip the bill if an identical one was added in the last minute, e.g. when retrying after a timeout")
 
 	return cmd
 }
@@ -56,18 +88,38 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("project is required (use -p or --project)")
 	}
 
-	expenseName := args[0]
-	amountStr := args[1]
+	if addInteractive && NoInteractive {
+		return fmt.Errorf("--interactive can't be used with --no-interactive")
+	}
 
-	// Parse amount
-	amount, err := strconv.ParseFloat(amountStr, 64)
-	if err != nil {
-		return fmt.Errorf("invalid amount: %s", amountStr)
+	if commentFile != "" && cmd.Flags().Changed("comment") {
+		return fmt.Errorf("--comment can't be used with --comment-file")
+	}
+
+	if reimbursement && cmd.Flags().Changed("category") {
+		return fmt.Errorf("--reimbursement can't be used with --category")
+	}
+
+	if commentFile == "-" && addInteractive {
+		return fmt.Errorf("--comment-file - can't be used with --interactive, which reads stdin")
+	}
+
+	if addCopyLast && addInteractive {
+		return fmt.Errorf("--copy-last can't be used with --interactive")
 	}
 
 	// Parameters validated, silence usage for subsequent errors
 	cmd.SilenceUsage = true
 
+	// The file's contents stand in for --comment
+	if commentFile != "" {
+		text, err := readCommentFile(cmd, commentFile)
+		if err != nil {
+			return err
+		}
+		comment = text
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -75,21 +127,12 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get API client
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	client := newClient(cmd, cfg)
 
 	// Get project (from cache or API)
-	project, ok := cache.Load(ProjectID)
-	if !ok {
-		project, err = client.GetProject(ProjectID)
-		if err != nil {
-			return fmt.Errorf("fetching project: %w", err)
-		}
-		if err := cache.Save(ProjectID, project); err != nil {
-			// Non-fatal: log warning but continue
-			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
-		}
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
 	}
 
 	// Build member name lookup
@@ -98,82 +141,38 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		memberNames[m.ID] = m.Name
 	}
 
-	// Resolve payer
-	payerUsername := paidBy
-	if payerUsername == "" {
-		payerUsername = cfg.User
-	}
-	payerID, err := cache.ResolveMember(project, payerUsername)
-	if err != nil {
-		return fmt.Errorf("resolving payer: %w", err)
-	}
+	// Resolve the locale for amount parsing and formatting
+	locale := loadLocale(cmd, client, cfg)
 
-	// Resolve owed members
-	var owedIDs []int
-	if len(paidFor) == 0 {
-		// Default to payer only
-		owedIDs = []int{payerID}
-	} else {
-		for _, username := range paidFor {
-			memberID, err := cache.ResolveMember(project, username)
-			if err != nil {
-				return fmt.Errorf("resolving owed member: %w", err)
-			}
-			owedIDs = append(owedIDs, memberID)
-		}
+	// Resolve the time zone that today's date is taken in
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
 	}
 
-	// Resolve date
-	billDate := time.Now().Format("2006-01-02")
-	if addDate != "" {
-		parsed, err := parseDate(addDate)
+	// Build bill; the wizard prompts for the name and amount arguments
+	var bill api.Bill
+	if addInteractive {
+		bill, err = promptBill(cmd, project, cfg, locale, loc, args)
 		if err != nil {
 			return err
 		}
-		billDate = parsed
-	}
-
-	// Build bill
-	bill := api.Bill{
-		What:    expenseName,
-		Amount:  amount,
-		PayerID: payerID,
-		OwedTo:  owedIDs,
-		Date:    billDate,
-	}
-
-	// Resolve optional category
-	if category != "" {
-		categoryID, err := cache.ResolveCategory(project, category)
+	} else {
+		amount, err := parseBillAmount(args[1], locale)
 		if err != nil {
-			return fmt.Errorf("resolving category: %w", err)
+			return err
 		}
-		bill.CategoryID = categoryID
-	}
-
-	// Resolve optional payment method
-	if paymentMethod != "" {
-		methodID, err := cache.ResolvePaymentMode(project, paymentMethod)
+		bill, err = billFromFlags(cmd, project, cfg, args[0], amount, loc)
 		if err != nil {
-			return fmt.Errorf("resolving payment method: %w", err)
+			return err
 		}
-		bill.PaymentModeID = methodID
-	}
-
-	// Fetch user info for locale-aware formatting
-	locale := "en_US"
-	userInfo, ok := cache.LoadUserInfo()
-	if !ok {
-		userInfo, err = client.GetUserInfo()
-		if err == nil {
-			_ = cache.SaveUserInfo(userInfo)
+		if addCopyLast {
+			if err := copyLastBill(cmd, client, project, &bill); err != nil {
+				return err
+			}
 		}
 	}
-	if userInfo != nil && userInfo.Locale != "" {
-		locale = userInfo.Locale
-	} else if userInfo != nil && userInfo.Language != "" {
-		locale = userInfo.Language
-	}
+	expenseName, amount := bill.What, bill.Amount
 
 	// Resolve optional currency and convert amount
 	if convertTo != "" {
@@ -187,11 +186,6 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		bill.What = fmt.Sprintf("%s (%s)", expenseName, origFormatter.Format(amount))
 	}
 
-	// Add optional comment
-	if comment != "" {
-		bill.Comment = comment
-	}
-
 	// Set repeat frequency
 	if repeat != "" {
 		if _, ok := api.ValidRepeatFrequencies[repeat]; !ok {
@@ -200,7 +194,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		bill.Repeat = repeat
 	}
 
-	formatter := format.NewAmountFormatter(locale, project.CurrencyName)
+	formatter := format.NewAmountFormatter(locale, displayCurrency(cfg, project))
 	out := cmd.OutOrStdout()
 
 	printBillSummary := func() {
@@ -209,13 +203,15 @@ func runAdd(cmd *cobra.Command, args []string) error {
 			origFormatter := format.NewAmountFormatter(locale, convertTo)
 			_, _ = fmt.Fprintf(out, "  Original: %s\n", origFormatter.Format(amount))
 		}
-		_, _ = fmt.Fprintf(out, "  Paid by:  %s\n", memberNames[payerID])
+		_, _ = fmt.Fprintf(out, "  Paid by:  %s\n", memberNames[bill.PayerID])
 		var owerNames []string
-		for _, id := range owedIDs {
+		for _, id := range bill.OwedTo {
 			owerNames = append(owerNames, memberNames[id])
 		}
 		_, _ = fmt.Fprintf(out, "  Paid for: %s\n", strings.Join(owerNames, ", "))
-		if bill.CategoryID != 0 {
+		if bill.CategoryID == api.CategoryReimbursement {
+			_, _ = fmt.Fprintf(out, "  Category: %s\n", reimbursementName)
+		} else if bill.CategoryID != 0 {
 			for _, c := range project.Categories {
 				if c.ID == bill.CategoryID {
 					_, _ = fmt.Fprintf(out, "  Category: %s\n", c.Name)
@@ -234,7 +230,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		if bill.Comment != "" {
 			_, _ = fmt.Fprintf(out, "  Comment:  %s\n", bill.Comment)
 		}
-		if addDate != "" {
+		if addDate != "" || addInteractive {
 			_, _ = fmt.Fprintf(out, "  Date:     %s\n", bill.Date)
 		}
 		if bill.Repeat != "" && bill.Repeat != "n" {
@@ -242,27 +238,428 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Confirm if configured
-	if cfg.ConfirmAdd {
+	// Confirm if configured; the wizard always confirms
+	if cfg.ConfirmAdd || addInteractive {
 		_, _ = fmt.Fprintf(out, "New expense: %s\n", expenseName)
 		printBillSummary()
-		if !confirm(os.Stdin, out, "Add bill?") {
+		if !prompter(cmd).Confirm("Add bill?") {
 			_, _ = fmt.Fprintln(out, "Cancelled.")
 			return nil
 		}
 	}
 
+	// A retry after a timeout may find the bill was created after all
+	if addNoDups {
+		dup, err := findRecentDuplicate(client, bill)
+		if err != nil {
+			return err
+		}
+		if dup != nil {
+			_, _ = fmt.Fprintf(out, "Skipped: bill #%d with the same name, amount, date, and payer was just added\n", dup.ID)
+			return nil
+		}
+	}
+
 	// Create the bill
-	if err := client.CreateBill(ProjectID, bill); err != nil {
+	billID, err := client.CreateBill(ProjectID, bill)
+	if err != nil {
 		return fmt.Errorf("creating bill: %w", err)
 	}
+	saveLastAction(cmd, cache.LastAction{Kind: cache.ActionAdd, BillID: billID})
 
 	_, _ = fmt.Fprintf(out, "Added expense: %s\n", expenseName)
 	printBillSummary()
 	return nil
 }
 
-func parseDate(s string) (string, error) {
+// findRecentDuplicate returns a bill changed within duplicateWindow that has
+// the same name, amount, date, and payer as bill, or nil if there's none
+func findRecentDuplicate(client *api.Client, bill api.Bill) (*api.BillResponse, error) {
+	update, err := client.GetBillsSince(ProjectID, now().Add(-duplicateWindow).Unix())
+	if err != nil {
+		return nil, fmt.Errorf("checking for duplicates: %w", err)
+	}
+	for i, existing := range update.Bills {
+		if strings.TrimSpace(existing.What) == strings.TrimSpace(bill.What) &&
+			math.Abs(existing.Amount-bill.Amount) < 0.005 &&
+			existing.Date == bill.Date &&
+			existing.PayerID == bill.PayerID {
+			return &update.Bills[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// reimbursementName is the name Cospend shows for api.CategoryReimbursement
+const reimbursementName = "Reimbursement"
+
+// amountFormats are examples of the amount formats parseBillAmount accepts
+const amountFormats = "25, 25.50, 25,50, 1,234.50, $25 or 25 EUR"
+
+// parseBillAmount parses the amount of a new bill using locale. Zero and
+// negative amounts are almost always typos, so they're rejected unless
+// allowed with --allow-zero or --allow-negative.
+func parseBillAmount(s, locale string) (float64, error) {
+	amount, err := format.ParseAmount(s, locale)
+	if err != nil {
+		return 0, fmt.Errorf("%w (amounts look like %s)", err, amountFormats)
+	}
+	if amount == 0 && !allowZero {
+		return 0, fmt.Errorf("amount %q is zero (use --allow-zero to add it anyway)", s)
+	}
+	if amount < 0 && !allowNegative {
+		return 0, fmt.Errorf("amount %q is negative (use --allow-negative for refunds)", s)
+	}
+	return amount, nil
+}
+
+// readCommentFile reads a bill comment from path, or from stdin when path is
+// "-". Newlines are kept, except for those at the end of the file.
+func readCommentFile(cmd *cobra.Command, path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading comment file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// flagOrDefault returns the value of the named flag if it was given, even if
+// empty, or else def. usedDefault reports whether def was returned.
+func flagOrDefault(cmd *cobra.Command, name, value, def string) (result string, usedDefault bool) {
+	if cmd.Flags().Changed(name) {
+		return value, false
+	}
+	return def, def != ""
+}
+
+// billFromFlags builds a bill from the command line flags, falling back to the
+// defaults in cfg for flags that weren't given
+func billFromFlags(cmd *cobra.Command, project *api.Project, cfg *config.Config, expenseName string, amount float64, loc *time.Location) (api.Bill, error) {
+	// Resolve payer
+	payerUsername := paidBy
+	if payerUsername == "" {
+		payerUsername = cfg.User
+	}
+	payerID, err := resolveAddMember(cmd, project, payerUsername)
+	if err != nil {
+		return api.Bill{}, fmt.Errorf("resolving payer: %w", err)
+	}
+
+	// Resolve owed members
+	var owedIDs []int
+	if len(paidFor) == 0 {
+		// Default to payer only
+		owedIDs = []int{payerID}
+	} else {
+		for _, username := range paidFor {
+			memberID, err := resolveAddMember(cmd, project, username)
+			if err != nil {
+				return api.Bill{}, fmt.Errorf("resolving owed member: %w", err)
+			}
+			owedIDs = append(owedIDs, memberID)
+		}
+	}
+
+	// Resolve date
+	billDate := now().In(loc).Format("2006-01-02")
+	if addDate != "" {
+		parsed, err := parseDate(addDate, loc)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		billDate = parsed
+	}
+
+	bill := api.Bill{
+		What:    expenseName,
+		Amount:  amount,
+		PayerID: payerID,
+		OwedTo:  owedIDs,
+		Date:    billDate,
+		Comment: comment,
+	}
+
+	// Resolve optional category, reporting a configured default that no
+	// longer matches the project. Reimbursements use Cospend's own category.
+	categoryName, usedDefault := flagOrDefault(cmd, "category", category, cfg.CategoryFor(project.ID))
+	if reimbursement {
+		bill.CategoryID = api.CategoryReimbursement
+	} else if categoryName != "" {
+		categoryID, err := resolveCategory(cmd, project, categoryName)
+		if err != nil {
+			if usedDefault {
+				return api.Bill{}, fmt.Errorf("resolving default category %q (set with 'cospend config set default-category'): %w", categoryName, err)
+			}
+			return api.Bill{}, fmt.Errorf("resolving category: %w", err)
+		}
+		bill.CategoryID = categoryID
+	}
+
+	// Resolve optional payment method, likewise
+	method, usedDefault := flagOrDefault(cmd, "method", paymentMethod, cfg.PaymentModeFor(project.ID))
+	if method != "" {
+		methodID, err := resolvePaymentMode(cmd, project, method)
+		if err != nil {
+			if usedDefault {
+				return api.Bill{}, fmt.Errorf("resolving default payment method %q (set with 'cospend config set default-method'): %w", method, err)
+			}
+			return api.Bill{}, fmt.Errorf("resolving payment method: %w", err)
+		}
+		bill.PaymentModeID = methodID
+	}
+
+	return bill, nil
+}
+
+// copyLastBill fills in the owed members, category, and payment method of
+// bill from the latest bill (by timestamp) with the same payer. Fields given
+// with flags are kept; the copied ones replace configured defaults.
+func copyLastBill(cmd *cobra.Command, client *api.Client, project *api.Project, bill *api.Bill) error {
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching bills...")
+	bills, err := client.GetBills(ProjectID)
+	stop()
+	if err != nil {
+		return fmt.Errorf("fetching bills: %w", err)
+	}
+
+	var last *api.BillResponse
+	for i, b := range bills {
+		if b.PayerID != bill.PayerID {
+			continue
+		}
+		if last == nil || b.Timestamp > last.Timestamp || (b.Timestamp == last.Timestamp && b.ID > last.ID) {
+			last = &bills[i]
+		}
+	}
+	if last == nil {
+		payer := strconv.Itoa(bill.PayerID)
+		for _, m := range project.Members {
+			if m.ID == bill.PayerID {
+				payer = m.Name
+			}
+		}
+		return fmt.Errorf("no bill paid by %s to copy (--copy-last)", payer)
+	}
+
+	if !cmd.Flags().Changed("for") && len(last.Owers) > 0 {
+		bill.OwedTo = nil
+		for _, o := range last.Owers {
+			bill.OwedTo = append(bill.OwedTo, o.ID)
+		}
+	}
+	if !cmd.Flags().Changed("category") && !reimbursement {
+		bill.CategoryID = last.CategoryID
+	}
+	if !cmd.Flags().Changed("method") {
+		bill.PaymentModeID = last.PaymentModeID
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Copying from %q (#%d, %s)\n", last.What, last.ID, last.Date)
+	return nil
+}
+
+// promptBill builds a bill by prompting for each field in turn. Arguments and
+// flags that were given, or the defaults in cfg, are offered as the defaults.
+// The amount is parsed using locale.
+func promptBill(cmd *cobra.Command, project *api.Project, cfg *config.Config, locale string, loc *time.Location, args []string) (api.Bill, error) {
+	out := cmd.OutOrStdout()
+	bill := api.Bill{Comment: comment}
+
+	// Name
+	defaultName := ""
+	if len(args) > 0 {
+		defaultName = args[0]
+	}
+	for bill.What == "" {
+		name, err := prompter(cmd).Default("Name", defaultName)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		bill.What = name
+	}
+
+	// Amount
+	defaultAmount := ""
+	if len(args) > 1 {
+		defaultAmount = args[1]
+	}
+	for {
+		input, err := prompter(cmd).Default("Amount", defaultAmount)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		amount, err := parseBillAmount(input, locale)
+		if err == nil {
+			bill.Amount = amount
+			break
+		}
+		_, _ = fmt.Fprintln(out, err)
+	}
+
+	// Members to pick from; deactivated ones are hidden with --active-only
+	var members []api.Member
+	var memberOptions []prompt.Option
+	for _, m := range project.Members {
+		if activeOnly && !m.Activated {
+			continue
+		}
+		opt := prompt.Option{Label: m.Name}
+		if !m.Activated {
+			opt.Description = "deactivated"
+		}
+		members = append(members, m)
+		memberOptions = append(memberOptions, opt)
+	}
+	if len(members) == 0 {
+		return api.Bill{}, fmt.Errorf("project has no members to choose from")
+	}
+	memberIndex := func(username string) int {
+		id, err := cache.ResolveMember(project, username)
+		if err != nil {
+			return -1
+		}
+		for i, m := range members {
+			if m.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// Payer
+	payerUsername := paidBy
+	if payerUsername == "" {
+		payerUsername = cfg.User
+	}
+	_, _ = fmt.Fprintln(out, "Paid by:")
+	payer, err := prompter(cmd).Select(memberOptions, max(memberIndex(payerUsername), 0))
+	if err != nil {
+		return api.Bill{}, err
+	}
+	bill.PayerID = members[payer].ID
+
+	// Owed members, defaulting to the payer only
+	checked := make([]bool, len(members))
+	if len(paidFor) == 0 {
+		checked[payer] = true
+	}
+	for _, username := range paidFor {
+		if i := memberIndex(username); i >= 0 {
+			checked[i] = true
+		}
+	}
+	for len(bill.OwedTo) == 0 {
+		_, _ = fmt.Fprintln(out, "Paid for:")
+		owers, err := prompter(cmd).MultiSelect(memberOptions, checked)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		for _, i := range owers {
+			bill.OwedTo = append(bill.OwedTo, members[i].ID)
+		}
+		if len(bill.OwedTo) == 0 {
+			_, _ = fmt.Fprintln(out, "Select at least one member.")
+		}
+	}
+
+	// Category
+	if reimbursement {
+		bill.CategoryID = api.CategoryReimbursement
+	} else if len(project.Categories) > 0 {
+		options := []prompt.Option{{Label: "(none)"}}
+		initial := 0
+		categoryName, _ := flagOrDefault(cmd, "category", category, cfg.CategoryFor(project.ID))
+		defaultID, _ := cache.ResolveCategory(project, categoryName)
+		for i, c := range project.Categories {
+			options = append(options, prompt.Option{Label: withIcon(c.Icon, c.Name)})
+			if categoryName != "" && c.ID == defaultID {
+				initial = i + 1
+			}
+		}
+		_, _ = fmt.Fprintln(out, "Category:")
+		idx, err := prompter(cmd).Select(options, initial)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		if idx > 0 {
+			bill.CategoryID = project.Categories[idx-1].ID
+		}
+	}
+
+	// Payment method
+	if len(project.PaymentModes) > 0 {
+		options := []prompt.Option{{Label: "(none)"}}
+		initial := 0
+		method, _ := flagOrDefault(cmd, "method", paymentMethod, cfg.PaymentModeFor(project.ID))
+		defaultID, _ := cache.ResolvePaymentMode(project, method)
+		for i, pm := range project.PaymentModes {
+			options = append(options, prompt.Option{Label: withIcon(pm.Icon, pm.Name)})
+			if method != "" && pm.ID == defaultID {
+				initial = i + 1
+			}
+		}
+		_, _ = fmt.Fprintln(out, "Payment method:")
+		idx, err := prompter(cmd).Select(options, initial)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		if idx > 0 {
+			bill.PaymentModeID = project.PaymentModes[idx-1].ID
+		}
+	}
+
+	// Comment
+	bill.Comment, err = prompter(cmd).Default("Comment (optional)", bill.Comment)
+	if err != nil {
+		return api.Bill{}, err
+	}
+
+	// Date
+	defaultDate := addDate
+	if defaultDate == "" {
+		defaultDate = now().In(loc).Format("2006-01-02")
+	}
+	for bill.Date == "" {
+		input, err := prompter(cmd).Default("Date", defaultDate)
+		if err != nil {
+			return api.Bill{}, err
+		}
+		parsed, err := parseDate(input, loc)
+		if err != nil {
+			_, _ = fmt.Fprintln(out, err)
+			continue
+		}
+		bill.Date = parsed
+	}
+
+	return bill, nil
+}
+
+// resolveAddMember resolves a member for a new bill. With --active-only,
+// deactivated members are rejected; otherwise a warning is printed.
+func resolveAddMember(cmd *cobra.Command, project *api.Project, username string) (int, error) {
+	if activeOnly {
+		return cache.ResolveActiveMember(project, username)
+	}
+	memberID, err := cache.ResolveMember(project, username)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range project.Members {
+		if m.ID == memberID && !m.Activated {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: member %s is deactivated\n", m.Name)
+		}
+	}
+	return memberID, nil
+}
+
+func parseDate(s string, loc *time.Location) (string, error) {
 	s = strings.TrimSpace(s)
 
 	// Try relative date: -1d, +2d, -1w, +2w, -1m, +2m
@@ -274,14 +671,14 @@ func parseDate(s string) (string, error) {
 			if s[0] == '-' {
 				value = -value
 			}
-			now := time.Now()
+			today := now().In(loc)
 			switch unit {
 			case 'd':
-				return now.AddDate(0, 0, value).Format("2006-01-02"), nil
+				return today.AddDate(0, 0, value).Format("2006-01-02"), nil
 			case 'w':
-				return now.AddDate(0, 0, value*7).Format("2006-01-02"), nil
+				return today.AddDate(0, 0, value*7).Format("2006-01-02"), nil
 			case 'm':
-				return now.AddDate(0, value, 0).Format("2006-01-02"), nil
+				return today.AddDate(0, value, 0).Format("2006-01-02"), nil
 			}
 		}
 	}
@@ -293,7 +690,7 @@ func parseDate(s string) (string, error) {
 
 	// Try short date MM-DD (assume current year)
 	if t, err := time.Parse("01-02", s); err == nil {
-		return fmt.Sprintf("%d-%s", time.Now().Year(), t.Format("01-02")), nil
+		return fmt.Sprintf("%d-%s", now().In(loc).Year(), t.Format("01-02")), nil
 	}
 
 	return "", fmt.Errorf("invalid date: %s (expected YYYY-MM-DD, MM-DD, or relative like -1d, +2w)", s)
@@ -3,13 +3,18 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/config"
 )
 
 // OCSResponse for test responses
@@ -43,8 +48,16 @@ func resetFlags() {
 	convertTo = ""
 	paymentMethod = ""
 	comment = ""
+	commentFile = ""
+	allowZero = false
+	allowNegative = false
+	reimbursement = false
 	addDate = ""
 	repeat = ""
+	activeOnly = false
+	addInteractive = false
+	addCopyLast = false
+	addNoDups = false
 	editName = ""
 	editAmount = ""
 	editCategory = ""
@@ -54,7 +67,28 @@ func resetFlags() {
 	editComment = ""
 	editDate = ""
 	editRepeat = ""
+	copyName = ""
+	copyAmount = ""
+	copyCategory = ""
+	copyPaidBy = ""
+	copyPaidFor = nil
+	copyPaymentMethod = ""
+	copyComment = ""
+	copyDate = ""
+	copyRepeat = ""
+	importDryRun = false
+	categoryIcon = ""
+	categoryColor = ""
+	categoryEditName = ""
+	categoryEditIcon = ""
+	categoryEditColor = ""
+	methodIcon = ""
+	methodColor = ""
+	memberUserID = ""
+	memberYes = false
 	infoCached = false
+	NoCache = false
+	NoInteractive = false
 }
 
 func setupTestEnv(t *testing.T, domain string) func() {
@@ -67,7 +101,7 @@ func setupTestEnv(t *testing.T, domain string) func() {
 	t.Setenv("NEXTCLOUD_DOMAIN", domain)
 	t.Setenv("NEXTCLOUD_USER", "testuser")
 	t.Setenv("NEXTCLOUD_PASSWORD", "testpass")
-	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 
 	return func() {
 		resetFlags()
@@ -155,6 +189,196 @@ func TestAddCommandInvalidAmount(t *testing.T) {
 	}
 }
 
+func TestAddCommandAmountValidation(t *testing.T) {
+	project := api.Project{
+		ID:   "test-project",
+		Name: "Test",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser", Activated: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantAmount string
+		wantErr    string
+	}{
+		{name: "zero", args: []string{"0"}, wantErr: "--allow-zero"},
+		{name: "zero with symbol", args: []string{"$0.00"}, wantErr: "--allow-zero"},
+		{name: "zero allowed", args: []string{"0", "--allow-zero"}, wantAmount: "0.00"},
+		{name: "negative", args: []string{"--", "-12.50"}, wantErr: "--allow-negative"},
+		{name: "negative with symbol", args: []string{"$-12.50"}, wantErr: "--allow-negative"},
+		{name: "negative allowed", args: []string{"--allow-negative", "--", "-12.50"}, wantAmount: "-12.50"},
+		{name: "unknown currency", args: []string{"12 bucks"}, wantErr: `unrecognized currency or text "bucks"`},
+		{name: "misplaced separator", args: []string{"$1,2,3"}, wantErr: "misplaced decimal or thousands separator"},
+		{name: "hint", args: []string{"abc"}, wantErr: "amounts look like"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedAmount string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = r.ParseForm()
+					receivedAmount = r.Form.Get("amount")
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				default:
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"Refund"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if receivedAmount != tt.wantAmount {
+				t.Errorf("amount = %q, want %q", receivedAmount, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestAddCommandRefund(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser", Activated: true},
+		},
+		Categories: []api.Category{{ID: 1, Name: "Food"}},
+	}
+
+	var receivedBill map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = r.ParseForm()
+			receivedBill = make(map[string]string)
+			for k, v := range r.Form {
+				receivedBill[k] = v[0]
+			}
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewAddCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"Refund", "--allow-negative", "--reimbursement", "--", "-45"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if receivedBill["amount"] != "-45.00" {
+		t.Errorf("amount = %q, want -45.00", receivedBill["amount"])
+	}
+	if receivedBill["categoryId"] != "-11" {
+		t.Errorf("categoryId = %q, want -11", receivedBill["categoryId"])
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Amount:   -$ 45.00") {
+		t.Errorf("Expected negative amount in summary, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Category: Reimbursement") {
+		t.Errorf("Expected reimbursement category in summary, got:\n%s", output)
+	}
+
+	resetFlags()
+	ProjectID = "test-project"
+	cmd = NewAddCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"Refund", "45", "--reimbursement", "-c", "food"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --reimbursement with --category")
+	}
+}
+
+func TestAddCommandFormattedAmount(t *testing.T) {
+	project := api.Project{
+		ID:      "test-project",
+		Name:    "Test",
+		Members: []api.Member{{ID: 1, Name: "testuser", UserID: "testuser"}},
+	}
+
+	var amount string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills" {
+			_ = r.ParseForm()
+			amount = r.Form.Get("amount")
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	tests := []struct {
+		input  string
+		locale string
+		want   string
+	}{
+		{"$1,234.50", "en_US", "1234.50"},
+		{"45,50", "en_US", "45.50"},
+		{"45,50", "de_DE", "45.50"},
+		{"1.234", "de_DE", "1234.00"},
+		{"1.234,50 €", "de_DE", "1234.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input+" "+tt.locale, func(t *testing.T) {
+			resetFlags()
+			Locale = tt.locale
+			defer func() { Locale = "" }()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetArgs([]string{"Rent", tt.input})
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if amount != tt.want {
+				t.Errorf("amount = %s, want %s", amount, tt.want)
+			}
+		})
+	}
+}
+
 func TestAddCommandSuccess(t *testing.T) {
 	project := api.Project{
 		ID:   "test-project",
@@ -191,7 +415,7 @@ func TestAddCommandSuccess(t *testing.T) {
 					receivedBill[k] = v[0]
 				}
 			}
-			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]int{"id": 1}))
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
 			return
 		}
 	}))
@@ -272,7 +496,7 @@ func TestAddCommandWithAllFlags(t *testing.T) {
 					receivedBill[k] = v[0]
 				}
 			}
-			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]int{"id": 1}))
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
 			return
 		}
 	}))
@@ -416,6 +640,352 @@ func TestAddCommandPaymentModeNotFound(t *testing.T) {
 	}
 }
 
+// writeTestConfig writes cfg as the config file that config.Load finds. The
+// env vars from setupTestEnv still override its credentials.
+func writeTestConfig(t *testing.T, cfg config.Config) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Failed to encode config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "cospend.json"), data, 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestAddCommandDefaultPaymentMethod(t *testing.T) {
+	project := api.Project{
+		ID:   "test-project",
+		Name: "Test Project",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser"},
+		},
+		PaymentModes: []api.PaymentMode{
+			{ID: 1, Name: "Cash"},
+			{ID: 2, Name: "Card"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		global        string
+		projectMethod string
+		args          []string
+		wantMethod    string
+		wantErr       string
+	}{
+		{name: "no default", wantMethod: ""},
+		{name: "global default", global: "cash", wantMethod: "1"},
+		{name: "project default wins", global: "cash", projectMethod: "card", wantMethod: "2"},
+		{name: "flag wins", global: "cash", projectMethod: "card", args: []string{"-m", "cash"}, wantMethod: "1"},
+		{name: "empty flag skips default", global: "cash", args: []string{"-m", ""}, wantMethod: ""},
+		{name: "stale default", projectMethod: "bitcoin", wantErr: `default payment method "bitcoin"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBill map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = r.ParseForm()
+					receivedBill = make(map[string]string)
+					for k, v := range r.Form {
+						receivedBill[k] = v[0]
+					}
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				default:
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			cfg := config.Config{DefaultPaymentMode: tt.global}
+			cfg.SetProject("test-project", config.ProjectConfig{DefaultPaymentMode: tt.projectMethod})
+			writeTestConfig(t, cfg)
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"Coffee", "4.50"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if receivedBill["paymentModeId"] != tt.wantMethod {
+				t.Errorf("paymentModeId = %q, want %q", receivedBill["paymentModeId"], tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestAddCommandDefaultCategory(t *testing.T) {
+	project := api.Project{
+		ID:   "groceries",
+		Name: "Groceries",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser"},
+		},
+		Categories: []api.Category{
+			{ID: 4, Name: "Groceries"},
+			{ID: 5, Name: "Household"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		global       string
+		projectCat   string
+		args         []string
+		wantCategory string
+		wantErr      string
+	}{
+		{name: "no default", wantCategory: ""},
+		{name: "global default", global: "household", wantCategory: "5"},
+		{name: "project default wins", global: "household", projectCat: "groceries", wantCategory: "4"},
+		{name: "flag wins", projectCat: "groceries", args: []string{"-c", "household"}, wantCategory: "5"},
+		{name: "renamed default", projectCat: "Food", wantErr: `default category "Food"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBill map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/groceries/bills":
+					_ = r.ParseForm()
+					receivedBill = make(map[string]string)
+					for k, v := range r.Form {
+						receivedBill[k] = v[0]
+					}
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				default:
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			cfg := config.Config{DefaultCategory: tt.global}
+			cfg.SetProject("groceries", config.ProjectConfig{DefaultCategory: tt.projectCat})
+			writeTestConfig(t, cfg)
+
+			ProjectID = "groceries"
+			cmd := NewAddCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"Milk", "2.00"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if receivedBill["categoryId"] != tt.wantCategory {
+				t.Errorf("categoryId = %q, want %q", receivedBill["categoryId"], tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestAddCommandCopyLast(t *testing.T) {
+	project := api.Project{
+		ID: "test-project",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser"},
+			{ID: 2, Name: "bob", UserID: "bob"},
+			{ID: 3, Name: "carol", UserID: "carol"},
+		},
+		Categories:   []api.Category{{ID: 4, Name: "Groceries"}, {ID: 5, Name: "Coffee"}},
+		PaymentModes: []api.PaymentMode{{ID: 7, Name: "Cash"}, {ID: 8, Name: "Card"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 10, What: "Milk", Date: "2026-03-01", PayerID: 1, Timestamp: 100, Owers: []api.Ower{{ID: 1}, {ID: 2}}, CategoryID: 4, PaymentModeID: 7},
+		{ID: 11, What: "Latte", Date: "2026-03-02", PayerID: 1, Timestamp: 300, Owers: []api.Ower{{ID: 1}, {ID: 2}, {ID: 3}}, CategoryID: 5, PaymentModeID: 8},
+		{ID: 12, What: "Bread", Date: "2026-03-03", PayerID: 2, Timestamp: 500, Owers: []api.Ower{{ID: 2}}, CategoryID: 4},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "copies my latest bill",
+			want: map[string]string{"what": "Coffee", "amount": "4.00", "payer": "1", "payedFor": "1,2,3", "categoryId": "5", "paymentModeId": "8"},
+		},
+		{
+			name: "flags win",
+			args: []string{"-c", "groceries", "-f", "bob"},
+			want: map[string]string{"payedFor": "2", "categoryId": "4", "paymentModeId": "8"},
+		},
+		{
+			name: "latest bill of the given payer",
+			args: []string{"-b", "bob"},
+			want: map[string]string{"payer": "2", "payedFor": "2", "categoryId": "4", "paymentModeId": ""},
+		},
+		{
+			name:    "payer without bills",
+			args:    []string{"-b", "carol"},
+			wantErr: "no bill paid by carol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills" && r.Method == "POST":
+					_ = r.ParseForm()
+					received = r.PostForm
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 13))
+				case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+				case r.URL.Path == "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				default:
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(append([]string{"Coffee", "4", "--copy-last"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for k, v := range tt.want {
+				if got := received.Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+			if !strings.Contains(stdout.String(), "Copying from") {
+				t.Errorf("Output should say which bill was copied:\n%s", stdout.String())
+			}
+		})
+	}
+}
+
+func TestAddCommandCommentFile(t *testing.T) {
+	project := api.Project{
+		ID:   "test-project",
+		Name: "Test Project",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser"},
+		},
+	}
+
+	receipt := "Milk 2.00\nBread 3.50\n\nTotal 5.50\n"
+	path := filepath.Join(t.TempDir(), "receipt.txt")
+	if err := os.WriteFile(path, []byte(receipt), 0600); err != nil {
+		t.Fatalf("Failed to write comment file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{name: "file", args: []string{"--comment-file", path}, want: "Milk 2.00\nBread 3.50\n\nTotal 5.50"},
+		{name: "stdin", args: []string{"--comment-file", "-"}, stdin: "Line 1\r\nLine 2\r\n", want: "Line 1\r\nLine 2"},
+		{name: "with comment", args: []string{"--comment-file", path, "-o", "note"}, wantErr: true},
+		{name: "missing file", args: []string{"--comment-file", filepath.Join(t.TempDir(), "nope.txt")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedComment string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = r.ParseForm()
+					receivedComment = r.Form.Get("comment")
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				default:
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			cmd.SetArgs(append([]string{"Groceries", "5.50"}, tt.args...))
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if receivedComment != tt.want {
+				t.Errorf("comment = %q, want %q", receivedComment, tt.want)
+			}
+		})
+	}
+}
+
 func TestAddCommandCurrencyNotFound(t *testing.T) {
 	project := api.Project{
 		ID:   "test-project",
@@ -500,6 +1070,8 @@ func TestAddCommandAPIError(t *testing.T) {
 }
 
 func TestParseDate(t *testing.T) {
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
 	tests := []struct {
 		name     string
 		input    string
@@ -507,21 +1079,21 @@ func TestParseDate(t *testing.T) {
 		wantErr  bool
 	}{
 		{"full date", "2026-03-15", "2026-03-15", false},
-		{"short date", "03-15", fmt.Sprintf("%d-03-15", time.Now().Year()), false},
+		{"short date", "03-15", "2026-03-15", false},
 		{"with spaces", " 2026-01-01 ", "2026-01-01", false},
-		{"relative -1d", "-1d", time.Now().AddDate(0, 0, -1).Format("2006-01-02"), false},
-		{"relative +2d", "+2d", time.Now().AddDate(0, 0, 2).Format("2006-01-02"), false},
-		{"relative -1w", "-1w", time.Now().AddDate(0, 0, -7).Format("2006-01-02"), false},
-		{"relative +2w", "+2w", time.Now().AddDate(0, 0, 14).Format("2006-01-02"), false},
-		{"relative -1m", "-1m", time.Now().AddDate(0, -1, 0).Format("2006-01-02"), false},
-		{"relative +3m", "+3m", time.Now().AddDate(0, 3, 0).Format("2006-01-02"), false},
+		{"relative -1d", "-1d", "2026-03-09", false},
+		{"relative +2d", "+2d", "2026-03-12", false},
+		{"relative -1w", "-1w", "2026-03-03", false},
+		{"relative +2w", "+2w", "2026-03-24", false},
+		{"relative -1m", "-1m", "2026-02-10", false},
+		{"relative +3m", "+3m", "2026-06-10", false},
 		{"invalid", "not-a-date", "", true},
 		{"invalid short", "13-40", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseDate(tt.input)
+			got, err := parseDate(tt.input, time.Local)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseDate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -560,7 +1132,7 @@ func TestAddCommandWithDate(t *testing.T) {
 					receivedBill[k] = v[0]
 				}
 			}
-			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]int{"id": 1}))
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
 			return
 		}
 	}))
@@ -615,7 +1187,7 @@ func TestAddCommandWithRepeat(t *testing.T) {
 					receivedBill[k] = v[0]
 				}
 			}
-			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]int{"id": 1}))
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
 			return
 		}
 	}))
@@ -704,7 +1276,7 @@ func TestAddCommandDefaultRepeat(t *testing.T) {
 					receivedBill[k] = v[0]
 				}
 			}
-			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]int{"id": 1}))
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
 			return
 		}
 	}))
@@ -733,3 +1305,273 @@ func TestAddCommandDefaultRepeat(t *testing.T) {
 		t.Errorf("Output should not show repeat for default, got:\n%s", stdout.String())
 	}
 }
+
+func TestAddCommandDeactivatedMembers(t *testing.T) {
+	project := api.Project{
+		ID:   "test-project",
+		Name: "Test Project",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser", Activated: true},
+			{ID: 2, Name: "Alex", Activated: false},
+			{ID: 3, Name: "Alex", Activated: true},
+			{ID: 4, Name: "Sam", Activated: false},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      bool
+		wantPayedFor string
+		wantWarning  bool
+	}{
+		{"shared name resolves to activated", []string{"Taxi", "10", "-f", "alex"}, false, "3", false},
+		{"deactivated member warns", []string{"Taxi", "10", "-f", "sam"}, false, "4", true},
+		{"active-only rejects deactivated", []string{"Taxi", "10", "-f", "sam", "--active-only"}, true, "", false},
+		{"active-only accepts activated", []string{"Taxi", "10", "-f", "alex", "--active-only"}, false, "3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payedFor string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = r.ParseForm()
+					payedFor = r.Form.Get("payedFor")
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			var stderr bytes.Buffer
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(&stderr)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if payedFor != tt.wantPayedFor {
+				t.Errorf("payedFor = %q, want %q", payedFor, tt.wantPayedFor)
+			}
+			if got := bytes.Contains(stderr.Bytes(), []byte("Warning: member")); got != tt.wantWarning {
+				t.Errorf("warning = %v, want %v (stderr: %s)", got, tt.wantWarning, stderr.String())
+			}
+		})
+	}
+}
+
+func TestAddCommandInteractive(t *testing.T) {
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
+	project := api.Project{
+		ID:   "test-project",
+		Name: "Test Project",
+		Members: []api.Member{
+			{ID: 1, Name: "testuser", UserID: "testuser", Activated: true},
+			{ID: 2, Name: "Alice", UserID: "alice", Activated: true},
+			{ID: 3, Name: "Bob", UserID: "bob", Activated: true},
+		},
+		Categories: []api.Category{
+			{ID: 4, Name: "Groceries"},
+			{ID: 5, Name: "Restaurant"},
+		},
+		PaymentModes: []api.PaymentMode{
+			{ID: 3, Name: "Credit Card"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		input     []string
+		wantBill  map[string]string
+		wantSaved bool
+	}{
+		{
+			name: "all fields",
+			// name, invalid then valid amount, payer, owers, category, method, comment, date, confirm
+			input: []string{"Dinner", "abc", "45.50", "2", "2,3", "3", "", "Team dinner", "2026-01-15", "y"},
+			wantBill: map[string]string{
+				"what":          "Dinner",
+				"amount":        "45.50",
+				"payer":         "2",
+				"payedFor":      "2,3",
+				"categoryId":    "5",
+				"paymentModeId": "",
+				"comment":       "Team dinner",
+				"date":          "2026-01-15",
+			},
+			wantSaved: true,
+		},
+		{
+			name: "defaults",
+			// every prompt accepts its default: payer is the current user, owers the payer
+			input: []string{"Coffee", "3", "", "", "", "", "", "", ""},
+			wantBill: map[string]string{
+				"what":     "Coffee",
+				"payer":    "1",
+				"payedFor": "1",
+				"date":     "2026-03-10",
+			},
+			wantSaved: true,
+		},
+		{
+			name:  "cancelled",
+			input: []string{"Coffee", "3", "", "", "", "", "", "", "n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBill map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				case "/ocs/v2.php/cloud/user":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US", "language": "en"}))
+				case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+					_ = r.ParseForm()
+					receivedBill = make(map[string]string)
+					for k, v := range r.Form {
+						if len(v) > 0 {
+							receivedBill[k] = v[0]
+						}
+					}
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewAddCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetIn(strings.NewReader(strings.Join(tt.input, "\n") + "\n"))
+			cmd.SetArgs([]string{"--interactive"})
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v\n%s", err, stdout.String())
+			}
+
+			if !tt.wantSaved {
+				if receivedBill != nil {
+					t.Errorf("Expected no bill to be created, got %v", receivedBill)
+				}
+				if !strings.Contains(stdout.String(), "Cancelled.") {
+					t.Errorf("Expected cancellation message, got:\n%s", stdout.String())
+				}
+				return
+			}
+			if receivedBill == nil {
+				t.Fatalf("Expected a bill to be created, output:\n%s", stdout.String())
+			}
+			for key, want := range tt.wantBill {
+				if receivedBill[key] != want {
+					t.Errorf("Wrong %s: got %q, want %q", key, receivedBill[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddCommandInteractiveArgs(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	ProjectID = "test-project"
+	NoInteractive = true
+	cmd := NewAddCommand()
+	cmd.SetArgs([]string{"--interactive", "Dinner"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--no-interactive") {
+		t.Errorf("Expected --no-interactive conflict error, got %v", err)
+	}
+
+	resetFlags()
+	ProjectID = "test-project"
+	cmd = NewAddCommand()
+	cmd.SetArgs([]string{"Dinner"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for a missing amount without --interactive")
+	}
+}
+
+func TestAddCommandNoDuplicates(t *testing.T) {
+	project := api.Project{
+		ID:      "test-project",
+		Members: []api.Member{{ID: 1, Name: "testuser", UserID: "testuser"}},
+	}
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
+	var recent []api.BillResponse
+	var lastChanged string
+	var created int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills" && r.Method == "POST":
+			created++
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 13))
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			lastChanged = r.URL.Query().Get("lastChanged")
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": recent}))
+		case r.URL.Path == "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	run := func() string {
+		t.Helper()
+		resetFlags()
+		ProjectID = "test-project"
+		cmd := NewAddCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"Coffee", "4", "--no-duplicates"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+		return stdout.String()
+	}
+
+	// Only a different bill was added recently
+	recent = []api.BillResponse{{ID: 12, What: "Coffee", Amount: 5, Date: "2026-03-10", PayerID: 1}}
+	run()
+	if created != 1 {
+		t.Fatalf("Expected the bill to be created, created %d", created)
+	}
+	if want := strconv.FormatInt(time.Date(2026, 3, 10, 11, 59, 0, 0, time.Local).Unix(), 10); lastChanged != want {
+		t.Errorf("lastChanged = %s, want %s (one minute ago)", lastChanged, want)
+	}
+
+	// The first attempt went through after all
+	recent = append(recent, api.BillResponse{ID: 13, What: "Coffee", Amount: 4, Date: "2026-03-10", PayerID: 1})
+	output := run()
+	if created != 1 {
+		t.Errorf("Duplicate should be skipped, created %d", created)
+	}
+	if !strings.Contains(output, "Skipped: bill #13") {
+		t.Errorf("Expected a skipped message, got:\n%s", output)
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser is a function variable to allow mocking in tests
+var openBrowser = openBrowserDefault
+
+// detectHeadless is a function variable to allow mocking in tests
+var detectHeadless = detectHeadlessDefault
+
+// detectHeadlessDefault reports whether a browser likely can't be opened on this
+// machine: an SSH session, or a Unix system without a graphical display
+func detectHeadlessDefault() bool {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return true
+	}
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return false
+	}
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// openBrowserDefault opens the given URL in the default browser
+func openBrowserDefault(url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", url)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	return cmd.Start()
+}
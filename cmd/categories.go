@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	categoryIcon  string
+	categoryColor string
+
+	categoryEditName  string
+	categoryEditIcon  string
+	categoryEditColor string
+)
+
+// hexColorRegex matches #rgb and #rrggbb colors
+var hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// NewCategoriesCommand creates the categories command with subcommands
+func NewCategoriesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "categories",
+		Aliases: []string{"category", "cat"},
+		Short:   "Manage project categories",
+		Long:    `Manage the bill categories of a Cospend project.`,
+	}
+
+	cmd.AddCommand(newCategoriesAddCommand())
+	cmd.AddCommand(newCategoriesEditCommand())
+
+	return cmd
+}
+
+func newCategoriesAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a category to a project",
+		Long: `Add a category to a Cospend project.
+
+Examples:
+  cospend categories add Groceries -p myproject
+  cospend categories add Restaurants -p myproject --icon 🍔 --color "#ff0000"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCategoriesAdd,
+	}
+
+	cmd.Flags().StringVar(&categoryIcon, "icon", "", "Category icon (usually an emoji)")
+	cmd.Flags().StringVar(&categoryColor, "color", "", "Category color as a hex string (e.g., #ff0000)")
+
+	return cmd
+}
+
+func runCategoriesAdd(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("category name is required")
+	}
+
+	color, err := normalizeHexColor(categoryColor)
+	if err != nil {
+		return err
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	categoryID, err := client.CreateCategory(ProjectID, api.Category{
+		Name:  name,
+		Icon:  categoryIcon,
+		Color: color,
+	})
+	if err != nil {
+		return fmt.Errorf("creating category: %w", err)
+	}
+
+	// Drop the cached project so the new category can be resolved by name
+	if err := cache.Invalidate(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Added category #%d: %s\n", categoryID, name)
+	return nil
+}
+
+func newCategoriesEditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit <id|name>",
+		Short: "Edit a category of a project",
+		Long: `Rename a category or change its icon or color. Only the given fields are changed.
+
+Examples:
+  cospend categories edit Restaurants -p myproject --name "Eating out"
+  cospend categories edit 12 -p myproject --icon 🍕 --color "#ffaa00"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCategoriesEdit,
+	}
+
+	cmd.Flags().StringVar(&categoryEditName, "name", "", "New category name")
+	cmd.Flags().StringVar(&categoryEditIcon, "icon", "", "New category icon (usually an emoji)")
+	cmd.Flags().StringVar(&categoryEditColor, "color", "", "New category color as a hex string (e.g., #ff0000)")
+
+	return cmd
+}
+
+func runCategoriesEdit(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	changes := api.Category{
+		Name: strings.TrimSpace(categoryEditName),
+		Icon: categoryEditIcon,
+	}
+	if cmd.Flags().Changed("name") && changes.Name == "" {
+		return fmt.Errorf("category name can't be empty")
+	}
+	color, err := normalizeHexColor(categoryEditColor)
+	if err != nil {
+		return err
+	}
+	changes.Color = color
+	if changes == (api.Category{}) {
+		return fmt.Errorf("nothing to change (use --name, --icon, or --color)")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := resolveCategory(cmd, project, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.EditCategory(ProjectID, categoryID, changes); err != nil {
+		return fmt.Errorf("editing category: %w", err)
+	}
+
+	// Drop the cached project so the change shows up in info and name lookups
+	if err := cache.Invalidate(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+	}
+
+	name := changes.Name
+	if name == "" {
+		for _, c := range project.Categories {
+			if c.ID == categoryID {
+				name = c.Name
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Updated category #%d: %s\n", categoryID, name)
+	return nil
+}
+
+// normalizeHexColor validates a hex color, adding the leading # if missing.
+// An empty color is returned unchanged.
+func normalizeHexColor(color string) (string, error) {
+	color = strings.TrimSpace(color)
+	if color == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(color, "#") {
+		color = "#" + color
+	}
+	if !hexColorRegex.MatchString(color) {
+		return "", fmt.Errorf("invalid color: %s (expected hex like #ff0000 or #f00)", color)
+	}
+	return strings.ToLower(color), nil
+}
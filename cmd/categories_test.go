@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+)
+
+func TestNormalizeHexColor(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"#ff0000", "#ff0000", false},
+		{"FF0000", "#ff0000", false},
+		{"#F00", "#f00", false},
+		{"#ff00", "", true},
+		{"red", "", true},
+		{"#gg0000", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := normalizeHexColor(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeHexColor(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeHexColor(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoriesAddCommand(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/category" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_ = r.ParseForm()
+		form = map[string]string{
+			"name":  r.PostForm.Get("name"),
+			"icon":  r.PostForm.Get("icon"),
+			"color": r.PostForm.Get("color"),
+		}
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 12))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	// Seed the cache so we can check it gets invalidated
+	if err := cache.Save("test-project", &api.Project{ID: "test-project"}); err != nil {
+		t.Fatalf("Failed to seed cache: %v", err)
+	}
+
+	ProjectID = "test-project"
+	cmd := NewCategoriesCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"add", "Restaurants", "--icon", "🍔", "--color", "#FF0000"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if form["name"] != "Restaurants" || form["icon"] != "🍔" || form["color"] != "#ff0000" {
+		t.Errorf("Unexpected form data: %v", form)
+	}
+	if !strings.Contains(buf.String(), "Added category #12: Restaurants") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+	if _, ok := cache.Load("test-project"); ok {
+		t.Error("Project cache should be invalidated")
+	}
+}
+
+func TestCategoriesAddInvalidColor(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	ProjectID = "test-project"
+	cmd := NewCategoriesCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"add", "Food", "--color", "red"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for invalid color")
+	}
+}
+
+func TestCategoriesEditCommand(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/category/12" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_ = r.ParseForm()
+		form = r.PostForm
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 12))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	// The category is resolved from the cached project, which is then invalidated
+	if err := cache.Save("test-project", &api.Project{
+		ID:         "test-project",
+		Categories: []api.Category{{ID: 12, Name: "Restaurants"}, {ID: 13, Name: "Groceries"}},
+	}); err != nil {
+		t.Fatalf("Failed to seed cache: %v", err)
+	}
+
+	ProjectID = "test-project"
+	cmd := NewCategoriesCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"edit", "restaurants", "--color", "FFAA00"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Only the changed field is sent
+	want := url.Values{"color": {"#ffaa00"}}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("Form data = %v, want %v", form, want)
+	}
+	if !strings.Contains(buf.String(), "Updated category #12: Restaurants") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+	if _, ok := cache.Load("test-project"); ok {
+		t.Error("Project cache should be invalidated")
+	}
+}
+
+func TestCategoriesEditValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no changes", []string{"edit", "Food"}, "nothing to change"},
+		{"empty name", []string{"edit", "Food", "--name", " "}, "can't be empty"},
+		{"invalid color", []string{"edit", "Food", "--color", "red"}, "invalid color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			defer resetFlags()
+
+			ProjectID = "test-project"
+			cmd := NewCategoriesCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,26 +1,322 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/chenasraf/cospend-cli/internal/prompt"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
-// Debug enables debug output when true
+// Debug enables full debug output when true (same as the highest verbosity)
 var Debug bool
 
+// Verbosity is the debug output level (-v for requests, -vv to include bodies)
+var Verbosity int
+
 // ProjectID is the project to operate on (shared across commands)
 var ProjectID string
 
+// ConfigFile is an explicit config file path that overrides the default search
+var ConfigFile string
+
+// EnvFile is the env file to read NEXTCLOUD_* and COSPEND_* variables from
+var EnvFile string
+
+// Locale overrides the locale used for amount formatting (shared across commands)
+var Locale string
+
+// Currency overrides the project currency used for amount formatting (shared across commands)
+var Currency string
+
+// DateFormat is the format for displaying bill dates: iso, us, eu, or a Go layout (shared across commands)
+var DateFormat string
+
+// Timezone is the IANA time zone "today" and relative dates are computed in (shared across commands)
+var Timezone string
+
+// NoInteractive disables interactive prompts, such as picking among ambiguous matches
+var NoInteractive bool
+
+// NoCache skips reading and writing cached project and user data for this invocation
+var NoCache bool
+
+// Quiet hides progress spinners, even on a terminal
+var Quiet bool
+
+// RawResponse dumps every API response body to this file, or to stderr when "-"
+var RawResponse string
+
+// rawResponseFile is the --raw-response file, created on first use and shared
+// by all clients of this run
+var rawResponseFile *outputFile
+
+// now returns the current time for "today" and relative dates. Tests replace
+// it to freeze the clock.
+var now = time.Now
+
+// stdinIsTerminal reports whether the command reads input from a terminal. Tests replace it.
+var stdinIsTerminal = func(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// pickAmbiguous passes id and err through, except when err is a
+// *cache.AmbiguousError in an interactive session: the user then picks one of
+// the matches. With --no-interactive or without a terminal, the error (which
+// lists the candidates) is returned as-is.
+func pickAmbiguous(cmd *cobra.Command, id int, err error) (int, error) {
+	var ambErr *cache.AmbiguousError
+	if !errors.As(err, &ambErr) || NoInteractive || !stdinIsTerminal(cmd) {
+		return id, err
+	}
+
+	options := make([]prompt.Option, len(ambErr.Matches))
+	for i, m := range ambErr.Matches {
+		options[i] = prompt.Option{Label: m.Name, Description: fmt.Sprintf("ID %d", m.ID)}
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%q matches several %s names, choose one:\n", ambErr.Query, ambErr.Kind)
+	idx, err := prompter(cmd).Select(options, 0)
+	if err != nil {
+		return 0, err
+	}
+	return ambErr.Matches[idx].ID, nil
+}
+
+// resolveCategory resolves a category name or ID, letting the user pick when
+// the name is ambiguous (see pickAmbiguous)
+func resolveCategory(cmd *cobra.Command, project *api.Project, nameOrID string) (int, error) {
+	id, err := cache.ResolveCategory(project, nameOrID)
+	return pickAmbiguous(cmd, id, err)
+}
+
+// resolvePaymentMode resolves a payment mode name or ID, letting the user pick
+// when the name is ambiguous (see pickAmbiguous)
+func resolvePaymentMode(cmd *cobra.Command, project *api.Project, nameOrID string) (int, error) {
+	id, err := cache.ResolvePaymentMode(project, nameOrID)
+	return pickAmbiguous(cmd, id, err)
+}
+
 // confirm prompts the user with a [Y/n] question and returns true if confirmed.
 // Defaults to yes (empty input = yes).
-func confirm(in io.Reader, out io.Writer, prompt string) bool {
-	_, _ = fmt.Fprintf(out, "%s [Y/n] ", prompt)
-	scanner := bufio.NewScanner(in)
-	if !scanner.Scan() {
-		return false
-	}
-	answer := strings.TrimSpace(strings.ToLower(scanner.Text()))
-	return answer == "" || answer == "y" || answer == "yes"
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	return prompt.New(in, out).Confirm(question)
+}
+
+// createOutputFile creates (or truncates) the file at path, creating parent directories as needed
+func createOutputFile(path string) (*outputFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating output file: %w", err)
+	}
+	return &outputFile{f: f}, nil
+}
+
+// outputFile is a file written by --output and similar flags. The printers
+// writing to it don't report errors, so it keeps the first one for Close.
+type outputFile struct {
+	f      *os.File
+	err    error
+	closed bool
+}
+
+func (o *outputFile) Write(p []byte) (int, error) {
+	if o.err != nil {
+		return 0, o.err
+	}
+	n, err := o.f.Write(p)
+	if err != nil {
+		o.err = err
+	}
+	return n, err
+}
+
+// Close closes the file and returns the first error writing or closing it.
+// Closing it again does nothing.
+func (o *outputFile) Close() error {
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	closeErr := o.f.Close()
+	if o.err != nil {
+		return fmt.Errorf("writing %s: %w", o.f.Name(), o.err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("closing %s: %w", o.f.Name(), closeErr)
+	}
+	return nil
+}
+
+// newClient creates an API client with debug output wired to the command's stderr
+func newClient(cmd *cobra.Command, cfg *config.Config) *api.Client {
+	client := api.NewClient(cfg)
+	client.Verbosity = Verbosity
+	if Debug {
+		client.Verbosity = api.VerbosityBodies
+	}
+	client.DebugWriter = cmd.ErrOrStderr()
+	if RawResponse != "" {
+		client.RawResponseWriter = rawResponseWriter(cmd)
+	}
+	return client
+}
+
+// rawResponseWriter returns where --raw-response dumps go: stderr for "-", or
+// the named file. If the file can't be created, dumps go to stderr instead.
+func rawResponseWriter(cmd *cobra.Command) io.Writer {
+	if RawResponse == "-" {
+		return cmd.ErrOrStderr()
+	}
+	if rawResponseFile == nil {
+		f, err := createOutputFile(RawResponse)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v, writing raw responses to stderr\n", err)
+			return cmd.ErrOrStderr()
+		}
+		rawResponseFile = f
+	}
+	return rawResponseFile
+}
+
+// CloseRawResponse closes the --raw-response file, if one was created, and
+// returns the first error writing or closing it. It's called once the command
+// has run, whether or not it succeeded.
+func CloseRawResponse() error {
+	if rawResponseFile == nil {
+		return nil
+	}
+	err := rawResponseFile.Close()
+	rawResponseFile = nil
+	return err
+}
+
+// dateLayout returns the Go layout for displaying bill dates: the
+// --date-format flag, then the config's date format, then ISO.
+func dateLayout(cfg *config.Config) (string, error) {
+	name := DateFormat
+	if name == "" && cfg != nil {
+		name = cfg.DateFormat
+	}
+	return format.DateLayout(name)
+}
+
+// dateLocation returns the time zone "today" and relative dates are computed
+// in: the --timezone flag, then the config's timezone, then the local zone.
+func dateLocation(cfg *config.Config) (*time.Location, error) {
+	name := Timezone
+	if name == "" && cfg != nil {
+		name = cfg.Timezone
+	}
+	return loadTimezone(name)
+}
+
+// loadTimezone returns the time zone named by an IANA name like Europe/Berlin,
+// or the local zone when name is empty
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s (use an IANA name like Europe/Berlin, or UTC)", name)
+	}
+	return loc, nil
+}
+
+// loadProject returns the project from cache, or fetches it from the API and caches it
+func loadProject(cmd *cobra.Command, client *api.Client, projectID string) (*api.Project, error) {
+	if !NoCache {
+		if project, ok := cache.Load(projectID); ok {
+			return project, nil
+		}
+	}
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching project...")
+	project, err := client.GetProject(projectID)
+	stop()
+	if errors.Is(err, api.ErrProjectNotFound) {
+		return nil, suggestProjects(client, projectID, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching project: %w", err)
+	}
+	if NoCache {
+		return project, nil
+	}
+	if err := cache.Save(projectID, project); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
+	}
+	return project, nil
+}
+
+// displayCurrency returns the currency name for amount formatting: the
+// --currency flag, then the project's currency, then the config default.
+// The name may be an ISO code or a symbol; a symbol shared by several
+// currencies is resolved with the config's currency hint when it matches.
+func displayCurrency(cfg *config.Config, project *api.Project) string {
+	name := Currency
+	if name == "" && project != nil {
+		name = project.CurrencyName
+	}
+	if name == "" && cfg != nil {
+		name = cfg.Currency
+	}
+
+	if name != "" && cfg != nil && cfg.CurrencyHint != "" {
+		if iso := cache.SymbolToISOWithHint(name, cfg.CurrencyHint); strings.EqualFold(iso, cfg.CurrencyHint) {
+			return iso
+		}
+	}
+	return name
+}
+
+// loadLocale returns the locale for amount formatting: the --locale flag, then
+// the config file, then the user's Nextcloud locale from cache or API, falling
+// back to en_US
+func loadLocale(cmd *cobra.Command, client *api.Client, cfg *config.Config) string {
+	if Locale != "" {
+		return Locale
+	}
+	if cfg != nil && cfg.Locale != "" {
+		return cfg.Locale
+	}
+
+	var userInfo *api.UserInfo
+	ok := false
+	if !NoCache {
+		userInfo, ok = cache.LoadUserInfo()
+	}
+	if !ok {
+		var err error
+		stop := startSpinner(cmd.ErrOrStderr(), "Fetching user info...")
+		userInfo, err = client.GetUserInfo()
+		stop()
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to fetch user info: %v\n", err)
+		} else if !NoCache {
+			if err := cache.SaveUserInfo(userInfo); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache user info: %v\n", err)
+			}
+		}
+	}
+	if userInfo != nil && userInfo.Locale != "" {
+		return userInfo.Locale
+	}
+	if userInfo != nil && userInfo.Language != "" {
+		return userInfo.Language
+	}
+	return "en_US"
 }
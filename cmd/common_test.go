@@ -2,8 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
 )
 
 func TestConfirm(t *testing.T) {
@@ -36,3 +48,240 @@ func TestConfirm(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayCurrency(t *testing.T) {
+	defer func() { Currency = "" }()
+
+	tests := []struct {
+		name     string
+		flag     string
+		project  string
+		config   string
+		expected string
+	}{
+		{"flag wins", "USD", "EUR", "GBP", "USD"},
+		{"flag accepts symbols", "₪", "EUR", "", "₪"},
+		{"project currency", "", "EUR", "GBP", "EUR"},
+		{"config default for projects without currency", "", "", "GBP", "GBP"},
+		{"none", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Currency = tt.flag
+			got := displayCurrency(&config.Config{Currency: tt.config}, &api.Project{CurrencyName: tt.project})
+			if got != tt.expected {
+				t.Errorf("displayCurrency() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDisplayCurrencyHint(t *testing.T) {
+	cfg := &config.Config{CurrencyHint: "CAD"}
+
+	if got := displayCurrency(cfg, &api.Project{CurrencyName: "$"}); got != "CAD" {
+		t.Errorf("displayCurrency() for $ with CAD hint = %q, want CAD", got)
+	}
+	if got := displayCurrency(cfg, &api.Project{CurrencyName: "€"}); got != "€" {
+		t.Errorf("displayCurrency() for € with CAD hint = %q, want € unchanged", got)
+	}
+
+	cfg.CurrencyHint = "NOK"
+	if got := displayCurrency(cfg, &api.Project{CurrencyName: "kr"}); got != "NOK" {
+		t.Errorf("displayCurrency() for kr with NOK hint = %q, want NOK", got)
+	}
+}
+
+// freezeNow makes now return at until the test ends
+func freezeNow(t *testing.T, at time.Time) {
+	t.Helper()
+	original := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = original })
+}
+
+func TestDateLocation(t *testing.T) {
+	defer func() { Timezone = "" }()
+
+	loc, err := dateLocation(&config.Config{})
+	if err != nil || loc != time.Local {
+		t.Errorf("dateLocation() = %v, %v, want the local zone", loc, err)
+	}
+
+	loc, err = dateLocation(&config.Config{Timezone: "Asia/Tokyo"})
+	if err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("dateLocation() = %v, %v, want the config's zone", loc, err)
+	}
+
+	// The flag takes precedence over the config
+	Timezone = "UTC"
+	loc, err = dateLocation(&config.Config{Timezone: "Asia/Tokyo"})
+	if err != nil || loc.String() != "UTC" {
+		t.Errorf("dateLocation() = %v, %v, want the flag's zone", loc, err)
+	}
+
+	Timezone = "Nowhere/Special"
+	if _, err := dateLocation(&config.Config{}); err == nil || !strings.Contains(err.Error(), "invalid timezone") {
+		t.Errorf("dateLocation() error = %v, want invalid timezone", err)
+	}
+}
+
+func TestLoadProjectNoCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"id": "myproject", "name": "Fresh"}))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	client := newClient(NewListCommand(), cfg)
+
+	if err := cache.Save("myproject", &api.Project{ID: "myproject", Name: "Stale"}); err != nil {
+		t.Fatalf("Failed to seed cache: %v", err)
+	}
+
+	NoCache = true
+	project, err := loadProject(NewListCommand(), client, "myproject")
+	if err != nil {
+		t.Fatalf("loadProject() error = %v", err)
+	}
+	if project.Name != "Fresh" || requests != 1 {
+		t.Errorf("--no-cache should fetch from the API, got %q after %d request(s)", project.Name, requests)
+	}
+	if cached, ok := cache.Load("myproject"); !ok || cached.Name != "Stale" {
+		t.Errorf("--no-cache should leave the cache untouched, got %+v", cached)
+	}
+
+	NoCache = false
+	if project, _ := loadProject(NewListCommand(), client, "myproject"); project.Name != "Stale" {
+		t.Errorf("Without --no-cache the cached project should be used, got %q", project.Name)
+	}
+}
+
+func TestRawResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"id": "myproject", "name": "Trip"}))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	defer func() {
+		RawResponse = ""
+		_ = CloseRawResponse()
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Bare --raw-response dumps to stderr
+	RawResponse = "-"
+	cmd := NewListCommand()
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	if _, err := newClient(cmd, cfg).GetProject("myproject"); err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if !strings.Contains(stderr.String(), "/projects/myproject -> 200") || !strings.Contains(stderr.String(), `  "ocs": {`) {
+		t.Errorf("Expected pretty-printed response on stderr, got:\n%s", stderr.String())
+	}
+
+	// With a path, every client of the run appends to the same file
+	RawResponse = filepath.Join(t.TempDir(), "responses", "raw.txt")
+	for range 2 {
+		if _, err := newClient(NewListCommand(), cfg).GetProject("myproject"); err != nil {
+			t.Fatalf("GetProject() error = %v", err)
+		}
+	}
+	if err := CloseRawResponse(); err != nil {
+		t.Fatalf("CloseRawResponse() error = %v", err)
+	}
+	data, err := os.ReadFile(RawResponse)
+	if err != nil {
+		t.Fatalf("Failed to read raw responses: %v", err)
+	}
+	if n := strings.Count(string(data), "# GET "); n != 2 {
+		t.Errorf("Expected 2 responses in the file, got %d:\n%s", n, data)
+	}
+
+	// Failed dumps are reported when the file is closed
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full not available")
+	}
+	RawResponse = "/dev/full"
+	if _, err := newClient(NewListCommand(), cfg).GetProject("myproject"); err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if err := CloseRawResponse(); err == nil || !strings.Contains(err.Error(), "writing /dev/full") {
+		t.Errorf("CloseRawResponse() error = %v, want a write error", err)
+	}
+}
+
+func TestResolveCategoryAmbiguous(t *testing.T) {
+	project := &api.Project{
+		Categories: []api.Category{{ID: 1, Name: "Fast Food"}, {ID: 2, Name: "Seafood"}},
+	}
+	defer func() { NoInteractive = false }()
+	origIsTerminal := stdinIsTerminal
+	defer func() { stdinIsTerminal = origIsTerminal }()
+
+	newCmd := func(input string) (*cobra.Command, *bytes.Buffer) {
+		cmd := NewAddCommand()
+		out := new(bytes.Buffer)
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetOut(out)
+		return cmd, out
+	}
+
+	// Without a terminal, the candidates are listed in the error
+	stdinIsTerminal = func(*cobra.Command) bool { return false }
+	cmd, _ := newCmd("")
+	if _, err := resolveCategory(cmd, project, "food"); err == nil || !strings.Contains(err.Error(), "Seafood") {
+		t.Errorf("Expected ambiguity error listing candidates, got %v", err)
+	}
+
+	// Interactively, the user picks one
+	stdinIsTerminal = func(*cobra.Command) bool { return true }
+	cmd, out := newCmd("2\n")
+	id, err := resolveCategory(cmd, project, "food")
+	if err != nil || id != 2 {
+		t.Errorf("resolveCategory() = %d, %v; want 2", id, err)
+	}
+	if !strings.Contains(out.String(), "Fast Food") {
+		t.Errorf("Expected candidates in prompt, got:\n%s", out.String())
+	}
+
+	// --no-interactive disables the prompt
+	NoInteractive = true
+	cmd, _ = newCmd("2\n")
+	if _, err := resolveCategory(cmd, project, "food"); err == nil {
+		t.Error("Expected ambiguity error with --no-interactive")
+	}
+}
+
+func TestLoadLocaleOverrides(t *testing.T) {
+	defer func() { Locale = "" }()
+
+	cmd := NewListCommand()
+
+	// Overrides are returned without contacting the server, so no client is needed
+	Locale = ""
+	if got := loadLocale(cmd, nil, &config.Config{Locale: "fr_FR"}); got != "fr_FR" {
+		t.Errorf("loadLocale() with config locale = %q, want fr_FR", got)
+	}
+
+	Locale = "de_DE"
+	if got := loadLocale(cmd, nil, &config.Config{Locale: "fr_FR"}); got != "de_DE" {
+		t.Errorf("loadLocale() with --locale = %q, want de_DE (flag beats config)", got)
+	}
+}
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
 	"github.com/spf13/cobra"
 )
 
@@ -36,12 +40,28 @@ Supported keys:
   confirm-add        Ask for confirmation before adding (true/false)
   confirm-delete     Ask for confirmation before deleting (true/false)
   confirm-update     Ask for confirmation before updating (true/false)
+  locale             Locale for amount formatting (e.g., de_DE)
+  currency           Currency for projects without one (ISO code or symbol)
+  currency-hint      ISO code for ambiguous currency symbols (e.g., CAD for $)
+  workers            Concurrent requests for multi-project commands (default 4)
+  date-format        Date display format: iso, us, eu, or a Go layout (default iso)
+  timezone           Time zone for "today" and relative dates (e.g., Europe/Berlin)
+  default-method     Payment method for new bills when -m is omitted
+  default-category   Category for new bills when -c is omitted
+
+These keys can also be set for a single project with -p, which then takes
+precedence over the global value in that project:
+  default-method
+  default-category
 
 Examples:
   cospend config set domain https://cloud.example.com
   cospend config set user alice
   cospend config set default-project myproject
-  cospend config set confirm-delete true`,
+  cospend config set confirm-delete true
+  cospend config set default-method Card
+  cospend config set default-method Cash -p groceries
+  cospend config set default-category Groceries -p groceries`,
 		Args: cobra.ExactArgs(2),
 		RunE: runConfigSet,
 	}
@@ -60,12 +80,26 @@ Supported keys:
   confirm-add        Ask for confirmation before adding (true/false)
   confirm-delete     Ask for confirmation before deleting (true/false)
   confirm-update     Ask for confirmation before updating (true/false)
+  locale             Locale for amount formatting (e.g., de_DE)
+  currency           Currency for projects without one (ISO code or symbol)
+  currency-hint      ISO code for ambiguous currency symbols (e.g., CAD for $)
+  workers            Concurrent requests for multi-project commands (default 4)
+  date-format        Date display format: iso, us, eu, or a Go layout (default iso)
+  timezone           Time zone for "today" and relative dates (e.g., Europe/Berlin)
+  default-method     Payment method for new bills when -m is omitted
+  default-category   Category for new bills when -c is omitted
+
+These keys can also be set for a single project with -p, which then takes
+precedence over the global value in that project:
+  default-method
+  default-category
 
 Examples:
   cospend config get domain
   cospend config get user
   cospend config get default-project
-  cospend config get confirm-delete`,
+  cospend config get confirm-delete
+  cospend config get default-method -p groceries`,
 		Args: cobra.ExactArgs(1),
 		RunE: runConfigGet,
 	}
@@ -90,6 +124,9 @@ func maskPassword(password string) string {
 	if password == "" {
 		return "(not set)"
 	}
+	if password == config.KeyringPassword {
+		return "(stored in OS keyring)"
+	}
 	return "********"
 }
 
@@ -118,13 +155,75 @@ func runConfigList(cmd *cobra.Command, _ []string) error {
 	_, _ = fmt.Fprintf(out, "  confirm-add:     %v\n", cfg.ConfirmAdd)
 	_, _ = fmt.Fprintf(out, "  confirm-delete:  %v\n", cfg.ConfirmDelete)
 	_, _ = fmt.Fprintf(out, "  confirm-update:  %v\n", cfg.ConfirmUpdate)
+	if cfg.Locale != "" {
+		_, _ = fmt.Fprintf(out, "  locale:          %s\n", cfg.Locale)
+	}
+	if cfg.Currency != "" {
+		_, _ = fmt.Fprintf(out, "  currency:        %s\n", cfg.Currency)
+	}
+	if cfg.CurrencyHint != "" {
+		_, _ = fmt.Fprintf(out, "  currency-hint:   %s\n", cfg.CurrencyHint)
+	}
+	if cfg.Workers > 0 {
+		_, _ = fmt.Fprintf(out, "  workers:         %d\n", cfg.Workers)
+	}
+	if cfg.DateFormat != "" {
+		_, _ = fmt.Fprintf(out, "  date-format:     %s\n", cfg.DateFormat)
+	}
+	if cfg.Timezone != "" {
+		_, _ = fmt.Fprintf(out, "  timezone:        %s\n", cfg.Timezone)
+	}
+	if cfg.DefaultPaymentMode != "" {
+		_, _ = fmt.Fprintf(out, "  default-method:  %s\n", cfg.DefaultPaymentMode)
+	}
+	if cfg.DefaultCategory != "" {
+		_, _ = fmt.Fprintf(out, "  default-category: %s\n", cfg.DefaultCategory)
+	}
+
+	projectIDs := make([]string, 0, len(cfg.Projects))
+	for id := range cfg.Projects {
+		projectIDs = append(projectIDs, id)
+	}
+	sort.Strings(projectIDs)
+	for _, id := range projectIDs {
+		p := cfg.Projects[id]
+		_, _ = fmt.Fprintf(out, "\n  project %s:\n", id)
+		if p.DefaultPaymentMode != "" {
+			_, _ = fmt.Fprintf(out, "    default-method:   %s\n", p.DefaultPaymentMode)
+		}
+		if p.DefaultCategory != "" {
+			_, _ = fmt.Fprintf(out, "    default-category: %s\n", p.DefaultCategory)
+		}
+	}
 
 	return nil
 }
 
+// projectKeys are the config keys that can be scoped to a project with -p
+var projectKeys = []string{"default-method", "default-category"}
+
+// isProjectKey reports whether key can be scoped to a project
+func isProjectKey(key string) bool {
+	return slices.Contains(projectKeys, key)
+}
+
+// configProject returns the project that per-project keys are scoped to: the
+// one given with -p, or "" for the global value. The default project doesn't
+// count, so that keys are only scoped when asked to.
+func configProject(cmd *cobra.Command) string {
+	if f := cmd.Flags().Lookup("project"); f != nil && f.Changed {
+		return ProjectID
+	}
+	return ""
+}
+
 func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
+	projectID := configProject(cmd)
+	if projectID != "" && !isProjectKey(key) {
+		return fmt.Errorf("config key %s can't be set per project", key)
+	}
 
 	cmd.SilenceUsage = true
 
@@ -140,6 +239,9 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 
 	switch key {
 	case "domain":
+		if err := config.ValidateDomain(value); err != nil {
+			return err
+		}
 		cfg.Domain = config.NormalizeURL(value)
 	case "user":
 		cfg.User = value
@@ -163,6 +265,44 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid boolean value: %s (use true or false)", value)
 		}
 		cfg.ConfirmUpdate = b
+	case "locale":
+		cfg.Locale = value
+	case "currency":
+		cfg.Currency = value
+	case "currency-hint":
+		cfg.CurrencyHint = strings.ToUpper(value)
+	case "workers":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid worker count: %s (use a positive number)", value)
+		}
+		cfg.Workers = n
+	case "date-format":
+		if _, err := format.DateLayout(value); err != nil {
+			return err
+		}
+		cfg.DateFormat = value
+	case "timezone":
+		if _, err := loadTimezone(value); err != nil {
+			return err
+		}
+		cfg.Timezone = value
+	case "default-method":
+		if projectID != "" {
+			p := cfg.Project(projectID)
+			p.DefaultPaymentMode = value
+			cfg.SetProject(projectID, p)
+		} else {
+			cfg.DefaultPaymentMode = value
+		}
+	case "default-category":
+		if projectID != "" {
+			p := cfg.Project(projectID)
+			p.DefaultCategory = value
+			cfg.SetProject(projectID, p)
+		} else {
+			cfg.DefaultCategory = value
+		}
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
@@ -171,12 +311,20 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s\n", key, value)
+	if projectID != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s (project %s)\n", key, value, projectID)
+	} else {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s\n", key, value)
+	}
 	return nil
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
 	key := args[0]
+	projectID := configProject(cmd)
+	if projectID != "" && !isProjectKey(key) {
+		return fmt.Errorf("config key %s can't be set per project", key)
+	}
 
 	cmd.SilenceUsage = true
 
@@ -204,6 +352,32 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		value = strconv.FormatBool(cfg.ConfirmDelete)
 	case "confirm-update":
 		value = strconv.FormatBool(cfg.ConfirmUpdate)
+	case "locale":
+		value = cfg.Locale
+	case "currency":
+		value = cfg.Currency
+	case "currency-hint":
+		value = cfg.CurrencyHint
+	case "workers":
+		if cfg.Workers > 0 {
+			value = strconv.Itoa(cfg.Workers)
+		}
+	case "date-format":
+		value = cfg.DateFormat
+	case "timezone":
+		value = cfg.Timezone
+	case "default-method":
+		if projectID != "" {
+			value = cfg.Project(projectID).DefaultPaymentMode
+		} else {
+			value = cfg.DefaultPaymentMode
+		}
+	case "default-category":
+		if projectID != "" {
+			value = cfg.Project(projectID).DefaultCategory
+		} else {
+			value = cfg.DefaultCategory
+		}
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
 )
 
 func TestNewConfigCommand(t *testing.T) {
@@ -457,6 +461,47 @@ func TestConfigSetConfirmInvalidBool(t *testing.T) {
 	}
 }
 
+func TestConfigSetWorkers(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "cospend.json")
+	if err := os.WriteFile(configPath, []byte(`{"domain":"x","user":"u","password":"p"}`), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	for _, bad := range []string{"0", "-2", "many"} {
+		cmd := NewConfigCommand()
+		cmd.SetArgs([]string{"set", "workers", bad})
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("Expected error for worker count %q", bad)
+		}
+	}
+
+	cmd := NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "workers", "8"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cmd = NewConfigCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"get", "workers"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "8" {
+		t.Errorf("Expected '8', got: %s", stdout.String())
+	}
+}
+
 func TestConfigListShowsConfirmations(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
@@ -506,3 +551,189 @@ func TestMaskPassword(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigSetDomain(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "cospend.json")
+	if err := os.WriteFile(configPath, []byte(`{"domain":"x","user":"u","password":"p"}`), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cmd := NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "domain", "cloud example.com"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for a domain with spaces")
+	}
+
+	// A pasted app URL is trimmed to the instance URL
+	cmd = NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "domain", "https://cloud.example.com:8443/index.php/apps/cospend/"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cmd = NewConfigCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"get", "domain"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "https://cloud.example.com:8443" {
+		t.Errorf("Expected 'https://cloud.example.com:8443', got: %s", stdout.String())
+	}
+}
+
+func TestConfigSetDateFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "cospend.json")
+	if err := os.WriteFile(configPath, []byte(`{"domain":"x","user":"u","password":"p"}`), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cmd := NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "date-format", "dd/mm/yyyy"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for a layout without reference components")
+	}
+
+	cmd = NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "date-format", "eu"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cmd = NewConfigCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"get", "date-format"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "eu" {
+		t.Errorf("Expected 'eu', got: %s", stdout.String())
+	}
+}
+
+func TestConfigSetTimezone(t *testing.T) {
+	writeTestConfig(t, config.Config{Domain: "x", User: "u", Password: "p"})
+
+	cmd := NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "timezone", "Mars/Olympus"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for an unknown time zone")
+	}
+
+	cmd = NewConfigCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"set", "timezone", "Europe/Berlin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cmd = NewConfigCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"get", "timezone"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "Europe/Berlin" {
+		t.Errorf("Expected 'Europe/Berlin', got: %s", stdout.String())
+	}
+}
+
+func TestConfigSetProjectDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "cospend.json")
+	if err := os.WriteFile(configPath, []byte(`{"domain":"x","user":"u","password":"p"}`), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	// -p is a persistent flag on the root command
+	run := func(args ...string) (string, error) {
+		ProjectID = ""
+		root := &cobra.Command{Use: "cospend"}
+		root.PersistentFlags().StringVarP(&ProjectID, "project", "p", "", "Project ID")
+		root.AddCommand(NewConfigCommand())
+		var stdout bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs(append([]string{"config"}, args...))
+		err := root.Execute()
+		return strings.TrimSpace(stdout.String()), err
+	}
+	defer func() { ProjectID = "" }()
+
+	if _, err := run("set", "default-method", "Cash"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out, err := run("set", "default-method", "Card", "-p", "groceries")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "Set default-method = Card (project groceries)" {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	cfg, err := config.LoadFromFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if cfg.DefaultPaymentMode != "Cash" {
+		t.Errorf("Expected global default Cash, got %q", cfg.DefaultPaymentMode)
+	}
+	if got := cfg.Project("groceries").DefaultPaymentMode; got != "Card" {
+		t.Errorf("Expected groceries default Card, got %q", got)
+	}
+
+	if out, _ := run("get", "default-method", "-p", "groceries"); out != "Card" {
+		t.Errorf("Expected 'Card', got: %s", out)
+	}
+	if out, _ := run("get", "default-method"); out != "Cash" {
+		t.Errorf("Expected 'Cash', got: %s", out)
+	}
+
+	if _, err := run("set", "default-category", "Groceries", "-p", "groceries"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out, _ := run("get", "default-category", "-p", "groceries"); out != "Groceries" {
+		t.Errorf("Expected 'Groceries', got: %s", out)
+	}
+	if out, _ := run("get", "default-category"); out != "(not set)" {
+		t.Errorf("Expected '(not set)', got: %s", out)
+	}
+
+	if _, err := run("set", "locale", "de_DE", "-p", "groceries"); err == nil {
+		t.Error("Expected error for a key that can't be set per project")
+	}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyName          string
+	copyAmount        string
+	copyCategory      string
+	copyPaidBy        string
+	copyPaidFor       []string
+	copyPaymentMethod string
+	copyComment       string
+	copyDate          string
+	copyRepeat        string
+)
+
+// NewCopyCommand creates the copy command
+func NewCopyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "copy <bill_id>",
+		Aliases: []string{"duplicate"},
+		Short:   "Create a new expense from an existing one",
+		Long: `Create a new expense with the same name, amount, payer, owed members,
+category, and payment method as an existing bill.
+
+The new bill is dated today unless --date is given. Any other field can be
+overridden with the same flags used by edit.
+
+Examples:
+  cospend copy 123 -p myproject
+  cospend copy 123 -p myproject -d 2026-02-01
+  cospend copy 123 -p myproject -a 1250.00 -o "New lease"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCopy,
+	}
+
+	cmd.Flags().StringVarP(&copyName, "name", "n", "", "New name/description")
+	cmd.Flags().StringVarP(&copyAmount, "amount", "a", "", "New amount")
+	cmd.Flags().StringVarP(&copyCategory, "category", "c", "", "Category by ID or name")
+	cmd.Flags().StringVarP(&copyPaidBy, "by", "b", "", "Paying member username")
+	cmd.Flags().StringArrayVarP(&copyPaidFor, "for", "f", nil, "Owed member username (repeatable)")
+	cmd.Flags().StringVarP(&copyPaymentMethod, "method", "m", "", "Payment method by ID or name")
+	cmd.Flags().StringVarP(&copyComment, "comment", "o", "", "Comment")
+	cmd.Flags().StringVarP(&copyDate, "date", "d", "", "Date (YYYY-MM-DD, MM-DD, or relative like -1d, +2w; defaults to today)")
+	cmd.Flags().StringVarP(&copyRepeat, "repeat", "r", "", "Repeat frequency: n (none), d (daily), w (weekly), b (biweekly), s (semi-monthly), m (monthly), y (yearly)")
+	cmd.Flags().BoolVar(&allowZero, "allow-zero", false, "Allow an amount of zero")
+	cmd.Flags().BoolVar(&allowNegative, "allow-negative", false, "Allow a negative amount, e.g. for refunds")
+
+	return cmd
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	billID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid bill ID: %s", args[0])
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	source, err := client.GetBill(ProjectID, billID)
+	if err != nil {
+		return fmt.Errorf("fetching bill: %w", err)
+	}
+
+	// Start from the source bill, dated today
+	bill := api.Bill{
+		What:          source.What,
+		Amount:        source.Amount,
+		PayerID:       source.PayerID,
+		Date:          now().In(loc).Format("2006-01-02"),
+		PaymentModeID: source.PaymentModeID,
+		CategoryID:    source.CategoryID,
+	}
+	for _, o := range source.Owers {
+		bill.OwedTo = append(bill.OwedTo, o.ID)
+	}
+
+	// Apply overrides for flags that were explicitly set
+	if cmd.Flags().Changed("name") {
+		bill.What = copyName
+	}
+
+	// Resolve the locale for amount parsing and formatting
+	locale := loadLocale(cmd, client, cfg)
+
+	if cmd.Flags().Changed("amount") {
+		amount, err := parseBillAmount(copyAmount, locale)
+		if err != nil {
+			return err
+		}
+		bill.Amount = amount
+	}
+
+	if cmd.Flags().Changed("by") {
+		payerID, err := cache.ResolveMember(project, copyPaidBy)
+		if err != nil {
+			return fmt.Errorf("resolving payer: %w", err)
+		}
+		bill.PayerID = payerID
+	}
+
+	if cmd.Flags().Changed("for") {
+		var owedIDs []int
+		for _, username := range copyPaidFor {
+			memberID, err := cache.ResolveMember(project, username)
+			if err != nil {
+				return fmt.Errorf("resolving owed member: %w", err)
+			}
+			owedIDs = append(owedIDs, memberID)
+		}
+		bill.OwedTo = owedIDs
+	}
+
+	if cmd.Flags().Changed("date") {
+		parsed, err := parseDate(copyDate, loc)
+		if err != nil {
+			return err
+		}
+		bill.Date = parsed
+	}
+
+	if cmd.Flags().Changed("category") {
+		categoryID, err := resolveCategory(cmd, project, copyCategory)
+		if err != nil {
+			return fmt.Errorf("resolving category: %w", err)
+		}
+		bill.CategoryID = categoryID
+	}
+
+	if cmd.Flags().Changed("method") {
+		methodID, err := resolvePaymentMode(cmd, project, copyPaymentMethod)
+		if err != nil {
+			return fmt.Errorf("resolving payment method: %w", err)
+		}
+		bill.PaymentModeID = methodID
+	}
+
+	if cmd.Flags().Changed("comment") {
+		bill.Comment = copyComment
+	}
+
+	if cmd.Flags().Changed("repeat") {
+		if _, ok := api.ValidRepeatFrequencies[copyRepeat]; !ok {
+			return fmt.Errorf("invalid repeat frequency: %s (valid: n, d, w, b, s, m, y)", copyRepeat)
+		}
+		bill.Repeat = copyRepeat
+	}
+
+	// Build member name lookup
+	memberNames := make(map[int]string)
+	for _, m := range project.Members {
+		memberNames[m.ID] = m.Name
+	}
+
+	formatter := format.NewAmountFormatter(locale, displayCurrency(cfg, project))
+	out := cmd.OutOrStdout()
+
+	printBillSummary := func() {
+		_, _ = fmt.Fprintf(out, "  Name:     %s\n", bill.What)
+		_, _ = fmt.Fprintf(out, "  Amount:   %s\n", formatter.Format(bill.Amount))
+		_, _ = fmt.Fprintf(out, "  Date:     %s\n", bill.Date)
+		_, _ = fmt.Fprintf(out, "  Paid by:  %s\n", memberNames[bill.PayerID])
+		var owerNames []string
+		for _, id := range bill.OwedTo {
+			owerNames = append(owerNames, memberNames[id])
+		}
+		_, _ = fmt.Fprintf(out, "  Paid for: %s\n", strings.Join(owerNames, ", "))
+		if bill.CategoryID != 0 {
+			for _, c := range project.Categories {
+				if c.ID == bill.CategoryID {
+					_, _ = fmt.Fprintf(out, "  Category: %s\n", c.Name)
+					break
+				}
+			}
+		}
+		if bill.PaymentModeID != 0 {
+			for _, pm := range project.PaymentModes {
+				if pm.ID == bill.PaymentModeID {
+					_, _ = fmt.Fprintf(out, "  Method:   %s\n", pm.Name)
+					break
+				}
+			}
+		}
+		if bill.Comment != "" {
+			_, _ = fmt.Fprintf(out, "  Comment:  %s\n", bill.Comment)
+		}
+		if bill.Repeat != "" && bill.Repeat != "n" {
+			_, _ = fmt.Fprintf(out, "  Repeat:   %s\n", api.ValidRepeatFrequencies[bill.Repeat])
+		}
+	}
+
+	// Confirm if configured
+	if cfg.ConfirmAdd {
+		_, _ = fmt.Fprintf(out, "Copy of bill #%d:\n", billID)
+		printBillSummary()
+		if !confirm(os.Stdin, out, "Add bill?") {
+			_, _ = fmt.Fprintln(out, "Cancelled.")
+			return nil
+		}
+	}
+
+	newID, err := client.CreateBill(ProjectID, bill)
+	if err != nil {
+		return fmt.Errorf("creating bill: %w", err)
+	}
+	saveLastAction(cmd, cache.LastAction{Kind: cache.ActionAdd, BillID: newID})
+
+	_, _ = fmt.Fprintf(out, "Copied bill #%d to #%d\n", billID, newID)
+	printBillSummary()
+	return nil
+}
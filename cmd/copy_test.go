@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func setupCopyServer(t *testing.T) (*httptest.Server, *url.Values) {
+	t.Helper()
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice"},
+			{ID: 2, Name: "Bob", UserID: "bob"},
+		},
+		Categories:   []api.Category{{ID: 3, Name: "Housing"}, {ID: 4, Name: "Utilities"}},
+		PaymentModes: []api.PaymentMode{{ID: 5, Name: "Transfer"}},
+	}
+	source := api.BillResponse{
+		ID:            10,
+		What:          "Rent",
+		Amount:        1200,
+		Date:          "2026-01-01",
+		PayerID:       1,
+		Owers:         []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}},
+		Comment:       "January",
+		CategoryID:    3,
+		PaymentModeID: 5,
+		Repeat:        "n",
+	}
+
+	created := &url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = r.ParseForm()
+			*created = r.PostForm
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 11))
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills/10":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, source))
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case r.URL.Path == "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, created
+}
+
+func TestCopyCommand(t *testing.T) {
+	server, created := setupCopyServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
+	ProjectID = "test-project"
+	cmd := NewCopyCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"10"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := map[string]string{
+		"what":          "Rent",
+		"amount":        "1200.00",
+		"payer":         "1",
+		"payedFor":      "1,2",
+		"categoryId":    "3",
+		"paymentModeId": "5",
+		"date":          "2026-03-10",
+	}
+	for key, value := range want {
+		if got := created.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if created.Get("comment") != "" {
+		t.Errorf("Comment should not be copied, got %q", created.Get("comment"))
+	}
+	if !strings.Contains(buf.String(), "Copied bill #10 to #11") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestCopyCommandOverrides(t *testing.T) {
+	server, created := setupCopyServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewCopyCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"10", "-d", "2026-02-01", "-a", "1250", "-c", "utilities", "-b", "bob", "-f", "bob", "-o", "February"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := map[string]string{
+		"what":       "Rent",
+		"amount":     "1250.00",
+		"payer":      "2",
+		"payedFor":   "2",
+		"categoryId": "4",
+		"date":       "2026-02-01",
+		"comment":    "February",
+	}
+	for key, value := range want {
+		if got := created.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestCopyCommandBillNotFound(t *testing.T) {
+	server, _ := setupCopyServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewCopyCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"99"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for missing bill")
+	}
+}
+
+func TestCopyCommandInvalidID(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	ProjectID = "test-project"
+	cmd := NewCopyCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"abc"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for invalid bill ID")
+	}
+}
+
+func TestCopyCommandZeroAmount(t *testing.T) {
+	server, created := setupCopyServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewCopyCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"10", "-a", "0"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--allow-zero") {
+		t.Fatalf("Execute() error = %v, want a zero amount error", err)
+	}
+	if len(*created) != 0 {
+		t.Errorf("Bill should not be created, got %v", *created)
+	}
+
+	ProjectID = "test-project"
+	cmd = NewCopyCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"10", "-a", "0", "--allow-zero"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() with --allow-zero error = %v", err)
+	}
+	if got := created.Get("amount"); got != "0.00" {
+		t.Errorf("amount = %q, want %q", got, "0.00")
+	}
+}
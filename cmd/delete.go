@@ -5,7 +5,6 @@ import (
 	"os"
 	"strconv"
 
-	"github.com/chenasraf/cospend-cli/internal/api"
 	"github.com/chenasraf/cospend-cli/internal/cache"
 	"github.com/chenasraf/cospend-cli/internal/config"
 	"github.com/chenasraf/cospend-cli/internal/format"
@@ -54,62 +53,36 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get API client
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	client := newClient(cmd, cfg)
+
+	// Fetch the bill first, so it can be recreated by undo
+	bill, err := client.GetBill(ProjectID, billID)
+	if err != nil {
+		return err
+	}
 
 	// Confirm if configured
 	if cfg.ConfirmDelete {
-		// Fetch bill details for preview
-		bills, err := client.GetBills(ProjectID)
+		out := cmd.OutOrStdout()
+
+		// Fetch project for member names and currency
+		project, err := loadProject(cmd, client, ProjectID)
 		if err != nil {
-			return fmt.Errorf("fetching bills: %w", err)
+			return err
 		}
-
-		var bill *api.BillResponse
-		for i := range bills {
-			if bills[i].ID == billID {
-				bill = &bills[i]
-				break
-			}
+		memberNames := make(map[int]string)
+		for _, m := range project.Members {
+			memberNames[m.ID] = m.Name
 		}
 
-		out := cmd.OutOrStdout()
-		if bill != nil {
-			// Fetch project for member names and currency
-			project, ok := cache.Load(ProjectID)
-			if !ok {
-				project, err = client.GetProject(ProjectID)
-				if err != nil {
-					return fmt.Errorf("fetching project: %w", err)
-				}
-			}
-			memberNames := make(map[int]string)
-			for _, m := range project.Members {
-				memberNames[m.ID] = m.Name
-			}
-
-			locale := "en_US"
-			userInfo, ok := cache.LoadUserInfo()
-			if !ok {
-				userInfo, err = client.GetUserInfo()
-				if err == nil {
-					_ = cache.SaveUserInfo(userInfo)
-				}
-			}
-			if userInfo != nil && userInfo.Locale != "" {
-				locale = userInfo.Locale
-			} else if userInfo != nil && userInfo.Language != "" {
-				locale = userInfo.Language
-			}
-
-			formatter := format.NewAmountFormatter(locale, project.CurrencyName)
-			_, _ = fmt.Fprintf(out, "Bill #%d:\n", billID)
-			_, _ = fmt.Fprintf(out, "  Name:     %s\n", bill.What)
-			_, _ = fmt.Fprintf(out, "  Amount:   %s\n", formatter.Format(bill.Amount))
-			_, _ = fmt.Fprintf(out, "  Date:     %s\n", bill.Date)
-			_, _ = fmt.Fprintf(out, "  Paid by:  %s\n", memberNames[bill.PayerID])
-		}
+		locale := loadLocale(cmd, client, cfg)
+
+		formatter := format.NewAmountFormatter(locale, displayCurrency(cfg, project))
+		_, _ = fmt.Fprintf(out, "Bill #%d:\n", billID)
+		_, _ = fmt.Fprintf(out, "  Name:     %s\n", bill.What)
+		_, _ = fmt.Fprintf(out, "  Amount:   %s\n", formatter.Format(bill.Amount))
+		_, _ = fmt.Fprintf(out, "  Date:     %s\n", bill.Date)
+		_, _ = fmt.Fprintf(out, "  Paid by:  %s\n", memberNames[bill.PayerID])
 
 		if !confirm(os.Stdin, out, fmt.Sprintf("Delete bill #%d?", billID)) {
 			_, _ = fmt.Fprintln(out, "Cancelled.")
@@ -121,6 +94,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	if err := client.DeleteBill(ProjectID, billID); err != nil {
 		return fmt.Errorf("deleting bill: %w", err)
 	}
+	saveLastAction(cmd, cache.LastAction{Kind: cache.ActionDelete, BillID: billID, Bill: bill})
 
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted bill #%d\n", billID)
 	return nil
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
 )
 
 func TestNewDeleteCommand(t *testing.T) {
@@ -71,14 +74,23 @@ func TestDeleteCommandSuccess(t *testing.T) {
 	resetDeleteFlags()
 
 	// Create mock server
+	deleted := false
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != "DELETE" {
-			t.Errorf("Expected DELETE method, got %s", r.Method)
-		}
 		if r.URL.Path != "/ocs/v2.php/apps/cospend/api/v1/projects/myproject/bills/123" {
 			t.Errorf("Unexpected path: %s", r.URL.Path)
 		}
 
+		var data interface{} = "OK"
+		switch r.Method {
+		case "GET":
+			// The bill is fetched first so it can be restored
+			data = api.BillResponse{ID: 123, What: "Dinner", Amount: 40, PayerID: 1, Owers: []api.Ower{{ID: 1, Weight: 1}}}
+		case "DELETE":
+			deleted = true
+		default:
+			t.Errorf("Unexpected method: %s", r.Method)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		resp := map[string]interface{}{
 			"ocs": map[string]interface{}{
@@ -87,7 +99,7 @@ func TestDeleteCommandSuccess(t *testing.T) {
 					"statuscode": 200,
 					"message":    "OK",
 				},
-				"data": "OK",
+				"data": data,
 			},
 		}
 		_ = json.NewEncoder(w).Encode(resp)
@@ -97,6 +109,7 @@ func TestDeleteCommandSuccess(t *testing.T) {
 	t.Setenv("NEXTCLOUD_DOMAIN", server.URL)
 	t.Setenv("NEXTCLOUD_USER", "testuser")
 	t.Setenv("NEXTCLOUD_PASSWORD", "testpass")
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 
 	ProjectID = "myproject"
 	cmd := NewDeleteCommand()
@@ -113,6 +126,14 @@ func TestDeleteCommandSuccess(t *testing.T) {
 	if !bytes.Contains([]byte(output), []byte("Successfully deleted bill #123")) {
 		t.Errorf("Expected success message in output, got: %s", output)
 	}
+	if !deleted {
+		t.Error("Bill was not deleted")
+	}
+
+	action, ok := cache.LoadLastAction("myproject")
+	if !ok || action.Kind != cache.ActionDelete || action.Bill == nil || action.Bill.What != "Dinner" {
+		t.Errorf("Deleted bill should be saved for undo, got %+v", action)
+	}
 }
 
 func TestDeleteCommandAPIError(t *testing.T) {
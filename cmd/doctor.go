@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
 	"github.com/chenasraf/cospend-cli/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -22,8 +25,13 @@ Checks performed:
   - Config file exists and is readable
   - Required fields (domain, user, password) are set
   - Nextcloud server is reachable
-  - Authentication credentials are valid
-  - Default project (if configured) is accessible`,
+  - Authentication credentials are valid and the user locale is readable
+  - Cache directory is writable
+  - Default project (if configured) is accessible
+
+Failed checks include a hint on how to fix them. The command exits with a
+non-zero status if any critical check fails; the cache directory and default
+project checks only warn.`,
 		RunE: runDoctor,
 	}
 }
@@ -32,6 +40,10 @@ type checkResult struct {
 	name   string
 	ok     bool
 	detail string
+	// hint suggests how to fix a failed check
+	hint string
+	// optional checks only warn when they fail
+	optional bool
 }
 
 func runDoctor(cmd *cobra.Command, _ []string) error {
@@ -45,14 +57,14 @@ func runDoctor(cmd *cobra.Command, _ []string) error {
 	// Check 1: Config file
 	configPath := config.GetConfigPath()
 	if configPath == "" {
-		results = append(results, checkResult{"Config file", false, "not found (run 'cospend init')"})
+		results = append(results, checkResult{name: "Config file", detail: "not found", hint: "Run 'cospend init' to create one"})
 	} else {
 		var err error
 		cfg, err = config.LoadFromFile(configPath)
 		if err != nil {
-			results = append(results, checkResult{"Config file", false, fmt.Sprintf("error reading %s: %v", configPath, err)})
+			results = append(results, checkResult{name: "Config file", detail: fmt.Sprintf("error reading %s: %v", configPath, err), hint: "Fix the syntax error, or run 'cospend init' to recreate the file"})
 		} else {
-			results = append(results, checkResult{"Config file", true, configPath})
+			results = append(results, checkResult{name: "Config file", ok: true, detail: configPath})
 		}
 	}
 
@@ -69,9 +81,19 @@ func runDoctor(cmd *cobra.Command, _ []string) error {
 			missing = append(missing, "password")
 		}
 		if len(missing) > 0 {
-			results = append(results, checkResult{"Required fields", false, fmt.Sprintf("missing: %s", joinWords(missing))})
+			results = append(results, checkResult{name: "Required fields", detail: fmt.Sprintf("missing: %s", joinWords(missing)), hint: "Run 'cospend init', or set them with 'cospend config set'"})
 		} else {
-			results = append(results, checkResult{"Required fields", true, "domain, user, password all set"})
+			results = append(results, checkResult{name: "Required fields", ok: true, detail: "domain, user, password all set"})
+		}
+
+		// Resolve password stored in the OS keyring
+		if cfg.Password == config.KeyringPassword {
+			if err := config.ResolvePassword(cfg); err != nil {
+				results = append(results, checkResult{name: "Keyring", detail: err.Error(), hint: "Run 'cospend init' to store the password again"})
+				cfg.Password = ""
+			} else {
+				results = append(results, checkResult{name: "Keyring", ok: true, detail: "password found in OS keyring"})
+			}
 		}
 	}
 
@@ -79,16 +101,16 @@ func runDoctor(cmd *cobra.Command, _ []string) error {
 	if cfg != nil && cfg.Domain != "" {
 		baseURL := config.NormalizeURL(cfg.Domain)
 		httpClient := &http.Client{Timeout: 10 * time.Second}
-		resp, err := httpClient.Get(baseURL + "/status.php")
+		resp, err := httpClient.Head(baseURL)
 		if err != nil {
-			results = append(results, checkResult{"Server reachable", false, err.Error()})
+			results = append(results, checkResult{name: "Server reachable", detail: err.Error(), hint: "Check the domain and your network connection"})
 		} else {
 			_ = resp.Body.Close()
-			if resp.StatusCode == http.StatusOK {
-				results = append(results, checkResult{"Server reachable", true, baseURL})
+			if resp.StatusCode < http.StatusInternalServerError {
+				results = append(results, checkResult{name: "Server reachable", ok: true, detail: baseURL})
 				canConnect = true
 			} else {
-				results = append(results, checkResult{"Server reachable", false, fmt.Sprintf("HTTP %d from %s/status.php", resp.StatusCode, baseURL)})
+				results = append(results, checkResult{name: "Server reachable", detail: fmt.Sprintf("HTTP %d from %s", resp.StatusCode, baseURL), hint: "The server is having trouble; try again later or contact its administrator"})
 			}
 		}
 	}
@@ -97,51 +119,88 @@ func runDoctor(cmd *cobra.Command, _ []string) error {
 	if cfg != nil && cfg.Domain != "" && cfg.User != "" && cfg.Password != "" && canConnect {
 		client := api.NewClient(cfg)
 		userInfo, err := client.GetUserInfo()
-		if err != nil {
-			results = append(results, checkResult{"Authentication", false, fmt.Sprintf("failed: %v", err)})
+		if errors.Is(err, api.ErrUnauthorized) {
+			results = append(results, checkResult{name: "Authentication", detail: fmt.Sprintf("credentials for %s were rejected", cfg.User), hint: "Run 'cospend init' to update them; use an app password if two-factor auth is enabled"})
+		} else if err != nil {
+			results = append(results, checkResult{name: "Authentication", detail: fmt.Sprintf("failed: %v", err), hint: "Check that the domain points at your Nextcloud instance"})
 		} else {
 			detail := fmt.Sprintf("logged in as %s", cfg.User)
 			if userInfo.Locale != "" {
 				detail += fmt.Sprintf(" (locale: %s)", userInfo.Locale)
+			} else {
+				detail += " (no locale set, amounts use the default format)"
 			}
-			results = append(results, checkResult{"Authentication", true, detail})
+			results = append(results, checkResult{name: "Authentication", ok: true, detail: detail})
 		}
 	}
 
-	// Check 5: Default project
+	// Check 5: Cache directory
+	cacheDir := cache.GetCacheDir()
+	if err := checkWritable(cacheDir); err != nil {
+		results = append(results, checkResult{name: "Cache directory", detail: err.Error(), hint: "Fix its permissions, or set COSPEND_CACHE_DIR to a writable directory", optional: true})
+	} else {
+		results = append(results, checkResult{name: "Cache directory", ok: true, detail: cacheDir})
+	}
+
+	// Check 6: Default project
 	if cfg != nil && cfg.DefaultProject != "" && canConnect {
 		client := api.NewClient(cfg)
 		project, err := client.GetProject(cfg.DefaultProject)
 		if err != nil {
-			results = append(results, checkResult{"Default project", false, fmt.Sprintf("%s: %v", cfg.DefaultProject, err)})
+			results = append(results, checkResult{name: "Default project", detail: fmt.Sprintf("%s: %v", cfg.DefaultProject, err), hint: "Run 'cospend projects' to see your projects, then 'cospend config set default-project <id>'", optional: true})
 		} else {
-			results = append(results, checkResult{"Default project", true, fmt.Sprintf("%s (%s)", cfg.DefaultProject, project.Name)})
+			results = append(results, checkResult{name: "Default project", ok: true, detail: fmt.Sprintf("%s (%s)", cfg.DefaultProject, project.Name)})
 		}
 	} else if cfg != nil && cfg.DefaultProject == "" {
-		results = append(results, checkResult{"Default project", true, "not configured (optional)"})
+		results = append(results, checkResult{name: "Default project", ok: true, detail: "not configured (optional)"})
 	}
 
 	// Render results
-	allOK := true
+	failed, warned := 0, 0
 	for _, r := range results {
-		if r.ok {
-			_, _ = fmt.Fprintf(out, "  [ok] %-17s %s\n", r.name, r.detail)
-		} else {
-			_, _ = fmt.Fprintf(out, "  [!!] %-17s %s\n", r.name, r.detail)
-			allOK = false
+		mark := "✓"
+		if !r.ok {
+			if r.optional {
+				mark = "!"
+				warned++
+			} else {
+				mark = "✗"
+				failed++
+			}
+		}
+		_, _ = fmt.Fprintf(out, "  %s %-17s %s\n", mark, r.name, r.detail)
+		if !r.ok && r.hint != "" {
+			_, _ = fmt.Fprintf(out, "    %-17s → %s\n", "", r.hint)
 		}
 	}
 
 	_, _ = fmt.Fprintln(out)
-	if allOK {
-		_, _ = fmt.Fprintln(out, "All checks passed.")
-	} else {
+	switch {
+	case failed > 0:
 		_, _ = fmt.Fprintln(out, "Some checks failed. See above for details.")
+		return fmt.Errorf("%d critical check(s) failed", failed)
+	case warned > 0:
+		_, _ = fmt.Fprintln(out, "All critical checks passed, with warnings.")
+	default:
+		_, _ = fmt.Fprintln(out, "All checks passed.")
 	}
 
 	return nil
 }
 
+// checkWritable verifies that a file can be created in dir, creating dir if needed
+func checkWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
 func joinWords(words []string) string {
 	switch len(words) {
 	case 0:
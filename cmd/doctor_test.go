@@ -39,7 +39,7 @@ func TestDoctorAllPassing(t *testing.T) {
 
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
-	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 	t.Setenv("HOME", tempDir)
 
 	configDir := filepath.Join(tempDir, "cospend")
@@ -57,19 +57,19 @@ func TestDoctorAllPassing(t *testing.T) {
 	}
 
 	output := stdout.String()
-	if !bytes.Contains([]byte(output), []byte("[ok] Config file")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Config file")) {
 		t.Errorf("Should show config ok, got: %s", output)
 	}
-	if !bytes.Contains([]byte(output), []byte("[ok] Required fields")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Required fields")) {
 		t.Errorf("Should show fields ok, got: %s", output)
 	}
-	if !bytes.Contains([]byte(output), []byte("[ok] Server reachable")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Server reachable")) {
 		t.Errorf("Should show server ok, got: %s", output)
 	}
-	if !bytes.Contains([]byte(output), []byte("[ok] Authentication")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Authentication")) {
 		t.Errorf("Should show auth ok, got: %s", output)
 	}
-	if !bytes.Contains([]byte(output), []byte("[ok] Default project")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Default project")) {
 		t.Errorf("Should show project ok, got: %s", output)
 	}
 	if !bytes.Contains([]byte(output), []byte("All checks passed")) {
@@ -80,19 +80,20 @@ func TestDoctorAllPassing(t *testing.T) {
 func TestDoctorNoConfig(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 	t.Setenv("HOME", tempDir)
 
 	cmd := NewDoctorCommand()
 	var stdout bytes.Buffer
 	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
 
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error when a critical check fails")
 	}
 
 	output := stdout.String()
-	if !bytes.Contains([]byte(output), []byte("[!!] Config file")) {
+	if !bytes.Contains([]byte(output), []byte("✗ Config file")) {
 		t.Errorf("Should show config error, got: %s", output)
 	}
 	if !bytes.Contains([]byte(output), []byte("Some checks failed")) {
@@ -103,6 +104,7 @@ func TestDoctorNoConfig(t *testing.T) {
 func TestDoctorMissingFields(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 	t.Setenv("HOME", tempDir)
 
 	configDir := filepath.Join(tempDir, "cospend")
@@ -112,14 +114,14 @@ func TestDoctorMissingFields(t *testing.T) {
 	cmd := NewDoctorCommand()
 	var stdout bytes.Buffer
 	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
 
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error when a critical check fails")
 	}
 
 	output := stdout.String()
-	if !bytes.Contains([]byte(output), []byte("[!!] Required fields")) {
+	if !bytes.Contains([]byte(output), []byte("✗ Required fields")) {
 		t.Errorf("Should show missing fields, got: %s", output)
 	}
 	if !bytes.Contains([]byte(output), []byte("user and password")) {
@@ -141,7 +143,7 @@ func TestDoctorAuthFailure(t *testing.T) {
 
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
-	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 	t.Setenv("HOME", tempDir)
 
 	configDir := filepath.Join(tempDir, "cospend")
@@ -152,19 +154,22 @@ func TestDoctorAuthFailure(t *testing.T) {
 	cmd := NewDoctorCommand()
 	var stdout bytes.Buffer
 	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
 
-	err := cmd.Execute()
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error when a critical check fails")
 	}
 
 	output := stdout.String()
-	if !bytes.Contains([]byte(output), []byte("[ok] Server reachable")) {
+	if !bytes.Contains([]byte(output), []byte("✓ Server reachable")) {
 		t.Errorf("Server should be reachable, got: %s", output)
 	}
-	if !bytes.Contains([]byte(output), []byte("[!!] Authentication")) {
+	if !bytes.Contains([]byte(output), []byte("✗ Authentication")) {
 		t.Errorf("Auth should fail, got: %s", output)
 	}
+	if !bytes.Contains([]byte(output), []byte("were rejected")) {
+		t.Errorf("Auth failure should explain the credentials were rejected, got: %s", output)
+	}
 }
 
 func TestDoctorNoDefaultProject(t *testing.T) {
@@ -180,7 +185,7 @@ func TestDoctorNoDefaultProject(t *testing.T) {
 
 	tempDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tempDir)
-	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
 	t.Setenv("HOME", tempDir)
 
 	configDir := filepath.Join(tempDir, "cospend")
@@ -206,6 +211,81 @@ func TestDoctorNoDefaultProject(t *testing.T) {
 	}
 }
 
+func TestDoctorCacheNotWritable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ocs/v2.php/cloud/user" {
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US", "language": "en"}))
+		}
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	// A regular file where the cache directory should be
+	blocker := filepath.Join(tempDir, "not-a-dir")
+	_ = os.WriteFile(blocker, nil, 0600)
+	t.Setenv("COSPEND_CACHE_DIR", filepath.Join(blocker, "cache"))
+
+	configDir := filepath.Join(tempDir, "cospend")
+	_ = os.MkdirAll(configDir, 0700)
+	configContent := `{"domain": "` + server.URL + `", "user": "testuser", "password": "testpass"}`
+	_ = os.WriteFile(filepath.Join(configDir, "cospend.json"), []byte(configContent), 0600)
+
+	cmd := NewDoctorCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+
+	// An unwritable cache only warns
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !bytes.Contains([]byte(output), []byte("! Cache directory")) {
+		t.Errorf("Should warn about the cache directory, got: %s", output)
+	}
+	if !bytes.Contains([]byte(output), []byte("→ Fix its permissions")) {
+		t.Errorf("Should show a remediation hint, got: %s", output)
+	}
+	if !bytes.Contains([]byte(output), []byte("with warnings")) {
+		t.Errorf("Should show warning summary, got: %s", output)
+	}
+}
+
+func TestDoctorServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("COSPEND_CACHE_DIR", t.TempDir())
+	t.Setenv("HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "cospend")
+	_ = os.MkdirAll(configDir, 0700)
+	configContent := `{"domain": "` + server.URL + `", "user": "testuser", "password": "testpass"}`
+	_ = os.WriteFile(filepath.Join(configDir, "cospend.json"), []byte(configContent), 0600)
+
+	cmd := NewDoctorCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
+
+	err := cmd.Execute()
+	if err == nil || !bytes.Contains([]byte(err.Error()), []byte("1 critical check(s) failed")) {
+		t.Errorf("Expected one failed check, got: %v", err)
+	}
+
+	output := stdout.String()
+	if !bytes.Contains([]byte(output), []byte("✗ Server reachable")) || !bytes.Contains([]byte(output), []byte("HTTP 502")) {
+		t.Errorf("Server check should fail with the status code, got: %s", output)
+	}
+}
+
 func TestJoinWords(t *testing.T) {
 	tests := []struct {
 		input []string
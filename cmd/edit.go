@@ -74,20 +74,17 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
 
 	// Get project (from cache or API)
-	project, ok := cache.Load(ProjectID)
-	if !ok {
-		project, err = client.GetProject(ProjectID)
-		if err != nil {
-			return fmt.Errorf("fetching project: %w", err)
-		}
-		if err := cache.Save(ProjectID, project); err != nil {
-			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
-		}
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
 	}
 
 	// Fetch all bills to find the existing one
@@ -104,7 +101,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if existing == nil {
-		return fmt.Errorf("bill #%d not found", billID)
+		return fmt.Errorf("%w: #%d", api.ErrBillNotFound, billID)
 	}
 
 	// Build member name lookup
@@ -114,29 +111,20 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Start from existing values
-	bill := api.Bill{
-		What:          existing.What,
-		Amount:        existing.Amount,
-		PayerID:       existing.PayerID,
-		Date:          existing.Date,
-		Comment:       existing.Comment,
-		PaymentModeID: existing.PaymentModeID,
-		CategoryID:    existing.CategoryID,
-		Repeat:        existing.Repeat,
-	}
-	for _, o := range existing.Owers {
-		bill.OwedTo = append(bill.OwedTo, o.ID)
-	}
+	bill := billFromResponse(existing)
 
 	// Apply changes for flags that were explicitly set
 	if cmd.Flags().Changed("name") {
 		bill.What = editName
 	}
 
+	// Resolve the locale for amount parsing and formatting
+	locale := loadLocale(cmd, client, cfg)
+
 	if cmd.Flags().Changed("amount") {
-		amount, err := strconv.ParseFloat(editAmount, 64)
+		amount, err := format.ParseAmount(editAmount, locale)
 		if err != nil {
-			return fmt.Errorf("invalid amount: %s", editAmount)
+			return fmt.Errorf("%w (amounts look like %s)", err, amountFormats)
 		}
 		bill.Amount = amount
 	}
@@ -162,7 +150,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Flags().Changed("date") {
-		parsed, err := parseDate(editDate)
+		parsed, err := parseDate(editDate, loc)
 		if err != nil {
 			return err
 		}
@@ -170,7 +158,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Flags().Changed("category") {
-		categoryID, err := cache.ResolveCategory(project, editCategory)
+		categoryID, err := resolveCategory(cmd, project, editCategory)
 		if err != nil {
 			return fmt.Errorf("resolving category: %w", err)
 		}
@@ -178,7 +166,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Flags().Changed("method") {
-		methodID, err := cache.ResolvePaymentMode(project, editPaymentMethod)
+		methodID, err := resolvePaymentMode(cmd, project, editPaymentMethod)
 		if err != nil {
 			return fmt.Errorf("resolving payment method: %w", err)
 		}
@@ -196,22 +184,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		bill.Repeat = editRepeat
 	}
 
-	// Fetch user info for locale-aware formatting
-	locale := "en_US"
-	userInfo, ok := cache.LoadUserInfo()
-	if !ok {
-		userInfo, err = client.GetUserInfo()
-		if err == nil {
-			_ = cache.SaveUserInfo(userInfo)
-		}
-	}
-	if userInfo != nil && userInfo.Locale != "" {
-		locale = userInfo.Locale
-	} else if userInfo != nil && userInfo.Language != "" {
-		locale = userInfo.Language
-	}
-
-	formatter := format.NewAmountFormatter(locale, project.CurrencyName)
+	formatter := format.NewAmountFormatter(locale, displayCurrency(cfg, project))
 	out := cmd.OutOrStdout()
 
 	printBillSummary := func() {
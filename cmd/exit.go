@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, so that scripts can tell kinds of failures apart
+const (
+	// ExitError is for usage errors and anything not covered below
+	ExitError = 1
+	// ExitConfig is for missing or invalid configuration and rejected credentials
+	ExitConfig = 2
+	// ExitNetwork is for servers that can't be reached or that report an error
+	ExitNetwork = 3
+	// ExitNotFound is for projects and bills that don't exist
+	ExitNotFound = 4
+)
+
+// ExitCode returns the exit code for a command that failed with err
+func ExitCode(err error) int {
+	var urlErr *url.Error
+	switch {
+	case errors.Is(err, api.ErrProjectNotFound), errors.Is(err, api.ErrBillNotFound):
+		return ExitNotFound
+	case errors.Is(err, config.ErrConfig), errors.Is(err, api.ErrUnauthorized):
+		return ExitConfig
+	case errors.Is(err, api.ErrAPI), errors.As(err, &urlErr):
+		return ExitNetwork
+	default:
+		return ExitError
+	}
+}
+
+// jsonError is how errors are printed for commands asked for JSON output
+type jsonError struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// wantsJSON reports whether c was asked for JSON output with --format json
+// or ndjson
+func wantsJSON(c *cobra.Command) bool {
+	f := c.Flags().Lookup("format")
+	return f != nil && (f.Value.String() == "json" || f.Value.String() == "ndjson")
+}
+
+// SetupErrors leaves printing errors, and the usage that follows them, to
+// PrintError
+func SetupErrors(root *cobra.Command) {
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+}
+
+// PrintError prints err from the failed command c to stderr. With --format
+// json it's a JSON object with the message and exit code, like
+// {"error":"project not found","code":4}, so that stderr stays parseable.
+// Otherwise it's printed as cobra does, followed by the usage for errors that
+// happen before the command silences it.
+func PrintError(c *cobra.Command, err error) {
+	if wantsJSON(c) {
+		_ = json.NewEncoder(c.ErrOrStderr()).Encode(jsonError{Error: err.Error(), Code: ExitCode(err)})
+		return
+	}
+
+	c.PrintErrln(c.ErrPrefix(), err.Error())
+	if errors.Is(err, api.ErrUnauthorized) {
+		c.PrintErrln("Run 'cospend init' to update your credentials, or check NEXTCLOUD_USER and NEXTCLOUD_PASSWORD.")
+	}
+	// Errors finding the command come with a pointer to the help
+	if !c.Runnable() {
+		c.PrintErrf("Run '%v --help' for usage.\n", c.CommandPath())
+	} else if !c.SilenceUsage {
+		c.Println(c.UsageString())
+	}
+}
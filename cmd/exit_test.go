@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("NEXTCLOUD_DOMAIN", "")
+	t.Setenv("NEXTCLOUD_USER", "")
+	t.Setenv("NEXTCLOUD_PASSWORD", "")
+	_, configErr := config.Load()
+
+	// Nothing listens on port 1 of localhost
+	_, networkErr := http.Get("http://127.0.0.1:1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	_, apiErr := api.NewClient(&config.Config{Domain: server.URL, User: "u", Password: "p"}).GetBills("p")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"usage", errors.New("project is required (use -p or --project)"), ExitError},
+		{"config", configErr, ExitConfig},
+		{"unauthorized", fmt.Errorf("fetching project: %w", api.ErrUnauthorized), ExitConfig},
+		{"network", fmt.Errorf("fetching bills: %w", networkErr), ExitNetwork},
+		{"api", apiErr, ExitNetwork},
+		{"project not found", &projectNotFoundError{ID: "tirp"}, ExitNotFound},
+		{"bill not found", fmt.Errorf("%w: #5", api.ErrBillNotFound), ExitNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("test error is nil")
+			}
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	run := func(args ...string) string {
+		var format string
+		root := &cobra.Command{Use: "cospend"}
+		sub := &cobra.Command{
+			Use: "list",
+			RunE: func(c *cobra.Command, _ []string) error {
+				c.SilenceUsage = true
+				return &projectNotFoundError{ID: "tirp", Suggestions: []string{"trip"}}
+			},
+		}
+		sub.Flags().StringVar(&format, "format", "table", "Output format")
+		root.AddCommand(sub)
+		SetupErrors(root)
+
+		var stderr bytes.Buffer
+		root.SetOut(&stderr)
+		root.SetErr(&stderr)
+		root.SetArgs(args)
+		c, err := root.ExecuteC()
+		if err == nil {
+			t.Fatal("Expected error")
+		}
+		PrintError(c, err)
+		return stderr.String()
+	}
+
+	out := run("list", "--format", "json")
+	var got jsonError
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("Expected JSON error, got %q: %v", out, err)
+	}
+	want := jsonError{Error: `project "tirp" not found. Did you mean: trip?`, Code: ExitNotFound}
+	if got != want {
+		t.Errorf("JSON error = %+v, want %+v", got, want)
+	}
+
+	// JSON Lines consumers get the same single-line object
+	if out := run("list", "--format", "ndjson"); strings.TrimSpace(out) != `{"error":"project \"tirp\" not found. Did you mean: trip?","code":4}` {
+		t.Errorf("Unexpected ndjson error: %q", out)
+	}
+
+	if out := run("list"); out != "Error: project \"tirp\" not found. Did you mean: trip?\n" {
+		t.Errorf("Unexpected text error: %q", out)
+	}
+
+	// Usage errors are JSON too, without the usage text
+	out = run("list", "--format", "json", "--bogus")
+	if strings.TrimSpace(out) != `{"error":"unknown flag: --bogus","code":1}` {
+		t.Errorf("Unexpected JSON usage error: %q", out)
+	}
+	if out := run("list", "--bogus"); !strings.Contains(out, "Usage:") {
+		t.Errorf("Text usage errors should include the usage, got: %q", out)
+	}
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAllProjects bool
+	exportFormat      string
+	exportOutput      string
+)
+
+// projectBill is a resolved bill tagged with the project it belongs to
+type projectBill struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Currency    string `json:"currency"`
+	resolvedBill
+}
+
+// projectBills holds the filtered bills of one project, or the error that
+// prevented fetching them
+type projectBills struct {
+	project *api.Project
+	bills   []resolvedBill
+	err     error
+}
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export bills from one or all projects",
+		Long: `Export bills as CSV, TSV, or JSON, with project ID, name, and currency
+columns on every row.
+
+With --all-projects, bills from every active (non-archived) project are
+fetched concurrently and written as a single stream. The list filters apply
+to each project.
+
+Examples:
+  cospend export -p myproject
+  cospend export --all-projects --format csv -O expenses.csv
+  cospend export --all-projects --year 2026
+  cospend export --all-projects --this-month --format json
+  cospend export --all-projects --year 2026 --format html -O report.html`,
+		Args: cobra.NoArgs,
+		RunE: runExport,
+	}
+
+	addFilterFlags(cmd)
+	cmd.Flags().BoolVar(&exportAllProjects, "all-projects", false, "Export bills from all active projects")
+	cmd.Flags().StringVar(&exportFormat, "format", "csv", "Output format: csv, tsv, json, html")
+	cmd.Flags().StringVarP(&exportOutput, "output", "O", "", "Write output to a file instead of stdout")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	if !exportAllProjects && ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project, or --all-projects)")
+	}
+	if exportAllProjects && ProjectID != "" {
+		return fmt.Errorf("--all-projects can't be used with --project")
+	}
+
+	switch exportFormat {
+	case "csv", "tsv", "json", "html":
+	default:
+		return fmt.Errorf("unsupported format: %s (expected csv, tsv, json, or html)", exportFormat)
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	layout, err := dateLayout(cfg)
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Get API client
+	client := newClient(cmd, cfg)
+
+	ids := []string{ProjectID}
+	if exportAllProjects {
+		projects, err := client.GetProjects()
+		if err != nil {
+			return fmt.Errorf("fetching projects: %w", err)
+		}
+		ids = nil
+		for _, proj := range projects {
+			if !proj.IsArchived() {
+				ids = append(ids, proj.ID)
+			}
+		}
+	}
+
+	results := fetchProjectBills(cmd, client, ids, cfg.User, loc, cfg.Workers)
+
+	// A single project failing is fatal; with --all-projects, warn and skip it
+	var rows []projectBill
+	for i, res := range results {
+		if res.err != nil {
+			if !exportAllProjects {
+				return res.err
+			}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping project %s: %v\n", ids[i], res.err)
+			continue
+		}
+		bills := res.bills
+		// JSON keeps ISO dates, so import can read it back
+		if exportFormat != "json" {
+			bills = formatBillDates(bills, layout)
+		}
+		for _, bill := range bills {
+			rows = append(rows, projectBill{
+				ProjectID:    res.project.ID,
+				ProjectName:  res.project.Name,
+				Currency:     displayCurrency(cfg, res.project),
+				resolvedBill: bill,
+			})
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	var file *outputFile
+	if exportOutput != "" {
+		file, err = createOutputFile(exportOutput)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	switch exportFormat {
+	case "tsv":
+		printProjectBillsCSV(out, rows, '\t')
+	case "json":
+		printProjectBillsJSON(out, rows)
+	case "html":
+		report := projectBillsHTMLReport(rows, loadLocale(cmd, client, cfg))
+		if err := printBillsHTML(out, report); err != nil {
+			return err
+		}
+	default:
+		printProjectBillsCSV(out, rows, ',')
+	}
+
+	if file != nil {
+		if err := file.Close(); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d bill(s) to %s\n", len(rows), exportOutput)
+	}
+
+	return nil
+}
+
+// fetchProjectBills loads each project and its filtered bills concurrently.
+// Results are returned in the order of ids. user is the authenticated user, for
+// --mine, and loc is the time zone of date filters like --today.
+func fetchProjectBills(cmd *cobra.Command, client *api.Client, ids []string, user string, loc *time.Location, workers int) []projectBills {
+	results := make([]projectBills, len(ids))
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching bills...")
+	_ = api.ForEachProject(ids, workers, func(id string) error {
+		res := &results[index[id]]
+		res.err = func() error {
+			var project *api.Project
+			ok := false
+			if !NoCache {
+				project, ok = cache.Load(id)
+			}
+			if !ok {
+				var err error
+				project, err = client.GetProject(id)
+				if err != nil {
+					return fmt.Errorf("fetching project: %w", err)
+				}
+				// Caching is best-effort here; a failure only costs a refetch
+				if !NoCache {
+					_ = cache.Save(id, project)
+				}
+			}
+			res.project = project
+
+			filters, err := buildFilters(project, user, loc)
+			if err != nil {
+				return err
+			}
+			bills, err := client.GetBills(id)
+			if err != nil {
+				return fmt.Errorf("fetching bills: %w", err)
+			}
+			res.bills = resolveBillNames(project, applyFilters(bills, filters))
+			return nil
+		}()
+		return res.err
+	})
+	stop()
+
+	return results
+}
+
+// printProjectBillsCSV writes bills as delimiter-separated values with the
+// project columns ahead of the regular list columns
+func printProjectBillsCSV(out io.Writer, rows []projectBill, comma rune) {
+	w := csv.NewWriter(out)
+	w.Comma = comma
+
+	_ = w.Write(append([]string{"Project ID", "Project Name", "Currency"}, billCSVHeader()...))
+	for _, row := range rows {
+		_ = w.Write(append([]string{row.ProjectID, row.ProjectName, row.Currency}, billCSVRecord(row.resolvedBill)...))
+	}
+	w.Flush()
+}
+
+// projectBillsHTMLReport builds the HTML report for exported bills, formatting
+// each amount in its project's currency. Amounts in different currencies can't
+// be added up, so there is a totals row per currency.
+func projectBillsHTMLReport(rows []projectBill, locale string) htmlReport {
+	report := htmlReport{Title: "Expenses", ShowProject: true}
+
+	var currencies []string
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, row := range rows {
+		formatter := format.NewAmountFormatter(locale, row.Currency)
+		htmlRow := newHTMLRow(row.resolvedBill, formatter, nil)
+		htmlRow.Project = row.ProjectName
+		report.Rows = append(report.Rows, htmlRow)
+
+		if _, ok := totals[row.Currency]; !ok {
+			currencies = append(currencies, row.Currency)
+		}
+		totals[row.Currency] += row.Amount
+		counts[row.Currency]++
+	}
+
+	for _, currency := range currencies {
+		label := fmt.Sprintf("Total: %d bill(s)", counts[currency])
+		if len(currencies) > 1 && currency != "" {
+			label = fmt.Sprintf("Total %s: %d bill(s)", currency, counts[currency])
+		}
+		report.Totals = append(report.Totals, htmlTotal{
+			Label:  label,
+			Amount: format.NewAmountFormatter(locale, currency).Format(totals[currency]),
+		})
+	}
+	return report
+}
+
+func printProjectBillsJSON(out io.Writer, rows []projectBill) {
+	if rows == nil {
+		rows = []projectBill{}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(rows)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func newExportTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	archived := int64(1700000000)
+	summaries := []api.ProjectSummary{
+		{ID: "home", Name: "Home", CurrName: "USD"},
+		{ID: "trip", Name: "Trip", CurrName: "EUR"},
+		{ID: "old", Name: "Old", ArchivedTS: &archived},
+	}
+	projects := map[string]map[string]any{
+		"home": {"id": "home", "name": "Home", "currencyname": "USD", "members": []api.Member{{ID: 1, Name: "Alice", UserID: "alice", Activated: true}}},
+		"trip": {"id": "trip", "name": "Trip", "currencyname": "EUR", "members": []api.Member{{ID: 7, Name: "Alice", UserID: "alice", Activated: true}}},
+	}
+	bills := map[string][]api.BillResponse{
+		"home": {
+			{ID: 1, What: "Rent", Amount: 1000, Date: "2026-01-01", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+			{ID: 2, What: "Old rent", Amount: 900, Date: "2025-12-01", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+		},
+		"trip": {
+			{ID: 3, What: "Hotel", Amount: 250, Date: "2026-03-10", PayerID: 7, Owers: []api.Ower{{ID: 7}}},
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/ocs/v2.php/apps/cospend/api/v1/projects"
+		switch {
+		case r.URL.Path == prefix:
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, summaries))
+		case strings.HasSuffix(r.URL.Path, "/bills"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix+"/"), "/bills")
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills[id]}))
+		case strings.HasPrefix(r.URL.Path, prefix+"/"):
+			proj, ok := projects[strings.TrimPrefix(r.URL.Path, prefix+"/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, proj))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func resetExportFlags() {
+	resetListFlags()
+	exportAllProjects = false
+	exportFormat = "csv"
+	exportOutput = ""
+}
+
+func TestExportAllProjects(t *testing.T) {
+	server := newExportTestServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetExportFlags()
+	defer resetExportFlags()
+
+	cmd := NewExportCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--all-projects", "--year", "2026"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV output: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d:\n%v", len(records), records)
+	}
+	if records[0][0] != "Project ID" || records[0][1] != "Project Name" || records[0][2] != "Currency" {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+	if records[1][0] != "home" || records[1][5] != "Rent" {
+		t.Errorf("Expected home's 2026 bill first, got %v", records[1])
+	}
+	if records[2][0] != "trip" || records[2][2] != "EUR" || records[2][5] != "Hotel" {
+		t.Errorf("Expected trip bill second, got %v", records[2])
+	}
+}
+
+func TestExportFiltersResolvePerProject(t *testing.T) {
+	server := newExportTestServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetExportFlags()
+	defer resetExportFlags()
+
+	// alice has a different member ID in each project
+	cmd := NewExportCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--all-projects", "--by", "alice", "--format", "json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var rows []projectBill
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 bills, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if row.PaidBy != "Alice" || row.ProjectID == "" {
+			t.Errorf("Unexpected row: %+v", row)
+		}
+	}
+}
+
+func TestExportRequiresProject(t *testing.T) {
+	resetExportFlags()
+	defer resetExportFlags()
+
+	cmd := NewExportCommand()
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error without --project or --all-projects")
+	}
+
+	cmd = NewExportCommand()
+	cmd.SetArgs([]string{"--all-projects", "--format", "table"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
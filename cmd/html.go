@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/format"
+)
+
+// htmlReport is the data rendered by the HTML bill report
+type htmlReport struct {
+	Title        string
+	ShowProject  bool
+	ShowOriginal bool
+	Rows         []htmlRow
+	Totals       []htmlTotal
+}
+
+// htmlRow is one bill in the HTML report, with amounts already formatted
+type htmlRow struct {
+	Project       string
+	ID            int
+	Date          string
+	Name          string
+	Amount        string
+	Original      string
+	PaidBy        string
+	PaidFor       string
+	Category      string
+	PaymentMethod string
+}
+
+// htmlTotal is a totals row; export has one per currency
+type htmlTotal struct {
+	Label    string
+	Amount   string
+	Original string
+}
+
+// LabelSpan is the number of columns before the amount column, which the
+// totals label spans
+func (r htmlReport) LabelSpan() int {
+	if r.ShowProject {
+		return 4
+	}
+	return 3
+}
+
+var billsHTMLTemplate = template.Must(template.New("bills").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ddd; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+tbody tr:nth-child(even) td { background: #fafafa; }
+.amount { text-align: right; white-space: nowrap; }
+tfoot td { font-weight: bold; background: #f4f4f4; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<thead>
+<tr>{{if .ShowProject}}<th>Project</th>{{end}}<th>ID</th><th>Date</th><th>Name</th><th class="amount">Amount</th>{{if .ShowOriginal}}<th class="amount">Original Amount</th>{{end}}<th>Paid By</th><th>Paid For</th><th>Category</th><th>Payment Method</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{if $.ShowProject}}<td>{{.Project}}</td>{{end}}<td>{{.ID}}</td><td>{{.Date}}</td><td>{{.Name}}</td><td class="amount">{{.Amount}}</td>{{if $.ShowOriginal}}<td class="amount">{{.Original}}</td>{{end}}<td>{{.PaidBy}}</td><td>{{.PaidFor}}</td><td>{{.Category}}</td><td>{{.PaymentMethod}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+{{- range .Totals}}
+<tr><td colspan="{{$.LabelSpan}}">{{.Label}}</td><td class="amount">{{.Amount}}</td>{{if $.ShowOriginal}}<td class="amount">{{.Original}}</td>{{end}}<td colspan="4"></td></tr>
+{{- end}}
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// printBillsHTML writes the report as a self-contained HTML page
+func printBillsHTML(out io.Writer, report htmlReport) error {
+	if err := billsHTMLTemplate.Execute(out, report); err != nil {
+		return fmt.Errorf("rendering HTML: %w", err)
+	}
+	return nil
+}
+
+// newHTMLRow formats a bill for the HTML report. Icons are included with
+// --show-icons, as in the table.
+func newHTMLRow(bill resolvedBill, formatter, origFormatter *format.AmountFormatter) htmlRow {
+	row := htmlRow{
+		ID:            bill.ID,
+		Date:          bill.Date,
+		Name:          bill.Name,
+		Amount:        formatter.Format(bill.Amount),
+		PaidBy:        bill.PaidBy,
+		PaidFor:       strings.Join(bill.PaidFor, ", "),
+		Category:      bill.Category,
+		PaymentMethod: bill.PaymentMethod,
+	}
+	if listShowIcons {
+		row.Category = withIcon(bill.CategoryIcon, bill.Category)
+		row.PaymentMethod = withIcon(bill.PaymentMethodIcon, bill.PaymentMethod)
+	}
+	if origFormatter != nil && bill.OriginalAmount != nil {
+		row.Original = origFormatter.Format(*bill.OriginalAmount)
+	}
+	return row
+}
+
+// billsHTMLReport builds the list report for a single project. When
+// origFormatter is non-nil, the unconverted amounts are shown too.
+func billsHTMLReport(title string, bills []resolvedBill, formatter, origFormatter *format.AmountFormatter) htmlReport {
+	report := htmlReport{Title: title, ShowOriginal: origFormatter != nil}
+
+	var total, totalOriginal float64
+	for _, bill := range bills {
+		report.Rows = append(report.Rows, newHTMLRow(bill, formatter, origFormatter))
+		total += bill.Amount
+		if bill.OriginalAmount != nil {
+			totalOriginal += *bill.OriginalAmount
+		}
+	}
+
+	totalRow := htmlTotal{
+		Label:  fmt.Sprintf("Total: %d bill(s)", len(bills)),
+		Amount: formatter.Format(total),
+	}
+	if origFormatter != nil {
+		totalRow.Original = origFormatter.Format(totalOriginal)
+	}
+	report.Totals = []htmlTotal{totalRow}
+	return report
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/format"
+)
+
+var updateGolden = flag.Bool("update", false, "Rewrite golden files in testdata")
+
+func TestPrintBillsHTMLGolden(t *testing.T) {
+	bills := []resolvedBill{
+		{ID: 2, Date: "2026-01-11", Name: "Fish & Chips <b>", Amount: 1234.5, PaidBy: "Bob", PaidFor: []string{"Alice", "Bob"}, Category: "Restaurant", PaymentMethod: "Card"},
+		{ID: 1, Date: "2026-01-10", Name: `<script>alert("x")</script>`, Amount: 20, PaidBy: "Alice", PaidFor: []string{"Alice"}},
+	}
+	formatter := format.NewAmountFormatter("en_US", "USD")
+
+	var buf bytes.Buffer
+	if err := printBillsHTML(&buf, billsHTMLReport("Trip \"2026\"", bills, formatter, nil)); err != nil {
+		t.Fatalf("printBillsHTML() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "bills.html")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+	}
+	if buf.String() != string(want) {
+		t.Errorf("HTML output doesn't match %s (run with -update to accept):\n%s", golden, buf.String())
+	}
+}
+
+func TestProjectBillsHTMLReportTotals(t *testing.T) {
+	rows := []projectBill{
+		{ProjectName: "Home", Currency: "USD", resolvedBill: resolvedBill{ID: 1, Name: "Rent", Amount: 1000}},
+		{ProjectName: "Trip", Currency: "EUR", resolvedBill: resolvedBill{ID: 2, Name: "Hotel", Amount: 250}},
+		{ProjectName: "Home", Currency: "USD", resolvedBill: resolvedBill{ID: 3, Name: "Power", Amount: 80.5}},
+	}
+
+	report := projectBillsHTMLReport(rows, "en_US")
+	if len(report.Rows) != 3 || report.Rows[1].Project != "Trip" || report.Rows[1].Amount != "€ 250.00" {
+		t.Errorf("Unexpected rows: %+v", report.Rows)
+	}
+	if len(report.Totals) != 2 {
+		t.Fatalf("Expected a total per currency, got %+v", report.Totals)
+	}
+	if report.Totals[0].Label != "Total USD: 2 bill(s)" || report.Totals[0].Amount != "$ 1,080.50" {
+		t.Errorf("Unexpected USD total: %+v", report.Totals[0])
+	}
+	if report.Totals[1].Label != "Total EUR: 1 bill(s)" || report.Totals[1].Amount != "€ 250.00" {
+		t.Errorf("Unexpected EUR total: %+v", report.Totals[1])
+	}
+
+	var buf bytes.Buffer
+	if err := printBillsHTML(&buf, report); err != nil {
+		t.Fatalf("printBillsHTML() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "<th>Project</th>") || !strings.Contains(buf.String(), `<td colspan="4">Total USD: 2 bill(s)</td>`) {
+		t.Errorf("Expected project column and totals, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+var importDryRun bool
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import bills from a JSON file",
+		Long: `Create bills in a Cospend project from a JSON file.
+
+The file must contain an array of bills in the same shape as
+'cospend list --format json', so bills can be copied between projects.
+Use "-" to read from stdin.
+
+Members, categories, and payment methods are resolved by name in the target
+project. If any bill can't be resolved, nothing is imported.
+
+Examples:
+  cospend list -p old --format json > bills.json
+  cospend import bills.json -p new
+  cospend import bills.json -p new --dry-run
+  cospend list -p old --format json | cospend import - -p new`,
+		Args: cobra.ExactArgs(1),
+		RunE: runImport,
+	}
+
+	cmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show the bills that would be created without creating them")
+
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	records, err := readImportFile(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	// Resolve all records before creating anything
+	bills := make([]api.Bill, 0, len(records))
+	var failed int
+	for i, record := range records {
+		bill, err := billFromResolved(project, record, loc)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Error: bill %d (%s): %v\n", i+1, record.Name, err)
+			failed++
+			continue
+		}
+		bills = append(bills, bill)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d bill(s) could not be resolved; nothing was imported", failed, len(records))
+	}
+
+	out := cmd.OutOrStdout()
+
+	if importDryRun {
+		formatter := format.NewAmountFormatter(loadLocale(cmd, client, cfg), displayCurrency(cfg, project))
+		_, _ = fmt.Fprintf(out, "Would import %d bill(s):\n", len(records))
+		table := NewTable("DATE", "NAME", "AMOUNT", "PAID BY", "PAID FOR")
+		for i, record := range records {
+			paidFor := record.PaidBy
+			if len(record.PaidFor) > 0 {
+				paidFor = strings.Join(record.PaidFor, ", ")
+			}
+			table.AddRow(bills[i].Date, record.Name, formatter.Format(record.Amount), record.PaidBy, paidFor)
+		}
+		table.Render(out)
+		return nil
+	}
+
+	for i, bill := range bills {
+		if _, err := client.CreateBill(ProjectID, bill); err != nil {
+			return fmt.Errorf("creating bill %d (%s): %w (%d of %d imported)", i+1, bill.What, err, i, len(bills))
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "Imported %d bill(s)\n", len(bills))
+	return nil
+}
+
+// readImportFile reads a JSON array of bills from path, or from stdin when path is "-"
+func readImportFile(cmd *cobra.Command, path string) ([]resolvedBill, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading import file: %w", err)
+	}
+
+	var records []resolvedBill
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parsing import file: %w", err)
+	}
+	return records, nil
+}
+
+// billFromResolved converts a bill with member, category, and payment method
+// names back into an api.Bill using the IDs from the given project. Bills
+// without a date are dated today in loc.
+func billFromResolved(project *api.Project, record resolvedBill, loc *time.Location) (api.Bill, error) {
+	if strings.TrimSpace(record.Name) == "" {
+		return api.Bill{}, fmt.Errorf("name is required")
+	}
+
+	payerID, err := cache.ResolveMember(project, record.PaidBy)
+	if err != nil {
+		return api.Bill{}, fmt.Errorf("resolving payer: %w", err)
+	}
+
+	// Default to payer only, like add
+	owedIDs := []int{payerID}
+	if len(record.PaidFor) > 0 {
+		owedIDs = nil
+		for _, name := range record.PaidFor {
+			memberID, err := cache.ResolveMember(project, name)
+			if err != nil {
+				return api.Bill{}, fmt.Errorf("resolving owed member: %w", err)
+			}
+			owedIDs = append(owedIDs, memberID)
+		}
+	}
+
+	date := record.Date
+	if date == "" {
+		date = now().In(loc).Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return api.Bill{}, fmt.Errorf("invalid date: %s (expected YYYY-MM-DD)", date)
+	}
+
+	bill := api.Bill{
+		What:    record.Name,
+		Amount:  record.Amount,
+		PayerID: payerID,
+		OwedTo:  owedIDs,
+		Date:    date,
+		Comment: record.Comment,
+	}
+
+	if record.Category != "" {
+		categoryID, err := cache.ResolveCategory(project, record.Category)
+		if err != nil {
+			return api.Bill{}, fmt.Errorf("resolving category: %w", err)
+		}
+		bill.CategoryID = categoryID
+	}
+
+	if record.PaymentMethod != "" {
+		methodID, err := cache.ResolvePaymentMode(project, record.PaymentMethod)
+		if err != nil {
+			return api.Bill{}, fmt.Errorf("resolving payment method: %w", err)
+		}
+		bill.PaymentModeID = methodID
+	}
+
+	return bill, nil
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func importTestProject() api.Project {
+	return api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice"},
+			{ID: 2, Name: "Bob", UserID: "bob"},
+		},
+		Categories:   []api.Category{{ID: 5, Name: "Food"}},
+		PaymentModes: []api.PaymentMode{{ID: 7, Name: "Card"}},
+	}
+}
+
+func TestBillFromResolved(t *testing.T) {
+	project := importTestProject()
+
+	bill, err := billFromResolved(&project, resolvedBill{
+		ID:            99,
+		Date:          "2026-01-10",
+		Name:          "Lunch",
+		Amount:        20.5,
+		PaidBy:        "Alice",
+		PaidFor:       []string{"Alice", "Bob"},
+		Category:      "Food",
+		PaymentMethod: "Card",
+		Comment:       "With the team",
+	}, time.Local)
+	if err != nil {
+		t.Fatalf("billFromResolved() error = %v", err)
+	}
+
+	if bill.What != "Lunch" || bill.Amount != 20.5 || bill.Date != "2026-01-10" || bill.Comment != "With the team" {
+		t.Errorf("Unexpected bill fields: %+v", bill)
+	}
+	if bill.PayerID != 1 {
+		t.Errorf("PayerID = %d, want 1", bill.PayerID)
+	}
+	if len(bill.OwedTo) != 2 || bill.OwedTo[0] != 1 || bill.OwedTo[1] != 2 {
+		t.Errorf("OwedTo = %v, want [1 2]", bill.OwedTo)
+	}
+	if bill.CategoryID != 5 || bill.PaymentModeID != 7 {
+		t.Errorf("CategoryID = %d, PaymentModeID = %d, want 5 and 7", bill.CategoryID, bill.PaymentModeID)
+	}
+}
+
+func TestBillFromResolvedDefaults(t *testing.T) {
+	project := importTestProject()
+
+	bill, err := billFromResolved(&project, resolvedBill{Name: "Taxi", Amount: 10, PaidBy: "bob"}, time.Local)
+	if err != nil {
+		t.Fatalf("billFromResolved() error = %v", err)
+	}
+	if len(bill.OwedTo) != 1 || bill.OwedTo[0] != 2 {
+		t.Errorf("OwedTo = %v, want payer only", bill.OwedTo)
+	}
+	if bill.Date == "" {
+		t.Error("Date should default to today")
+	}
+	if bill.CategoryID != 0 || bill.PaymentModeID != 0 {
+		t.Errorf("Expected no category or method, got %+v", bill)
+	}
+}
+
+func TestBillFromResolvedErrors(t *testing.T) {
+	project := importTestProject()
+
+	tests := []struct {
+		name   string
+		record resolvedBill
+		want   string
+	}{
+		{"missing name", resolvedBill{PaidBy: "Alice"}, "name is required"},
+		{"unknown payer", resolvedBill{Name: "X", PaidBy: "Carol"}, "resolving payer"},
+		{"unknown ower", resolvedBill{Name: "X", PaidBy: "Alice", PaidFor: []string{"Carol"}}, "resolving owed member"},
+		{"unknown category", resolvedBill{Name: "X", PaidBy: "Alice", Category: "Travel"}, "resolving category"},
+		{"unknown method", resolvedBill{Name: "X", PaidBy: "Alice", PaymentMethod: "Cash"}, "resolving payment method"},
+		{"invalid date", resolvedBill{Name: "X", PaidBy: "Alice", Date: "10/01/2026"}, "invalid date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := billFromResolved(&project, tt.record, time.Local)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("billFromResolved() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func setupImportServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	project := importTestProject()
+
+	var mu sync.Mutex
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = r.ParseForm()
+			mu.Lock()
+			created = append(created, r.FormValue("what"))
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 1))
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case r.URL.Path == "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	return server, &created
+}
+
+func writeImportFile(t *testing.T, bills []resolvedBill) string {
+	t.Helper()
+	data, err := json.Marshal(bills)
+	if err != nil {
+		t.Fatalf("Failed to marshal bills: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bills.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write import file: %v", err)
+	}
+	return path
+}
+
+func TestImportCommand(t *testing.T) {
+	server, created := setupImportServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	path := writeImportFile(t, []resolvedBill{
+		{Name: "Lunch", Amount: 20, Date: "2026-01-10", PaidBy: "Alice", Category: "Food"},
+		{Name: "Taxi", Amount: 40, Date: "2026-01-11", PaidBy: "Bob", PaidFor: []string{"Alice"}},
+	})
+
+	ProjectID = "test-project"
+	cmd := NewImportCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{path})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(*created) != 2 || (*created)[0] != "Lunch" || (*created)[1] != "Taxi" {
+		t.Errorf("Created bills = %v, want [Lunch Taxi]", *created)
+	}
+	if !strings.Contains(buf.String(), "Imported 2 bill(s)") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestImportCommandDryRun(t *testing.T) {
+	server, created := setupImportServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewImportCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetIn(strings.NewReader(`[{"name": "Lunch", "amount": 20, "date": "2026-01-10", "paid_by": "Alice"}]`))
+	cmd.SetArgs([]string{"-", "--dry-run"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(*created) != 0 {
+		t.Errorf("Dry run should not create bills, created %v", *created)
+	}
+	output := buf.String()
+	for _, want := range []string{"Would import 1 bill(s)", "Lunch", "$ 20.00", "Alice"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestImportCommandResolutionFailure(t *testing.T) {
+	server, created := setupImportServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	path := writeImportFile(t, []resolvedBill{
+		{Name: "Lunch", Amount: 20, PaidBy: "Alice"},
+		{Name: "Hotel", Amount: 200, PaidBy: "Carol"},
+	})
+
+	ProjectID = "test-project"
+	cmd := NewImportCommand()
+	errBuf := new(bytes.Buffer)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{path})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for unresolvable bill")
+	}
+	if !strings.Contains(err.Error(), "1 of 2") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(errBuf.String(), "bill 2 (Hotel)") || !strings.Contains(errBuf.String(), "Carol") {
+		t.Errorf("Expected per-record error, got: %s", errBuf.String())
+	}
+	if len(*created) != 0 {
+		t.Errorf("No bills should be created on resolution failure, created %v", *created)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -27,6 +28,10 @@ func NewInfoCommand() *cobra.Command {
 }
 
 func runInfo(cmd *cobra.Command, _ []string) error {
+	if infoCached && NoCache {
+		return fmt.Errorf("--cached can't be used with --no-cache")
+	}
+
 	cmd.SilenceUsage = true
 
 	cfg, err := config.Load()
@@ -34,9 +39,7 @@ func runInfo(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	client := newClient(cmd, cfg)
 
 	var userInfo *api.UserInfo
 	if infoCached {
@@ -47,7 +50,9 @@ func runInfo(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return fmt.Errorf("fetching user info: %w", err)
 		}
-		_ = cache.SaveUserInfo(userInfo)
+		if !NoCache {
+			_ = cache.SaveUserInfo(userInfo)
+		}
 	}
 
 	server := config.NormalizeURL(cfg.Domain)
@@ -65,21 +70,34 @@ func runInfo(cmd *cobra.Command, _ []string) error {
 		}
 		if project == nil {
 			project, err = client.GetProject(ProjectID)
+			if errors.Is(err, api.ErrProjectNotFound) {
+				return suggestProjects(client, ProjectID, err)
+			}
 			if err != nil {
 				return fmt.Errorf("fetching project: %w", err)
 			}
-			if err := cache.Save(ProjectID, project); err != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
+			if !NoCache {
+				if err := cache.Save(ProjectID, project); err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
+				}
 			}
 		}
 
 		_, _ = fmt.Fprintf(out, "\nProject:  %s\n", project.Name)
 		_, _ = fmt.Fprintf(out, "Currency: %s\n", project.CurrencyName)
+		if project.DeletionDisabled {
+			_, _ = fmt.Fprintln(out, "Bills can't be deleted in this project")
+		}
 
 		_, _ = fmt.Fprintln(out)
-		membersTable := NewTable("ID", "Name", "UserID")
+		membersTable := NewTable("ID", "Name", "UserID", "Weight")
 		for _, m := range project.Members {
-			membersTable.AddRow(strconv.Itoa(m.ID), m.Name, m.UserID)
+			// Servers that don't report weights leave them at 0
+			weight := "-"
+			if m.Weight > 0 {
+				weight = strconv.FormatFloat(m.Weight, 'f', -1, 64)
+			}
+			membersTable.AddRow(strconv.Itoa(m.ID), m.Name, m.UserID, weight)
 		}
 		_, _ = fmt.Fprintln(out, "Members:")
 		membersTable.Render(out)
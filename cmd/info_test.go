@@ -86,6 +86,36 @@ func TestInfoCommandNormalizesURL(t *testing.T) {
 	}
 }
 
+func TestInfoCommandSubpath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nextcloud/ocs/v2.php/cloud/user" {
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{
+				"locale":   "en_US",
+				"language": "en",
+			}))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// A pasted app URL keeps the subpath the instance is served from
+	cleanup := setupTestEnv(t, server.URL+"/nextcloud/index.php/apps/cospend")
+	defer cleanup()
+
+	cmd := NewInfoCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "Server:   "+server.URL+"/nextcloud\n") {
+		t.Errorf("Expected server with subpath, got:\n%s", stdout.String())
+	}
+}
+
 func TestInfoCommandAPIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -112,9 +142,10 @@ func TestInfoCommandWithProject(t *testing.T) {
 		Name:         "Test Project",
 		CurrencyName: "EUR",
 		Members: []api.Member{
-			{ID: 1, Name: "Alice", UserID: "alice"},
-			{ID: 2, Name: "Bob", UserID: "bob"},
+			{ID: 1, Name: "Alice", UserID: "alice", Weight: 1},
+			{ID: 2, Name: "Bob", UserID: "bob", Weight: 2.5},
 		},
+		DeletionDisabled: true,
 		Categories: []api.Category{
 			{ID: 5, Name: "Food", Icon: "\U0001F354", Color: "#ff0000"},
 			{ID: 12, Name: "Transport", Icon: "\U0001F697", Color: "#00ff00"},
@@ -161,9 +192,11 @@ func TestInfoCommandWithProject(t *testing.T) {
 	expected := []string{
 		"Project:  Test Project",
 		"Currency: EUR",
+		"Bills can't be deleted in this project",
 		"Members:",
 		"Alice",
 		"Bob",
+		"2.5",
 		"Categories:",
 		"Food",
 		"Transport",
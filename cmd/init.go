@@ -1,23 +1,34 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
-	"runtime"
+	"os/signal"
 	"strings"
 	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/prompt"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
-var configFormat string
+var (
+	configFormat       string
+	initKeyring        bool
+	initNoVerify       bool
+	initNonInteractive bool
+	initDomain         string
+	initUser           string
+	initPassword       string
+	initNoBrowser      bool
+	initLoginTimeout   time.Duration
+	initPollInterval   time.Duration
+)
 
 // NewInitCommand creates the init command
 func NewInitCommand() *cobra.Command {
@@ -32,11 +43,42 @@ and password, then save them to a config file.
 Config file location:
   Linux:   ~/.config/cospend/cospend.{ext}
   macOS:   ~/Library/Application Support/cospend/cospend.{ext}
-  Windows: %APPDATA%\cospend\cospend.{ext}`,
+  Windows: %APPDATA%\cospend\cospend.{ext}
+
+The credentials are verified against the server before saving. Use
+--no-verify to skip this check (e.g. when setting up offline).
+
+Use --keyring to store the password in the OS keyring instead of the
+config file.
+
+On headless systems (SSH sessions or no display), or with --no-browser,
+browser login prints the login URL to open on another device instead of
+launching a browser, then waits for you to finish logging in. Use
+--login-timeout and --poll-interval to control how long and how often it
+checks; Ctrl+C cancels the wait.
+
+For provisioning in CI or containers, use --non-interactive with --domain,
+--user, and --password (an app token) to skip all prompts. Missing values
+fall back to NEXTCLOUD_DOMAIN, NEXTCLOUD_USER, and NEXTCLOUD_PASSWORD. An
+existing config file is overwritten.
+
+Examples:
+  cospend init
+  cospend init --format yaml --keyring
+  cospend init --non-interactive --domain cloud.example.com --user alice --password "$TOKEN"`,
 		RunE: runInit,
 	}
 
 	cmd.Flags().StringVarP(&configFormat, "format", "f", "json", "Config file format (json, yaml, toml)")
+	cmd.Flags().BoolVar(&initKeyring, "keyring", false, "Store the password in the OS keyring instead of the config file")
+	cmd.Flags().BoolVar(&initNoVerify, "no-verify", false, "Skip verifying the credentials against the server")
+	cmd.Flags().BoolVar(&initNoBrowser, "no-browser", false, "Never launch a browser for browser login; print the login URL instead")
+	cmd.Flags().DurationVar(&initLoginTimeout, "login-timeout", 20*time.Minute, "How long to wait for browser login to complete")
+	cmd.Flags().DurationVar(&initPollInterval, "poll-interval", 2*time.Second, "How often to check whether browser login has completed")
+	cmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Don't prompt; take credentials from flags or environment variables")
+	cmd.Flags().StringVar(&initDomain, "domain", "", "Nextcloud domain (with --non-interactive)")
+	cmd.Flags().StringVar(&initUser, "user", "", "Nextcloud username (with --non-interactive)")
+	cmd.Flags().StringVar(&initPassword, "password", "", "Nextcloud password or app token (with --non-interactive)")
 
 	return cmd
 }
@@ -50,66 +92,60 @@ func runInit(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("unsupported format: %s (use json, yaml, or toml)", configFormat)
 	}
 
+	if initLoginTimeout <= 0 {
+		return fmt.Errorf("--login-timeout must be positive")
+	}
+	if initPollInterval <= 0 || initPollInterval > initLoginTimeout {
+		return fmt.Errorf("--poll-interval must be positive and no longer than --login-timeout")
+	}
+
+	var cfg *config.Config
+	if initNonInteractive {
+		var err error
+		cfg, err = nonInteractiveCredentials()
+		if err != nil {
+			return err
+		}
+	} else if cmd.Flags().Changed("domain") || cmd.Flags().Changed("user") || cmd.Flags().Changed("password") {
+		return fmt.Errorf("--domain, --user, and --password require --non-interactive")
+	}
+
 	// Parameters validated, silence usage for subsequent errors
 	cmd.SilenceUsage = true
 
-	// Check if config already exists
 	var overwritePath string
-	if existingPath := config.GetConfigPath(); existingPath != "" {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Config file already exists: %s\n", existingPath)
-		overwrite, err := promptYesNo(cmd, "Overwrite?")
+	var err error
+	if initNonInteractive {
+		overwritePath = config.GetConfigPath()
+		if !initNoVerify {
+			if err := verifyCredentials(cmd, cfg); err != nil {
+				return fmt.Errorf("verifying credentials: %w", err)
+			}
+		}
+	} else {
+		cfg, overwritePath, err = interactiveCredentials(cmd)
 		if err != nil {
 			return err
 		}
-		if !overwrite {
+		if cfg == nil {
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Aborted.")
 			return nil
 		}
-		overwritePath = existingPath
-	}
-
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Setting up Cospend CLI configuration...")
-	_, _ = fmt.Fprintln(cmd.OutOrStdout())
-
-	// Prompt for domain
-	domain, err := promptString(cmd, "Nextcloud domain (e.g., cloud.example.com)")
-	if err != nil {
-		return err
-	}
-	domain = config.NormalizeURL(domain)
-
-	// Choose login method
-	_, _ = fmt.Fprintln(cmd.OutOrStdout())
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Choose login method:")
-
-	options := []selectOption{
-		{label: "Browser login (recommended)", description: "Opens browser for secure authentication"},
-		{label: "Password/App token", description: "Enter credentials manually"},
 	}
 
-	selected, err := promptSelect(cmd, options)
-	if err != nil {
-		return err
-	}
-
-	var cfg *config.Config
-
-	switch selected {
-	case 0:
-		cfg, err = loginFlowAuth(cmd, domain)
-		if err != nil {
-			return err
-		}
-	case 1:
-		cfg, err = passwordAuth(cmd, domain)
-		if err != nil {
+	if initKeyring {
+		if err := config.StorePassword(cfg, cfg.Password); err != nil {
 			return err
 		}
+		cfg.Password = config.KeyringPassword
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Password stored in OS keyring.")
 	}
 
 	var path string
 	if overwritePath != "" {
 		path, err = config.SaveToPath(cfg, overwritePath)
+	} else if explicitPath := config.ExplicitConfigPath(); explicitPath != "" {
+		path, err = config.SaveToPath(cfg, explicitPath)
 	} else {
 		path, err = config.Save(cfg, configFormat)
 	}
@@ -125,166 +161,164 @@ func runInit(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func promptString(cmd *cobra.Command, prompt string) (string, error) {
-	reader := bufio.NewReader(cmd.InOrStdin())
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: ", prompt)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return "", err
+// nonInteractiveCredentials builds the config from the init flags, falling back
+// to environment variables for values that weren't given
+func nonInteractiveCredentials() (*config.Config, error) {
+	domain := initDomain
+	if domain == "" {
+		domain = os.Getenv("NEXTCLOUD_DOMAIN")
 	}
-	return strings.TrimSpace(input), nil
-}
-
-func promptPassword(cmd *cobra.Command, prompt string) (string, error) {
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: ", prompt)
-
-	// Try to read password with hidden input
-	if f, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(f.Fd())) {
-		password, err := term.ReadPassword(int(f.Fd()))
-		_, _ = fmt.Fprintln(cmd.OutOrStdout()) // Print newline after hidden input
-		if err != nil {
-			return "", err
-		}
-		return string(password), nil
+	user := initUser
+	if user == "" {
+		user = os.Getenv("NEXTCLOUD_USER")
 	}
-
-	// Fallback to regular input (for non-terminal/testing)
-	reader := bufio.NewReader(cmd.InOrStdin())
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return "", err
+	password := initPassword
+	if password == "" {
+		password = os.Getenv("NEXTCLOUD_PASSWORD")
 	}
-	return strings.TrimSpace(input), nil
-}
 
-func promptYesNo(cmd *cobra.Command, prompt string) (bool, error) {
-	reader := bufio.NewReader(cmd.InOrStdin())
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N]: ", prompt)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return false, err
+	var missing []string
+	if domain == "" {
+		missing = append(missing, "--domain")
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "y" || input == "yes", nil
-}
-
-// selectOption represents an option in a select prompt
-type selectOption struct {
-	label       string
-	description string
-}
-
-// promptSelect displays an interactive select menu and returns the selected index
-func promptSelect(cmd *cobra.Command, options []selectOption) (int, error) {
-	// Check if we're in a terminal
-	f, ok := cmd.InOrStdin().(*os.File)
-	if !ok || !term.IsTerminal(int(f.Fd())) {
-		// Fallback to simple numbered input for non-terminal
-		return promptSelectFallback(cmd, options)
+	if user == "" {
+		missing = append(missing, "--user")
 	}
-
-	selected := 0
-	out := cmd.OutOrStdout()
-
-	// Save terminal state and set raw mode
-	oldState, err := term.MakeRaw(int(f.Fd()))
-	if err != nil {
-		return promptSelectFallback(cmd, options)
+	if password == "" {
+		missing = append(missing, "--password")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("--non-interactive requires %s", strings.Join(missing, ", "))
+	}
+	if err := config.ValidateDomain(domain); err != nil {
+		return nil, err
 	}
-	defer func() { _ = term.Restore(int(f.Fd()), oldState) }()
 
-	// Hide cursor
-	_, _ = fmt.Fprint(out, "\033[?25l")
-	defer func() { _, _ = fmt.Fprint(out, "\033[?25h") }() // Show cursor on exit
+	return &config.Config{
+		Domain:   config.NormalizeURL(domain),
+		User:     user,
+		Password: password,
+	}, nil
+}
 
-	renderOptions := func() {
-		for i, opt := range options {
-			if i == selected {
-				_, _ = fmt.Fprintf(out, "\r\033[K  \033[36m>\033[0m \033[1m%s\033[0m - %s\n", opt.label, opt.description)
-			} else {
-				_, _ = fmt.Fprintf(out, "\r\033[K    %s - %s\n", opt.label, opt.description)
-			}
+// interactiveCredentials prompts for credentials and verifies them, offering to
+// retry on failure. It returns the existing config path when the user agrees to
+// overwrite it, and a nil config if the user declines.
+func interactiveCredentials(cmd *cobra.Command) (*config.Config, string, error) {
+	// Check if config already exists
+	var overwritePath string
+	if existingPath := config.GetConfigPath(); existingPath != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Config file already exists: %s\n", existingPath)
+		overwrite, err := prompter(cmd).YesNo("Overwrite?")
+		if err != nil {
+			return nil, "", err
 		}
-	}
-
-	// Move cursor up helper
-	moveUp := func(n int) {
-		if n > 0 {
-			_, _ = fmt.Fprintf(out, "\033[%dA", n)
+		if !overwrite {
+			return nil, "", nil
 		}
+		overwritePath = existingPath
 	}
 
-	renderOptions()
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Setting up Cospend CLI configuration...")
+	_, _ = fmt.Fprintln(cmd.OutOrStdout())
 
-	buf := make([]byte, 3)
 	for {
-		moveUp(len(options))
-		renderOptions()
-
-		n, err := f.Read(buf)
+		cfg, err := promptCredentials(cmd)
 		if err != nil {
-			return 0, err
+			return nil, "", err
 		}
 
-		// Handle input
-		if n == 1 {
-			switch buf[0] {
-			case 13, 10: // Enter
-				_, _ = fmt.Fprintln(out)
-				return selected, nil
-			case 3: // Ctrl+C
-				_, _ = fmt.Fprintln(out)
-				return 0, fmt.Errorf("cancelled")
-			case 'j', 'J': // vim down
-				selected = (selected + 1) % len(options)
-			case 'k', 'K': // vim up
-				selected = (selected - 1 + len(options)) % len(options)
-			}
-		} else if n == 3 && buf[0] == 27 && buf[1] == 91 {
-			// Arrow keys: ESC [ A/B
-			switch buf[2] {
-			case 65: // Up
-				selected = (selected - 1 + len(options)) % len(options)
-			case 66: // Down
-				selected = (selected + 1) % len(options)
-			}
+		if initNoVerify {
+			return cfg, overwritePath, nil
+		}
+
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+		verifyErr := verifyCredentials(cmd, cfg)
+		if verifyErr == nil {
+			return cfg, overwritePath, nil
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: credentials didn't authenticate: %v\n", verifyErr)
+
+		retry, err := prompter(cmd).YesNo("Retry?")
+		if err != nil {
+			return nil, "", err
+		}
+		if !retry {
+			return cfg, overwritePath, nil
 		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
 	}
 }
 
-// promptSelectFallback is a simple numbered fallback for non-terminals
-func promptSelectFallback(cmd *cobra.Command, options []selectOption) (int, error) {
-	out := cmd.OutOrStdout()
-	for i, opt := range options {
-		_, _ = fmt.Fprintf(out, "  %d. %s - %s\n", i+1, opt.label, opt.description)
+// promptCredentials prompts for the domain and login method, then runs the chosen authentication
+func promptCredentials(cmd *cobra.Command) (*config.Config, error) {
+	// Prompt for domain
+	domain, err := prompter(cmd).String("Nextcloud domain (e.g., cloud.example.com)")
+	if err != nil {
+		return nil, err
 	}
-	_, _ = fmt.Fprintln(out)
+	if err := config.ValidateDomain(domain); err != nil {
+		return nil, err
+	}
+	domain = config.NormalizeURL(domain)
 
-	choice, err := promptString(cmd, "Enter choice [1]")
+	// Choose login method
+	_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Choose login method:")
+
+	browserDescription := "Opens browser for secure authentication"
+	if initNoBrowser || detectHeadless() {
+		browserDescription = "Prints a login URL to open on any device"
+	}
+	options := []prompt.Option{
+		{Label: "Browser login (recommended)", Description: browserDescription},
+		{Label: "Password/App token", Description: "Enter credentials manually"},
+	}
+
+	selected, err := prompter(cmd).Select(options, 0)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	if choice == "" {
-		return 0, nil
+
+	if selected == 0 {
+		return loginFlowAuth(cmd, domain)
 	}
+	return passwordAuth(cmd, domain)
+}
 
-	idx := 0
-	if _, err := fmt.Sscanf(choice, "%d", &idx); err != nil || idx < 1 || idx > len(options) {
-		return 0, fmt.Errorf("invalid choice: %s", choice)
+// verifyCredentials makes a test request with cfg and reports the authenticated user
+func verifyCredentials(cmd *cobra.Command, cfg *config.Config) error {
+	userInfo, err := newClient(cmd, cfg).GetUserInfo()
+	if err != nil {
+		return err
+	}
+
+	user := userInfo.ID
+	if user == "" {
+		user = cfg.User
+	}
+	locale := userInfo.Locale
+	if locale == "" {
+		locale = userInfo.Language
 	}
-	return idx - 1, nil
+	if locale != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Credentials verified: logged in as %s (locale: %s)\n", user, locale)
+	} else {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Credentials verified: logged in as %s\n", user)
+	}
+	return nil
 }
 
 // passwordAuth handles traditional password/app token authentication
 func passwordAuth(cmd *cobra.Command, domain string) (*config.Config, error) {
 	// Prompt for username
-	user, err := promptString(cmd, "Username")
+	user, err := prompter(cmd).String("Username")
 	if err != nil {
 		return nil, err
 	}
 
 	// Prompt for password (hidden input)
-	password, err := promptPassword(cmd, "Password (or app token)")
+	password, err := prompter(cmd).Password("Password (or app token)")
 	if err != nil {
 		return nil, err
 	}
@@ -341,26 +375,53 @@ func loginFlowAuth(cmd *cobra.Command, domain string) (*config.Config, error) {
 		return nil, fmt.Errorf("parsing login flow response: %w", err)
 	}
 
-	// Step 2: Open browser for user to authenticate
+	// Step 2: Have the user authenticate in a browser
 	_, _ = fmt.Fprintln(cmd.OutOrStdout())
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Opening browser for authentication...")
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "If the browser doesn't open, visit this URL manually:")
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), flowResp.Login)
+	if initNoBrowser || detectHeadless() {
+		// Headless: the login can be completed from any device
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Open this URL in a browser on any device and log in:")
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", flowResp.Login)
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "This machine will pick up the app password once you grant access.")
+	} else {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Opening browser for authentication...")
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "If the browser doesn't open, visit this URL manually:")
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), flowResp.Login)
+
+		if err := openBrowser(flowResp.Login); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: couldn't open browser: %v\n", err)
+		}
+	}
 	_, _ = fmt.Fprintln(cmd.OutOrStdout())
 
-	if err := openBrowser(flowResp.Login); err != nil {
-		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: couldn't open browser: %v\n", err)
+	// Step 3: Poll for authentication result, stopping early on Ctrl+C
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
 	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
 
-	// Step 3: Poll for authentication result
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Waiting for authentication...")
+	out := cmd.OutOrStdout()
+	var progress func(elapsed time.Duration)
+	if f, ok := out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		progress = func(elapsed time.Duration) {
+			_, _ = fmt.Fprintf(out, "\r\033[KWaiting for authentication... %s elapsed (Ctrl+C to cancel)", elapsed.Truncate(time.Second))
+		}
+	} else {
+		_, _ = fmt.Fprintf(out, "Waiting for authentication (up to %s)...\n", initLoginTimeout)
+	}
 
-	result, err := pollForLogin(flowResp.Poll.Endpoint, flowResp.Poll.Token)
+	result, err := pollForLogin(ctx, flowResp.Poll.Endpoint, flowResp.Poll.Token, initLoginTimeout, initPollInterval, progress)
+	if progress != nil {
+		_, _ = fmt.Fprintln(out)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Authentication successful!")
+	_, _ = fmt.Fprintln(out, "Authentication successful!")
 
 	// Use the server from the response (in case of redirects) or fall back to original domain
 	serverDomain := result.Server
@@ -375,26 +436,43 @@ func loginFlowAuth(cmd *cobra.Command, domain string) (*config.Config, error) {
 	}, nil
 }
 
-// pollForLogin polls the login endpoint until authentication completes or times out
-func pollForLogin(endpoint, token string) (*loginFlowResult, error) {
+// pollForLogin polls the login endpoint every interval until authentication
+// completes, the timeout passes, or ctx is cancelled. progress, if not nil, is
+// called after each attempt with the time spent waiting so far.
+func pollForLogin(ctx context.Context, endpoint, token string, timeout, interval time.Duration, progress func(elapsed time.Duration)) (*loginFlowResult, error) {
 	client := &http.Client{Timeout: 10 * time.Second}
-	deadline := time.Now().Add(20 * time.Minute) // Token valid for 20 minutes
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	// wait sleeps for the poll interval, returning false if ctx is cancelled
+	wait := func() bool {
+		if progress != nil {
+			progress(time.Since(start))
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(interval):
+			return true
+		}
+	}
 
 	for time.Now().Before(deadline) {
 		data := url.Values{}
 		data.Set("token", token)
 
-		req, err := http.NewRequest("POST", endpoint, strings.NewReader(data.Encode()))
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(data.Encode()))
 		if err != nil {
-			time.Sleep(2 * time.Second)
-			continue
+			return nil, fmt.Errorf("creating request: %w", err)
 		}
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.Header.Set("User-Agent", userAgent)
 
 		resp, err := client.Do(req)
 		if err != nil {
-			time.Sleep(2 * time.Second)
+			if ctx.Err() != nil || !wait() {
+				return nil, fmt.Errorf("login cancelled")
+			}
 			continue
 		}
 
@@ -412,33 +490,14 @@ func pollForLogin(endpoint, token string) (*loginFlowResult, error) {
 
 		// 404 means still waiting for user to authenticate
 		if resp.StatusCode == http.StatusNotFound {
-			time.Sleep(2 * time.Second)
+			if !wait() {
+				return nil, fmt.Errorf("login cancelled")
+			}
 			continue
 		}
 
 		return nil, fmt.Errorf("unexpected status during polling: %d", resp.StatusCode)
 	}
 
-	return nil, fmt.Errorf("authentication timed out (20 minutes)")
-}
-
-// openBrowser is a function variable to allow mocking in tests
-var openBrowser = openBrowserDefault
-
-// openBrowserDefault opens the given URL in the default browser
-func openBrowserDefault(url string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-
-	return cmd.Start()
+	return nil, fmt.Errorf("authentication timed out (%s)", timeout)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,23 +10,39 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/config"
 )
 
 func resetInitFlags() {
 	configFormat = "json"
+	initKeyring = false
+	initNoVerify = false
+	initNonInteractive = false
+	initDomain = ""
+	initUser = ""
+	initPassword = ""
+	initNoBrowser = false
+	initLoginTimeout = 20 * time.Minute
+	initPollInterval = 2 * time.Second
 }
 
-// mockOpenBrowser replaces openBrowser for testing and returns a restore function
+// mockOpenBrowser replaces openBrowser for testing and returns a restore function.
+// The environment is treated as having a display.
 func mockOpenBrowser() (openedURL *string, restore func()) {
 	original := openBrowser
+	originalHeadless := detectHeadless
 	var url string
 	openBrowser = func(u string) error {
 		url = u
 		return nil
 	}
-	return &url, func() { openBrowser = original }
+	detectHeadless = func() bool { return false }
+	return &url, func() {
+		openBrowser = original
+		detectHeadless = originalHeadless
+	}
 }
 
 func TestNewInitCommand(t *testing.T) {
@@ -184,70 +201,6 @@ func TestLoginFlowInitError(t *testing.T) {
 	}
 }
 
-func TestPromptPassword(t *testing.T) {
-	// Test password prompt in non-terminal mode (fallback to regular input)
-	cmd := NewInitCommand()
-	var stdout bytes.Buffer
-	cmd.SetOut(&stdout)
-	cmd.SetIn(strings.NewReader("secretpass\n"))
-
-	result, err := promptPassword(cmd, "Enter password")
-	if err != nil {
-		t.Fatalf("promptPassword error: %v", err)
-	}
-
-	if result != "secretpass" {
-		t.Errorf("Result = %s, want secretpass", result)
-	}
-}
-
-func TestPromptSelectFallback(t *testing.T) {
-	cmd := NewInitCommand()
-	var stdout bytes.Buffer
-	cmd.SetOut(&stdout)
-
-	options := []selectOption{
-		{label: "Option A", description: "First option"},
-		{label: "Option B", description: "Second option"},
-	}
-
-	tests := []struct {
-		name     string
-		input    string
-		expected int
-		wantErr  bool
-	}{
-		{"default selection", "\n", 0, false},
-		{"select first", "1\n", 0, false},
-		{"select second", "2\n", 1, false},
-		{"invalid choice", "5\n", 0, true},
-		{"invalid input", "abc\n", 0, true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			stdout.Reset()
-			cmd.SetIn(strings.NewReader(tt.input))
-
-			selected, err := promptSelectFallback(cmd, options)
-
-			if tt.wantErr {
-				if err == nil {
-					t.Error("Expected error")
-				}
-				return
-			}
-
-			if err != nil {
-				t.Fatalf("Unexpected error: %v", err)
-			}
-			if selected != tt.expected {
-				t.Errorf("Selected = %d, want %d", selected, tt.expected)
-			}
-		})
-	}
-}
-
 func TestDomainAutoPrependHTTPS(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -258,6 +211,7 @@ func TestDomainAutoPrependHTTPS(t *testing.T) {
 		{"http://cloud.example.com", "http://cloud.example.com"},
 		{"HTTPS://CLOUD.EXAMPLE.COM", "HTTPS://CLOUD.EXAMPLE.COM"},
 		{"HTTP://cloud.example.com", "HTTP://cloud.example.com"},
+		{"cloud.example.com/", "https://cloud.example.com"},
 	}
 
 	for _, tt := range tests {
@@ -422,56 +376,207 @@ func TestOpenBrowserMock(t *testing.T) {
 	}
 }
 
-func TestPromptString(t *testing.T) {
+func TestVerifyCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"id": "alice", "locale": "de_DE"}))
+	}))
+	defer server.Close()
+
+	t.Run("valid credentials", func(t *testing.T) {
+		cmd := NewInitCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+
+		cfg := &config.Config{Domain: server.URL, User: "alice", Password: "secret"}
+		if err := verifyCredentials(cmd, cfg); err != nil {
+			t.Fatalf("verifyCredentials() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "logged in as alice (locale: de_DE)") {
+			t.Errorf("Unexpected output: %s", buf.String())
+		}
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		cmd := NewInitCommand()
+		cmd.SetOut(new(bytes.Buffer))
+
+		cfg := &config.Config{Domain: server.URL, User: "alice", Password: "wrong"}
+		if err := verifyCredentials(cmd, cfg); err == nil {
+			t.Error("Expected error for invalid credentials")
+		}
+	})
+}
+
+func TestInitNonInteractive(t *testing.T) {
+	resetInitFlags()
+	defer resetInitFlags()
+
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+	t.Setenv("NEXTCLOUD_DOMAIN", "")
+	t.Setenv("NEXTCLOUD_USER", "")
+	t.Setenv("NEXTCLOUD_PASSWORD", "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"id": "alice"}))
+	}))
+	defer server.Close()
+
 	cmd := NewInitCommand()
 	var stdout bytes.Buffer
 	cmd.SetOut(&stdout)
-	cmd.SetIn(strings.NewReader("test input\n"))
+	cmd.SetIn(strings.NewReader("")) // Any prompt would fail on EOF
+	cmd.SetArgs([]string{"--non-interactive", "--format", "yaml", "--domain", server.URL, "--user", "alice", "--password", "token"})
 
-	result, err := promptString(cmd, "Enter value")
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	configPath := filepath.Join(tempDir, "cospend", "cospend.yaml")
+	cfg, err := config.LoadFromFile(configPath)
 	if err != nil {
-		t.Fatalf("promptString error: %v", err)
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+	if cfg.Domain != server.URL || cfg.User != "alice" || cfg.Password != "token" {
+		t.Errorf("Unexpected saved config: %+v", cfg)
+	}
+	if !strings.Contains(stdout.String(), "logged in as alice") {
+		t.Errorf("Expected credentials to be verified, got: %s", stdout.String())
 	}
+}
+
+func TestInitNonInteractiveMissingValues(t *testing.T) {
+	resetInitFlags()
+	defer resetInitFlags()
 
-	if result != "test input" {
-		t.Errorf("Result = %s, want 'test input'", result)
+	t.Setenv("NEXTCLOUD_DOMAIN", "")
+	t.Setenv("NEXTCLOUD_USER", "")
+	t.Setenv("NEXTCLOUD_PASSWORD", "")
+
+	cmd := NewInitCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--non-interactive", "--user", "alice"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for missing values")
 	}
+	if !strings.Contains(err.Error(), "--domain, --password") {
+		t.Errorf("Error should list missing flags, got: %v", err)
+	}
+}
 
-	if !strings.Contains(stdout.String(), "Enter value:") {
-		t.Errorf("Prompt not shown: %s", stdout.String())
+func TestInitCredentialFlagsRequireNonInteractive(t *testing.T) {
+	resetInitFlags()
+	defer resetInitFlags()
+
+	cmd := NewInitCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--domain", "cloud.example.com"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--non-interactive") {
+		t.Errorf("Expected --non-interactive error, got: %v", err)
 	}
 }
 
-func TestPromptYesNo(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected bool
-	}{
-		{"y\n", true},
-		{"Y\n", true},
-		{"yes\n", true},
-		{"YES\n", true},
-		{"n\n", false},
-		{"N\n", false},
-		{"no\n", false},
-		{"\n", false},
-		{"anything\n", false},
+func TestLoginFlowNoBrowser(t *testing.T) {
+	resetInitFlags()
+	defer resetInitFlags()
+
+	openedURL, restore := mockOpenBrowser()
+	defer restore()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/index.php/login/v2" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"poll":  map[string]string{"token": "t", "endpoint": "http://" + r.Host + "/login/v2/poll"},
+				"login": "http://" + r.Host + "/login/v2/flow/abc123",
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"loginName": "testuser", "appPassword": "app-password"})
+	}))
+	defer server.Close()
+
+	cmd := NewInitCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	initNoBrowser = true
+
+	cfg, err := loginFlowAuth(cmd, server.URL)
+	if err != nil {
+		t.Fatalf("loginFlowAuth error: %v", err)
+	}
+	if cfg.Password != "app-password" {
+		t.Errorf("Password = %s, want app-password", cfg.Password)
+	}
+	if *openedURL != "" {
+		t.Errorf("Browser should not be opened with --no-browser, got %s", *openedURL)
+	}
+	if !strings.Contains(stdout.String(), "on any device") || !strings.Contains(stdout.String(), "/login/v2/flow/abc123") {
+		t.Errorf("Expected login URL instructions, got:\n%s", stdout.String())
 	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			cmd := NewInitCommand()
-			cmd.SetOut(&bytes.Buffer{})
-			cmd.SetIn(strings.NewReader(tt.input))
+func TestPollForLoginTimeout(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
 
-			result, err := promptYesNo(cmd, "Confirm?")
-			if err != nil {
-				t.Fatalf("promptYesNo error: %v", err)
-			}
+	var progressCalls int
+	_, err := pollForLogin(context.Background(), server.URL, "t", 50*time.Millisecond, 10*time.Millisecond, func(time.Duration) { progressCalls++ })
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Expected timeout error, got: %v", err)
+	}
+	if polls < 2 {
+		t.Errorf("Expected several polls, got %d", polls)
+	}
+	if progressCalls == 0 {
+		t.Error("Expected progress to be reported")
+	}
+}
 
-			if result != tt.expected {
-				t.Errorf("Result = %v, want %v for input %q", result, tt.expected, tt.input)
-			}
-		})
+func TestPollForLoginCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := pollForLogin(ctx, server.URL, "t", time.Minute, 10*time.Second, nil)
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Fatalf("Expected cancellation error, got: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("pollForLogin should return promptly when cancelled")
+	}
+}
+
+func TestInitInvalidPollInterval(t *testing.T) {
+	resetInitFlags()
+	defer resetInitFlags()
+
+	cmd := NewInitCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--login-timeout", "5s", "--poll-interval", "10s"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--poll-interval") {
+		t.Errorf("Expected --poll-interval error, got: %v", err)
 	}
 }
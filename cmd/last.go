@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+// defaultLastCount is the number of bills shown by last without an argument
+const defaultLastCount = 5
+
+var (
+	lastFormat        string
+	lastRelativeDates bool
+)
+
+// NewLastCommand creates the last command
+func NewLastCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "last [n]",
+		Short: "Show the most recent bills in a Cospend project",
+		Long: `Show the n most recent bills (5 by default), newest first.
+
+This is a shortcut for "list --limit n" without any filters.
+
+Examples:
+  cospend last -p myproject
+  cospend last 10 -p myproject --relative-dates
+  cospend last 1 -p myproject --format json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runLast,
+	}
+
+	cmd.Flags().StringVar(&lastFormat, "format", "table", "Output format: table, json")
+	cmd.Flags().BoolVar(&lastRelativeDates, "relative-dates", false, "Show dates relative to today, like \"3 days ago\" (table format only)")
+
+	return cmd
+}
+
+func runLast(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	count := defaultLastCount
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid count: %s (expected a positive number)", args[0])
+		}
+		count = n
+	}
+
+	if lastFormat != "table" && lastFormat != "json" {
+		return fmt.Errorf("unsupported format: %s (expected table or json)", lastFormat)
+	}
+
+	if lastRelativeDates && lastFormat != "table" {
+		return fmt.Errorf("--relative-dates only applies to the table format")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	layout, err := dateLayout(cfg)
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching bills...")
+	bills, err := client.GetBills(ProjectID)
+	stop()
+	if err != nil {
+		return fmt.Errorf("fetching bills: %w", err)
+	}
+
+	resolved := resolveBillNames(project, bills)
+	if len(resolved) > count {
+		resolved = resolved[:count]
+	}
+	// JSON keeps ISO dates, so import can read it back
+	if lastRelativeDates {
+		resolved = humanizeBillDates(resolved, now().In(loc))
+	} else if lastFormat != "json" {
+		resolved = formatBillDates(resolved, layout)
+	}
+
+	out := cmd.OutOrStdout()
+	if lastFormat == "json" {
+		printBillsJSON(out, resolved)
+		return nil
+	}
+
+	formatter := format.NewAmountFormatter(loadLocale(cmd, client, cfg), displayCurrency(cfg, project))
+	printBillsTable(out, resolved, formatter, nil)
+	return nil
+}
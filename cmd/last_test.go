@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func newLastTestServer(t *testing.T, count int) *httptest.Server {
+	t.Helper()
+
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}},
+	}
+	var bills []api.BillResponse
+	for i := 1; i <= count; i++ {
+		bills = append(bills, api.BillResponse{
+			ID:      i,
+			What:    fmt.Sprintf("Bill %d", i),
+			Amount:  float64(i),
+			Date:    fmt.Sprintf("2026-01-%02d", i),
+			PayerID: 1,
+			Owers:   []api.Ower{{ID: 1}},
+		})
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+}
+
+func TestLastCommand(t *testing.T) {
+	server := newLastTestServer(t, 8)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+		skip []string
+	}{
+		{"default count", nil, []string{"Bill 8", "Bill 4"}, []string{"Bill 3"}},
+		{"explicit count", []string{"2"}, []string{"Bill 8", "Bill 7"}, []string{"Bill 6"}},
+		{"more than available", []string{"20"}, []string{"Bill 8", "Bill 1"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ProjectID = "test-project"
+			lastFormat = "table"
+			lastRelativeDates = false
+			cmd := NewLastCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			output := stdout.String()
+			for _, s := range tt.want {
+				if !strings.Contains(output, s) {
+					t.Errorf("Output missing %q:\n%s", s, output)
+				}
+			}
+			for _, s := range tt.skip {
+				if strings.Contains(output, s) {
+					t.Errorf("Output should not contain %q:\n%s", s, output)
+				}
+			}
+		})
+	}
+}
+
+func TestLastCommandJSON(t *testing.T) {
+	server := newLastTestServer(t, 3)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	cmd := NewLastCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"2", "--format", "json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var got []resolvedBill
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got) != 2 || got[0].ID != 3 || got[1].ID != 2 {
+		t.Errorf("Unexpected bills: %+v", got)
+	}
+}
+
+func TestLastCommandRelativeDates(t *testing.T) {
+	server := newLastTestServer(t, 2)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
+	ProjectID = "test-project"
+	cmd := NewLastCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--relative-dates"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "2 months ago"
+	if !strings.Contains(stdout.String(), want) || strings.Contains(stdout.String(), "2026-01-02") {
+		t.Errorf("Expected relative date %q instead of 2026-01-02:\n%s", want, stdout.String())
+	}
+}
+
+func TestLastCommandInvalidArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"not a number", []string{"abc"}, "invalid count"},
+		{"zero", []string{"0"}, "invalid count"},
+		{"bad format", []string{"--format", "csv"}, "unsupported format"},
+		{"relative json", []string{"--format", "json", "--relative-dates"}, "--relative-dates only applies to the table format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ProjectID = "test-project"
+			cmd := NewLastCommand()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Execute() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,21 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/api"
@@ -21,6 +29,7 @@ var (
 	listPaidBy        string
 	listPaidFor       []string
 	listAmount        string
+	listAmountOrig    string
 	listName          string
 	listPaymentMethod string
 	listCategory      string
@@ -30,9 +39,57 @@ var (
 	listThisMonth     bool
 	listThisWeek      bool
 	listRecent        string
+	listYear          int
+	listFrom          string
+	listTo            string
+	listWeekday       string
+	listWeekends      bool
+	listMine          bool
+	listInvolves      []string
 	listFormat        string
+	listIn            string
+	listShowOriginal  bool
+	listOutput        string
+	listWatch         time.Duration
+	listNoHeader      bool
+	listTotalOnly     bool
+	listShowIcons     bool
+	listRelativeDates bool
+	listPerPerson     bool
+	listRaw           bool
+	listTemplate      string
+	listSumBy         string
+	listNew           bool
+	listRecurring     bool
+	listOneTime       bool
+	listShowRepeat    bool
+	listShowFilters   bool
+	listCompact       bool
+	listFields        []string
 )
 
+// billFields are the keys of a bill in JSON output, which --fields selects
+// from. rawBillFields are only there with --raw.
+var (
+	billFields = []string{
+		"id", "date", "name", "amount", "original_amount", "paid_by", "paid_for",
+		"category", "payment_method", "comment", "shares",
+	}
+	rawBillFields = []string{"payer_id", "owers", "category_id", "payment_mode_id"}
+)
+
+// sumByColumns are the dimensions --sum-by totals bills by, with their table
+// and CSV column names
+var sumByColumns = map[string][2]string{
+	"category": {"CATEGORY", "Category"},
+	"payer":    {"PAID BY", "Paid By"},
+	"method":   {"PAYMENT METHOD", "Payment Method"},
+	"month":    {"MONTH", "Month"},
+}
+
+// minWatchInterval is the shortest refresh interval allowed for --watch
+const minWatchInterval = 5 * time.Second
+
 // amountFilter holds parsed amount filter criteria
 type amountFilter struct {
 	operator string
@@ -56,28 +113,82 @@ Examples:
   cospend list -p myproject --today
   cospend list -p myproject --date ">=2026-01-01"
   cospend list -p myproject --date "<=01-15"
+  cospend list -p myproject --from 2026-01-01 --to 2026-03-31
   cospend list -p myproject --this-month
   cospend list -p myproject --this-week
+  cospend list -p myproject --weekday fri,sat
+  cospend list -p myproject --weekends
   cospend list -p myproject --recent 7d
-  cospend list -p myproject --recent 2w`,
+  cospend list -p myproject --recent 2w
+  cospend list -p myproject --year 2026
+  cospend list -p myproject --in eur
+  cospend list -p myproject --in eur --show-original
+  cospend list -p myproject --show-icons
+  cospend list -p myproject --compact
+  cospend list -p myproject --format csv -O expenses.csv
+  cospend list -p myproject --this-month --format html -O report.html
+  cospend list -p myproject --format tsv | cut -f3,4
+  cospend list -p myproject --format json --raw
+  cospend list -p myproject --format json --fields id,name,amount
+  cospend list -p myproject --format ndjson | jq -c 'select(.amount > 50)'
+  cospend list -p myproject --template '{{.Date}} {{.Name}} {{money .Amount}}'
+  cospend list -p myproject --this-month --format csv --no-header >> all.csv
+  cospend list -p myproject --this-month --total-only
+  cospend list -p myproject --this-month --sum-by category
+  cospend list -p myproject --new
+  cospend list -p myproject --recurring --show-repeat
+  cospend list -p myproject -c food --from 2026-01-01 --show-filters
+  cospend list -p myproject --this-week --watch 30s`,
 		RunE: runList,
 	}
 
+	addFilterFlags(cmd)
+	cmd.Flags().IntVarP(&listLimit, "limit", "l", 0, "Limit number of results (0 = no limit)")
+	cmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table, csv, tsv, json, ndjson, html")
+	cmd.Flags().BoolVar(&listNoHeader, "no-header", false, "Omit the header row (csv and tsv formats only)")
+	cmd.Flags().StringSliceVar(&listFields, "fields", nil, "Only include these keys in each bill, e.g. id,name,amount (json and ndjson formats only)")
+	cmd.Flags().BoolVar(&listRaw, "raw", false, "Include numeric IDs for payer, owers, category, and payment method (json and ndjson formats only)")
+	cmd.Flags().BoolVar(&listTotalOnly, "total-only", false, "Print only the total of the matching bills (with --format json: count and total)")
+	cmd.Flags().StringVar(&listIn, "in", "", "Display amounts converted to a project currency (by ID, name, or code like eur)")
+	cmd.Flags().BoolVar(&listShowOriginal, "show-original", false, "Show the unconverted amount alongside (requires --in)")
+	cmd.Flags().BoolVar(&listShowIcons, "show-icons", false, "Prefix categories and payment methods with their icons in the table")
+	cmd.Flags().BoolVar(&listShowRepeat, "show-repeat", false, "Add a column with how often each bill repeats (table, csv, and tsv formats)")
+	cmd.Flags().BoolVar(&listCompact, "compact", false, "Narrower table without cell padding or the ID and METHOD columns (table format only)")
+	cmd.Flags().BoolVar(&listShowFilters, "show-filters", false, "Print the active filters above the output (table and json formats only)")
+	cmd.Flags().BoolVar(&listPerPerson, "per-person", false, "Show how much each owed member owes, split by weight (table, json, and ndjson formats only)")
+	cmd.Flags().BoolVar(&listRelativeDates, "relative-dates", false, "Show dates relative to today, like \"3 days ago\" (table format only)")
+	cmd.Flags().StringVar(&listSumBy, "sum-by", "", "Print only the totals by category, payer, method, or month")
+	cmd.Flags().BoolVar(&listNew, "new", false, "Show only bills added or changed since you last listed this project")
+	cmd.Flags().StringVar(&listTemplate, "template", "", "Print each bill with a Go template, e.g. '{{.Date}} {{.Name}} {{money .Amount}}' (replaces --format)")
+	cmd.Flags().StringVarP(&listOutput, "output", "O", "", "Write output to a file instead of stdout")
+	cmd.Flags().DurationVar(&listWatch, "watch", 0, "Refresh the table at the given interval (e.g., 30s, 1m) until Ctrl+C")
+
+	return cmd
+}
+
+// addFilterFlags registers the bill filter flags used by buildFilters
+func addFilterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&listPaidBy, "by", "b", "", "Filter by paying member username")
 	cmd.Flags().StringArrayVarP(&listPaidFor, "for", "f", nil, "Filter by owed member username (repeatable)")
 	cmd.Flags().StringVarP(&listAmount, "amount", "a", "", "Filter by amount (e.g., 50, >30, <=100, =25)")
+	cmd.Flags().StringVar(&listAmountOrig, "amount-original", "", "Filter by the amount in the currency the bill was entered in (e.g., >50)")
 	cmd.Flags().StringVarP(&listName, "name", "n", "", "Filter by name (case-insensitive, contains)")
 	cmd.Flags().StringVarP(&listPaymentMethod, "method", "m", "", "Filter by payment method")
 	cmd.Flags().StringVarP(&listCategory, "category", "c", "", "Filter by category")
-	cmd.Flags().IntVarP(&listLimit, "limit", "l", 0, "Limit number of results (0 = no limit)")
 	cmd.Flags().StringVarP(&listDate, "date", "d", "", "Filter by date (e.g., 2026-01-15, >=2026-01-01, <=01-15)")
 	cmd.Flags().BoolVar(&listToday, "today", false, "Filter bills from today")
 	cmd.Flags().BoolVar(&listThisMonth, "this-month", false, "Filter bills from the current month")
 	cmd.Flags().BoolVar(&listThisWeek, "this-week", false, "Filter bills from the current calendar week")
 	cmd.Flags().StringVar(&listRecent, "recent", "", "Filter recent bills (e.g., 7d, 2w, 1m)")
-	cmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table, csv, json")
-
-	return cmd
+	cmd.Flags().IntVar(&listYear, "year", 0, "Filter bills from the given year (e.g., 2026)")
+	cmd.Flags().StringVar(&listFrom, "from", "", "Filter bills on or after a date (e.g., 2026-01-01, 01-01)")
+	cmd.Flags().StringVar(&listTo, "to", "", "Filter bills on or before a date (e.g., 2026-03-31, 03-31)")
+	cmd.Flags().StringVar(&listWeekday, "weekday", "", "Filter by day of the week (comma-separated, e.g., sat,sun)")
+	cmd.Flags().BoolVar(&listWeekends, "weekends", false, "Filter bills on Saturdays and Sundays")
+	cmd.Flags().BoolVar(&listMine, "mine", false, "Filter bills you paid or owe a share of")
+	cmd.Flags().StringArrayVar(&listInvolves, "involves", nil, "Filter bills a member paid or owes a share of (repeatable; any of them)")
+	cmd.Flags().BoolVar(&listRecurring, "recurring", false, "Filter bills set to repeat")
+	cmd.Flags().BoolVar(&listOneTime, "one-time", false, "Filter bills that don't repeat")
 }
 
 func runList(cmd *cobra.Command, _ []string) error {
@@ -86,9 +197,106 @@ func runList(cmd *cobra.Command, _ []string) error {
 	}
 
 	switch listFormat {
-	case "table", "csv", "json":
+	case "table", "csv", "tsv", "json", "ndjson", "html":
 	default:
-		return fmt.Errorf("unsupported format: %s (expected table, csv, or json)", listFormat)
+		return fmt.Errorf("unsupported format: %s (expected table, csv, tsv, json, ndjson, or html)", listFormat)
+	}
+
+	if listShowOriginal && listIn == "" {
+		return fmt.Errorf("--show-original requires --in")
+	}
+
+	if listNoHeader && listFormat != "csv" && listFormat != "tsv" {
+		return fmt.Errorf("--no-header only applies to the csv and tsv formats")
+	}
+
+	if listRaw && listFormat != "json" && listFormat != "ndjson" {
+		return fmt.Errorf("--raw only applies to the json and ndjson formats")
+	}
+
+	if len(listFields) > 0 {
+		if listFormat != "json" && listFormat != "ndjson" {
+			return fmt.Errorf("--fields only applies to the json and ndjson formats")
+		}
+		if listTotalOnly {
+			return fmt.Errorf("--fields can't be used with --total-only")
+		}
+		for i, field := range listFields {
+			listFields[i] = strings.ToLower(strings.TrimSpace(field))
+		}
+		if err := checkFields(listFields); err != nil {
+			return err
+		}
+	}
+
+	if listPerPerson && listFormat != "table" && listFormat != "json" && listFormat != "ndjson" {
+		return fmt.Errorf("--per-person only applies to the table, json, and ndjson formats")
+	}
+
+	if listRelativeDates && listFormat != "table" {
+		return fmt.Errorf("--relative-dates only applies to the table format")
+	}
+
+	if listCompact && listFormat != "table" {
+		return fmt.Errorf("--compact only applies to the table format")
+	}
+
+	if listShowRepeat && listFormat != "table" && listFormat != "csv" && listFormat != "tsv" {
+		return fmt.Errorf("--show-repeat only applies to the table, csv, and tsv formats")
+	}
+
+	if listShowFilters {
+		if listFormat != "table" && listFormat != "json" {
+			return fmt.Errorf("--show-filters only applies to the table and json formats")
+		}
+		if listTemplate != "" {
+			return fmt.Errorf("--show-filters can't be used with --template")
+		}
+		if listFormat == "json" && (listTotalOnly || listSumBy != "") {
+			return fmt.Errorf("--show-filters with --format json only applies to the bill list")
+		}
+	}
+
+	if listSumBy != "" {
+		if _, ok := sumByColumns[listSumBy]; !ok {
+			return fmt.Errorf("invalid --sum-by: %s (expected category, payer, method, or month)", listSumBy)
+		}
+		if listFormat == "html" {
+			return fmt.Errorf("--sum-by doesn't support the html format")
+		}
+		for _, flag := range []string{"total-only", "per-person", "relative-dates", "raw", "template", "fields"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--sum-by can't be used with --%s", flag)
+			}
+		}
+	}
+
+	// Check the template before fetching anything, so mistakes fail fast
+	if listTemplate != "" {
+		if cmd.Flags().Changed("format") {
+			return fmt.Errorf("--template can't be used with --format")
+		}
+		if listTotalOnly {
+			return fmt.Errorf("--template can't be used with --total-only")
+		}
+		if _, err := parseBillTemplate(listTemplate, nil); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("watch") {
+		if listFormat != "table" {
+			return fmt.Errorf("--watch only supports the table format")
+		}
+		if listOutput != "" {
+			return fmt.Errorf("--watch can't be used with --output")
+		}
+		if listWatch < minWatchInterval {
+			return fmt.Errorf("--watch interval must be at least %s", minWatchInterval)
+		}
+		if listNew {
+			return fmt.Errorf("--watch can't be used with --new")
+		}
 	}
 
 	// Parameters validated, silence usage for subsequent errors
@@ -101,78 +309,407 @@ func runList(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Get API client
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	client := newClient(cmd, cfg)
 
 	// Get project (from cache or API)
-	project, ok := cache.Load(ProjectID)
-	if !ok {
-		project, err = client.GetProject(ProjectID)
-		if err != nil {
-			return fmt.Errorf("fetching project: %w", err)
-		}
-		if err := cache.Save(ProjectID, project); err != nil {
-			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project: %v\n", err)
-		}
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	// Resolve locale (flag, config, then user info with cache and graceful fallback)
+	locale := loadLocale(cmd, client, cfg)
+
+	layout, err := dateLayout(cfg)
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Summarize the filters now, while the flags still say what was given
+	var shown []appliedFilter
+	if listShowFilters {
+		shown = activeFilters(cmd)
 	}
 
-	// Fetch bills
+	// Archived projects are read-only; the table notes it so that doesn't
+	// come as a surprise
+	archived := listFormat == "table" && listTemplate == "" && project.IsArchived()
+
+	if listWatch > 0 {
+		return watchList(cmd, client, project, cfg.User, locale, displayCurrency(cfg, project), layout, loc, shown, archived, listWatch)
+	}
+
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching bills...")
 	bills, err := client.GetBills(ProjectID)
+	stop()
 	if err != nil {
 		return fmt.Errorf("fetching bills: %w", err)
 	}
 
-	// Fetch user info for locale (with cache, graceful fallback)
-	locale := "en_US"
-	userInfo, ok := cache.LoadUserInfo()
-	if !ok {
-		userInfo, err = client.GetUserInfo()
+	// The next --new starts from the newest change the server reported, so it
+	// doesn't depend on this machine's clock agreeing with the server's
+	seenAt := newestChange(bills)
+	lastSeen, seen := cache.LoadLastSeen(ProjectID)
+	if listNew && seen {
+		bills = billsChangedSince(bills, lastSeen)
+	}
+
+	out := cmd.OutOrStdout()
+	var file *outputFile
+	if listOutput != "" {
+		file, err = createOutputFile(listOutput)
 		if err != nil {
-			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to fetch user info: %v\n", err)
-		} else {
-			if err := cache.SaveUserInfo(userInfo); err != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache user info: %v\n", err)
-			}
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	count, err := renderBills(out, project, cfg.User, bills, locale, displayCurrency(cfg, project), layout, loc, shown, archived)
+	if err != nil {
+		return err
+	}
+
+	if file != nil {
+		if err := file.Close(); err != nil {
+			return err
 		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d bill(s) to %s\n", count, listOutput)
 	}
-	if userInfo != nil && userInfo.Locale != "" {
-		locale = userInfo.Locale
-	} else if userInfo != nil && userInfo.Language != "" {
-		locale = userInfo.Language
+
+	// Deleting the newest bill mustn't move the marker back
+	if !NoCache && seenAt.After(lastSeen) {
+		if err := cache.SaveLastSeen(ProjectID, seenAt); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to save last seen time: %v\n", err)
+		}
 	}
 
+	return nil
+}
+
+// newestChange returns the server time of the latest change to any of bills,
+// or the zero time if none carry one
+func newestChange(bills []api.BillResponse) time.Time {
+	var newest int64
+	for _, bill := range bills {
+		if bill.Timestamp > newest {
+			newest = bill.Timestamp
+		}
+	}
+	if newest == 0 {
+		return time.Time{}
+	}
+	return time.Unix(newest, 0)
+}
+
+// billsChangedSince returns the bills added or last changed after since
+func billsChangedSince(bills []api.BillResponse, since time.Time) []api.BillResponse {
+	var result []api.BillResponse
+	for _, bill := range bills {
+		if bill.Timestamp > since.Unix() {
+			result = append(result, bill)
+		}
+	}
+	return result
+}
+
+// renderBills filters, resolves, and prints bills in the selected format,
+// formatting amounts with locale and currencyName and dates with dateLayout.
+// user is the authenticated user, for --mine. With --show-filters, shown are
+// the filters printed with the bills. archived adds a note to the table that
+// the project is archived. It returns the number of bills printed.
+func renderBills(out io.Writer, project *api.Project, user string, bills []api.BillResponse, locale, currencyName, dateLayout string, loc *time.Location, shown []appliedFilter, archived bool) (int, error) {
 	// Build filters
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, user, loc)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// Apply filters
 	filteredBills := applyFilters(bills, filters)
 
 	// Output results
-	formatter := format.NewAmountFormatter(locale, project.CurrencyName)
+	formatter := format.NewAmountFormatter(locale, currencyName)
 	resolved := resolveBillNames(project, filteredBills)
 
+	// Convert amounts to the requested currency
+	var origFormatter *format.AmountFormatter
+	if listIn != "" {
+		currency, err := resolveDisplayCurrency(project, listIn)
+		if err != nil {
+			return 0, err
+		}
+		resolved = convertBills(resolved, currency.ExchangeRate, listShowOriginal)
+		if listShowOriginal {
+			origFormatter = formatter
+		}
+		formatter = format.NewAmountFormatter(locale, currency.Name)
+	}
+
+	if listPerPerson {
+		resolved = splitBills(resolved)
+	}
+
+	if listFormat == "table" && (archived || listShowFilters) {
+		if archived {
+			_, _ = fmt.Fprintf(out, "Project: %s (archived)\n", project.Name)
+		}
+		if listShowFilters {
+			_, _ = fmt.Fprintf(out, "Filters: %s\n", formatFilters(shown))
+		}
+		_, _ = fmt.Fprintln(out)
+	}
+
+	// Totals use the original dates, before they're formatted for display
+	if listSumBy != "" {
+		printBillSums(out, sumBills(resolved, listSumBy), formatter, locale)
+		return len(resolved), nil
+	}
+
+	// JSON keeps ISO dates, so import can read it back
+	if listRelativeDates {
+		resolved = humanizeBillDates(resolved, now().In(loc))
+	} else if listFormat != "json" && listFormat != "ndjson" {
+		resolved = formatBillDates(resolved, dateLayout)
+	}
+
+	if listTotalOnly {
+		printBillsTotal(out, resolved, formatter)
+		return len(resolved), nil
+	}
+
+	if listTemplate != "" {
+		tmpl, err := parseBillTemplate(listTemplate, formatter)
+		if err != nil {
+			return 0, err
+		}
+		if err := printBillsTemplate(out, resolved, tmpl); err != nil {
+			return 0, err
+		}
+		return len(resolved), nil
+	}
+
 	switch listFormat {
 	case "csv":
-		printBillsCSV(cmd, resolved)
+		printBillsCSV(out, resolved, ',')
+	case "tsv":
+		printBillsCSV(out, resolved, '\t')
 	case "json":
-		printBillsJSON(cmd, resolved)
+		if listShowFilters {
+			printBillsJSONWithFilters(out, resolved, shown)
+		} else {
+			printBillsJSON(out, resolved)
+		}
+	case "ndjson":
+		printBillsNDJSON(out, resolved)
+	case "html":
+		if err := printBillsHTML(out, billsHTMLReport(project.Name, resolved, formatter, origFormatter)); err != nil {
+			return 0, err
+		}
 	default:
-		printBillsTable(cmd, resolved, formatter)
+		printBillsTable(out, resolved, formatter, origFormatter)
 	}
 
-	return nil
+	return len(resolved), nil
+}
+
+// watchList re-renders the bills table every interval until interrupted.
+// After the first fetch, only bills changed since the last refresh are requested.
+func watchList(cmd *cobra.Command, client *api.Client, project *api.Project, user, locale, currencyName, dateLayout string, loc *time.Location, shown []appliedFilter, archived bool, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	update, err := client.GetBillsSince(ProjectID, 0)
+	if err != nil {
+		return fmt.Errorf("fetching bills: %w", err)
+	}
+	bills := update.Bills
+	lastChanged := update.Timestamp
+	refreshed := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	out := cmd.OutOrStdout()
+	var refreshErr error
+	for {
+		// Clear screen and move cursor to top-left
+		_, _ = fmt.Fprint(out, "\033[H\033[2J")
+		_, _ = fmt.Fprintf(out, "Last refresh: %s (every %s, Ctrl+C to exit)\n", refreshed.Format("2006-01-02 15:04:05"), interval)
+		if refreshErr != nil {
+			_, _ = fmt.Fprintf(out, "Warning: refresh failed: %v\n", refreshErr)
+		}
+		_, _ = fmt.Fprintln(out)
+
+		// resolveBillNames sorts in place, so render from a copy
+		if _, err := renderBills(out, project, user, append([]api.BillResponse(nil), bills...), locale, currencyName, dateLayout, loc, shown, archived); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		update, err := client.GetBillsSince(ProjectID, lastChanged)
+		if err != nil {
+			refreshErr = err
+			continue
+		}
+		refreshErr = nil
+		bills = mergeBills(bills, update)
+		lastChanged = update.Timestamp
+		refreshed = time.Now()
+	}
+}
+
+// mergeBills applies an incremental update to bills: changed bills replace
+// their previous version, new bills are added, and bills no longer listed in
+// the update's AllBillIDs are dropped
+func mergeBills(bills []api.BillResponse, update *api.BillsUpdate) []api.BillResponse {
+	changed := make(map[int]api.BillResponse, len(update.Bills))
+	for _, bill := range update.Bills {
+		changed[bill.ID] = bill
+	}
+	exists := make(map[int]bool, len(update.AllBillIDs))
+	for _, id := range update.AllBillIDs {
+		exists[id] = true
+	}
+
+	var result []api.BillResponse
+	for _, bill := range bills {
+		if update.AllBillIDs != nil && !exists[bill.ID] {
+			continue
+		}
+		if c, ok := changed[bill.ID]; ok {
+			bill = c
+			delete(changed, bill.ID)
+		}
+		result = append(result, bill)
+	}
+	for _, bill := range update.Bills {
+		if _, ok := changed[bill.ID]; ok {
+			result = append(result, bill)
+		}
+	}
+	return result
+}
+
+// filterFlags are the flags that select which bills are listed, in the order
+// --show-filters prints them
+var filterFlags = []string{
+	"by", "for", "involves", "mine", "amount", "amount-original", "name", "method", "category",
+	"date", "today", "this-month", "this-week", "recent", "year", "from", "to",
+	"weekday", "weekends", "recurring", "one-time", "new", "limit",
+}
+
+// appliedFilter is a filter flag given on the command line. Value is a
+// string, a list of strings for repeatable flags, or true for switches.
+type appliedFilter struct {
+	Name  string
+	Value any
+}
+
+// String returns the filter like "category=Food", or "date>=2026-01-01" when
+// the value starts with a comparison
+func (f appliedFilter) String() string {
+	switch v := f.Value.(type) {
+	case bool:
+		return f.Name
+	case []string:
+		return f.Name + "=" + strings.Join(v, ",")
+	default:
+		s := fmt.Sprint(v)
+		if strings.IndexAny(s, "<>=") == 0 {
+			return f.Name + s
+		}
+		return f.Name + "=" + s
+	}
+}
+
+// activeFilters returns the filter flags given to cmd
+func activeFilters(cmd *cobra.Command) []appliedFilter {
+	var result []appliedFilter
+	for _, name := range filterFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		switch flag.Value.Type() {
+		case "bool":
+			if flag.Value.String() == "true" {
+				result = append(result, appliedFilter{Name: name, Value: true})
+			}
+		case "stringArray":
+			values, _ := cmd.Flags().GetStringArray(name)
+			result = append(result, appliedFilter{Name: name, Value: values})
+		default:
+			if value := flag.Value.String(); value != "" {
+				result = append(result, appliedFilter{Name: name, Value: value})
+			}
+		}
+	}
+	return result
+}
+
+// formatFilters joins filters for the --show-filters header
+func formatFilters(filters []appliedFilter) string {
+	if len(filters) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = f.String()
+	}
+	return strings.Join(parts, ", ")
 }
 
 // billFilter is a function that returns true if a bill should be included
 type billFilter func(bill api.BillResponse) bool
 
-func buildFilters(project *api.Project) ([]billFilter, error) {
+// buildFilters builds the filters selected by the filter flags. user is the
+// authenticated user, which --mine resolves to a member of project. Dates
+// relative to today are computed in loc.
+func buildFilters(project *api.Project, user string, loc *time.Location) ([]billFilter, error) {
 	var filters []billFilter
 
+	// Filter to bills involving the authenticated user
+	if listMine {
+		memberID, err := cache.ResolveMember(project, user)
+		if err != nil {
+			return nil, fmt.Errorf("resolving --mine filter: %s is not a member of project %s", user, project.ID)
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return involvesMember(bill, memberID)
+		})
+	}
+
+	// Filter to bills involving any of the given members
+	if len(listInvolves) > 0 {
+		var memberIDs []int
+		for _, username := range listInvolves {
+			memberID, err := cache.ResolveMember(project, username)
+			if err != nil {
+				return nil, fmt.Errorf("resolving --involves filter: %w", err)
+			}
+			memberIDs = append(memberIDs, memberID)
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			for _, memberID := range memberIDs {
+				if involvesMember(bill, memberID) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
 	// Filter by payer
 	if listPaidBy != "" {
 		payerID, err := cache.ResolveMember(project, listPaidBy)
@@ -222,6 +759,21 @@ func buildFilters(project *api.Project) ([]billFilter, error) {
 		})
 	}
 
+	// Filter by the amount before conversion to the main currency
+	if listAmountOrig != "" {
+		af, err := parseAmountFilter(listAmountOrig)
+		if err != nil {
+			return nil, fmt.Errorf("parsing original amount filter: %w", err)
+		}
+		rates := make(map[int]float64, len(project.Currencies))
+		for _, c := range project.Currencies {
+			rates[c.ID] = c.ExchangeRate
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return matchAmount(originalAmount(bill, rates), af)
+		})
+	}
+
 	// Filter by name (case-insensitive contains)
 	if listName != "" {
 		lowerName := strings.ToLower(listName)
@@ -254,15 +806,15 @@ func buildFilters(project *api.Project) ([]billFilter, error) {
 
 	// Filter by today
 	if listToday {
-		today := time.Now().Format("2006-01-02")
+		today := now().In(loc).Format("2006-01-02")
 		filters = append(filters, func(bill api.BillResponse) bool {
-			return bill.Date == today
+			return validBillDate(bill.Date) && bill.Date == today
 		})
 	}
 
 	// Filter by date
 	if listDate != "" {
-		df, err := parseDateFilter(listDate)
+		df, err := parseDateFilter(listDate, loc)
 		if err != nil {
 			return nil, fmt.Errorf("parsing date filter: %w", err)
 		}
@@ -273,44 +825,119 @@ func buildFilters(project *api.Project) ([]billFilter, error) {
 
 	// Filter by this month
 	if listThisMonth {
-		now := time.Now()
-		prefix := now.Format("2006-01")
+		today := now().In(loc)
+		prefix := today.Format("2006-01")
 		filters = append(filters, func(bill api.BillResponse) bool {
-			return strings.HasPrefix(bill.Date, prefix)
+			return validBillDate(bill.Date) && strings.HasPrefix(bill.Date, prefix)
 		})
 	}
 
 	// Filter by this week
 	if listThisWeek {
-		now := time.Now()
-		weekday := now.Weekday()
+		today := now().In(loc)
+		weekday := today.Weekday()
 		if weekday == time.Sunday {
 			weekday = 7
 		}
-		startOfWeek := now.AddDate(0, 0, -int(weekday-time.Monday))
+		startOfWeek := today.AddDate(0, 0, -int(weekday-time.Monday))
 		endOfWeek := startOfWeek.AddDate(0, 0, 6)
 		startStr := startOfWeek.Format("2006-01-02")
 		endStr := endOfWeek.Format("2006-01-02")
 		filters = append(filters, func(bill api.BillResponse) bool {
-			return bill.Date >= startStr && bill.Date <= endStr
+			return validBillDate(bill.Date) && bill.Date >= startStr && bill.Date <= endStr
 		})
 	}
 
 	// Filter by recent duration
 	if listRecent != "" {
-		cutoff, err := parseRecent(listRecent)
+		cutoff, err := parseRecent(listRecent, loc)
 		if err != nil {
 			return nil, fmt.Errorf("parsing recent filter: %w", err)
 		}
 		cutoffStr := cutoff.Format("2006-01-02")
 		filters = append(filters, func(bill api.BillResponse) bool {
-			return bill.Date >= cutoffStr
+			return validBillDate(bill.Date) && bill.Date >= cutoffStr
+		})
+	}
+
+	// Filter by date range
+	if listFrom != "" || listTo != "" {
+		if listDate != "" || listToday || listThisMonth || listThisWeek || listRecent != "" {
+			return nil, fmt.Errorf("--from/--to can't be combined with --date, --today, --this-month, --this-week, or --recent")
+		}
+	}
+	if listFrom != "" {
+		from, err := parseFilterDate(listFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("parsing --from: %w", err)
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return validBillDate(bill.Date) && bill.Date >= from
+		})
+	}
+	if listTo != "" {
+		to, err := parseFilterDate(listTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("parsing --to: %w", err)
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return validBillDate(bill.Date) && bill.Date <= to
+		})
+	}
+
+	// Filter by day of the week
+	if listWeekday != "" || listWeekends {
+		if listWeekday != "" && listWeekends {
+			return nil, fmt.Errorf("--weekday can't be combined with --weekends")
+		}
+		spec := listWeekday
+		if listWeekends {
+			spec = "sat,sun"
+		}
+		days, err := parseWeekdays(spec)
+		if err != nil {
+			return nil, fmt.Errorf("parsing weekday filter: %w", err)
+		}
+		filters = append(filters, func(bill api.BillResponse) bool {
+			t, err := time.Parse("2006-01-02", bill.Date)
+			if err != nil {
+				return false
+			}
+			return days[t.Weekday()]
+		})
+	}
+
+	// Filter by repeat
+	if listRecurring && listOneTime {
+		return nil, fmt.Errorf("--recurring can't be combined with --one-time")
+	}
+	if listRecurring || listOneTime {
+		want := listRecurring
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return isRecurring(bill.Repeat) == want
+		})
+	}
+
+	// Filter by year
+	if listYear != 0 {
+		if listYear < 1 || listYear > 9999 {
+			return nil, fmt.Errorf("invalid year: %d", listYear)
+		}
+		prefix := fmt.Sprintf("%04d-", listYear)
+		filters = append(filters, func(bill api.BillResponse) bool {
+			return validBillDate(bill.Date) && strings.HasPrefix(bill.Date, prefix)
 		})
 	}
 
 	return filters, nil
 }
 
+// isRecurring reports whether a bill's repeat value makes it repeat. An empty
+// value is treated like "n" (no repeat).
+func isRecurring(repeat string) bool {
+	return repeat != "" && repeat != "n"
+}
+
 func applyFilters(bills []api.BillResponse, filters []billFilter) []api.BillResponse {
 	if len(filters) == 0 {
 		return bills
@@ -378,7 +1005,9 @@ type dateFilter struct {
 	date     string // YYYY-MM-DD format for string comparison
 }
 
-func parseDateFilter(s string) (dateFilter, error) {
+// parseDateFilter parses a date filter like ">=2026-01-01" or "01-15", with
+// short dates in the current year in loc
+func parseDateFilter(s string, loc *time.Location) (dateFilter, error) {
 	s = strings.TrimSpace(s)
 
 	re := regexp.MustCompile(`^(>=|<=|>|<|=)?(.+)$`)
@@ -392,23 +1021,68 @@ func parseDateFilter(s string) (dateFilter, error) {
 		operator = "="
 	}
 
-	dateStr := strings.TrimSpace(matches[2])
+	date, err := parseFilterDate(matches[2], loc)
+	if err != nil {
+		return dateFilter{}, err
+	}
+	return dateFilter{operator: operator, date: date}, nil
+}
+
+// parseFilterDate parses a YYYY-MM-DD or MM-DD date (assuming the current
+// year in loc) and returns it as YYYY-MM-DD
+func parseFilterDate(s string, loc *time.Location) (string, error) {
+	s = strings.TrimSpace(s)
 
 	// Try full date format YYYY-MM-DD
-	if _, err := time.Parse("2006-01-02", dateStr); err == nil {
-		return dateFilter{operator: operator, date: dateStr}, nil
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return s, nil
 	}
 
 	// Try short format MM-DD (assume current year)
-	if t, err := time.Parse("01-02", dateStr); err == nil {
-		dateStr = fmt.Sprintf("%d-%s", time.Now().Year(), t.Format("01-02"))
-		return dateFilter{operator: operator, date: dateStr}, nil
+	if t, err := time.Parse("01-02", s); err == nil {
+		return fmt.Sprintf("%d-%s", now().In(loc).Year(), t.Format("01-02")), nil
+	}
+
+	return "", fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD or MM-DD)", s)
+}
+
+// originalAmount returns the bill's amount in the currency it was entered in,
+// using rates (currency ID to exchange rate) to undo the conversion. Bills in
+// the main currency, or in a currency the project no longer has, keep their
+// stored amount.
+func originalAmount(bill api.BillResponse, rates map[int]float64) float64 {
+	rate := rates[bill.OriginalCurrencyID]
+	if bill.OriginalCurrencyID == 0 || rate <= 0 {
+		return bill.Amount
+	}
+	return math.Round(bill.Amount/rate*100) / 100
+}
+
+// involvesMember reports whether the member paid the bill or owes a share of it
+func involvesMember(bill api.BillResponse, memberID int) bool {
+	if bill.PayerID == memberID {
+		return true
+	}
+	for _, ower := range bill.Owers {
+		if ower.ID == memberID {
+			return true
+		}
 	}
+	return false
+}
 
-	return dateFilter{}, fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD or MM-DD)", dateStr)
+// validBillDate reports whether s is a YYYY-MM-DD date. Date filters compare
+// dates as strings, so bills with empty or malformed dates must be excluded
+// explicitly rather than compared (an empty string sorts before everything).
+func validBillDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
 }
 
 func matchDate(billDate string, df dateFilter) bool {
+	if !validBillDate(billDate) {
+		return false
+	}
 	switch df.operator {
 	case "=":
 		return billDate == df.date
@@ -425,7 +1099,40 @@ func matchDate(billDate string, df dateFilter) bool {
 	}
 }
 
-func parseRecent(s string) (time.Time, error) {
+// weekdayNames maps short and full lowercase weekday names to time.Weekday
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseWeekdays parses a comma-separated list of weekday names (e.g., "sat,sun")
+func parseWeekdays(s string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool)
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdayNames[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday: %s (expected mon, tue, wed, thu, fri, sat, or sun)", part)
+		}
+		days[day] = true
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no weekdays given")
+	}
+	return days, nil
+}
+
+// parseRecent parses a duration like 7d, 2w, or 1m and returns the time that
+// long before now in loc
+func parseRecent(s string, loc *time.Location) (time.Time, error) {
 	s = strings.TrimSpace(s)
 	if len(s) < 2 {
 		return time.Time{}, fmt.Errorf("invalid recent format: %s (expected e.g. 7d, 2w, 1m)", s)
@@ -438,14 +1145,14 @@ func parseRecent(s string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("invalid recent value: %s", valueStr)
 	}
 
-	now := time.Now()
+	today := now().In(loc)
 	switch unit {
 	case 'd':
-		return now.AddDate(0, 0, -value), nil
+		return today.AddDate(0, 0, -value), nil
 	case 'w':
-		return now.AddDate(0, 0, -value*7), nil
+		return today.AddDate(0, 0, -value*7), nil
 	case 'm':
-		return now.AddDate(0, -value, 0), nil
+		return today.AddDate(0, -value, 0), nil
 	default:
 		return time.Time{}, fmt.Errorf("invalid recent unit: %c (expected d, w, or m)", unit)
 	}
@@ -453,23 +1160,208 @@ func parseRecent(s string) (time.Time, error) {
 
 // resolvedBill holds a bill with human-readable names resolved from IDs
 type resolvedBill struct {
-	ID            int      `json:"id"`
-	Date          string   `json:"date"`
-	Name          string   `json:"name"`
-	Amount        float64  `json:"amount"`
-	PaidBy        string   `json:"paid_by"`
-	PaidFor       []string `json:"paid_for"`
-	Category      string   `json:"category"`
-	PaymentMethod string   `json:"payment_method"`
+	ID             int      `json:"id"`
+	Date           string   `json:"date"`
+	Name           string   `json:"name"`
+	Amount         float64  `json:"amount"`
+	OriginalAmount *float64 `json:"original_amount,omitempty"`
+	PaidBy         string   `json:"paid_by"`
+	PaidFor        []string `json:"paid_for"`
+	Category       string   `json:"category"`
+	PaymentMethod  string   `json:"payment_method"`
+	Comment        string   `json:"comment"`
+
+	// Shares are only filled in with --per-person
+	Shares []billShare `json:"shares,omitempty"`
+
+	// Icons are only shown in the table with --show-icons
+	CategoryIcon      string `json:"-"`
+	PaymentMethodIcon string `json:"-"`
+
+	// Repeat is how often the bill repeats ("monthly"), empty when it doesn't.
+	// It's only shown in the table and CSV with --show-repeat.
+	Repeat string `json:"-"`
+
+	// ids are only included in JSON output with --raw
+	ids billIDs
+}
+
+// billShare is how much one owed member owes of a bill
+type billShare struct {
+	Member string  `json:"member"`
+	Owes   float64 `json:"owes"`
+}
+
+// billIDs holds the numeric IDs behind a resolved bill's names
+type billIDs struct {
+	PayerID       int        `json:"payer_id"`
+	Owers         []api.Ower `json:"owers"`
+	CategoryID    int        `json:"category_id"`
+	PaymentModeID int        `json:"payment_mode_id"`
+}
+
+// rawBill is a resolved bill with its numeric IDs, for --format json --raw
+type rawBill struct {
+	resolvedBill
+	billIDs
+}
+
+// resolveDisplayCurrency finds the currency to convert displayed amounts into.
+// The project's main currency is accepted too, with an exchange rate of 1.
+func resolveDisplayCurrency(project *api.Project, nameOrID string) (*api.Currency, error) {
+	currency, err := cache.ResolveCurrency(project, nameOrID)
+	if err != nil {
+		if project.CurrencyName != "" && strings.EqualFold(project.CurrencyName, nameOrID) {
+			return &api.Currency{Name: project.CurrencyName, ExchangeRate: 1}, nil
+		}
+		return nil, fmt.Errorf("resolving currency: %w", err)
+	}
+	if currency.ExchangeRate <= 0 {
+		return nil, fmt.Errorf("currency %s has no exchange rate", currency.Name)
+	}
+	return currency, nil
+}
+
+// convertBills converts bill amounts from the project's main currency using the
+// given exchange rate (units of main currency per unit of the target currency).
+// When keepOriginal is set, the unconverted amount is kept in OriginalAmount.
+func convertBills(bills []resolvedBill, rate float64, keepOriginal bool) []resolvedBill {
+	result := make([]resolvedBill, len(bills))
+	for i, bill := range bills {
+		if keepOriginal {
+			original := bill.Amount
+			bill.OriginalAmount = &original
+		}
+		bill.Amount = bill.Amount / rate
+		result[i] = bill
+	}
+	return result
+}
+
+// formatBillDates reformats bill dates with a Go layout for display.
+func formatBillDates(bills []resolvedBill, layout string) []resolvedBill {
+	result := make([]resolvedBill, len(bills))
+	for i, bill := range bills {
+		bill.Date = format.FormatDate(bill.Date, layout)
+		result[i] = bill
+	}
+	return result
+}
+
+// splitBills fills in each bill's Shares, for --per-person.
+func splitBills(bills []resolvedBill) []resolvedBill {
+	result := make([]resolvedBill, len(bills))
+	for i, bill := range bills {
+		bill.Shares = billShares(bill.Amount, bill.PaidFor, bill.ids.Owers)
+		result[i] = bill
+	}
+	return result
+}
+
+// billShares splits amount between the owers in proportion to their weights.
+// names holds the owers' names, in the same order. When no ower has a
+// positive weight, the amount is split equally.
+func billShares(amount float64, names []string, owers []api.Ower) []billShare {
+	var totalWeight float64
+	for _, o := range owers {
+		if o.Weight > 0 {
+			totalWeight += o.Weight
+		}
+	}
+
+	shares := make([]billShare, len(owers))
+	for i, o := range owers {
+		var owes float64
+		switch {
+		case totalWeight == 0:
+			owes = amount / float64(len(owers))
+		case o.Weight > 0:
+			owes = amount * o.Weight / totalWeight
+		}
+		shares[i] = billShare{Member: names[i], Owes: owes}
+	}
+	return shares
+}
+
+// humanizeBillDates replaces bill dates with dates relative to now, for
+// --relative-dates.
+func humanizeBillDates(bills []resolvedBill, now time.Time) []resolvedBill {
+	result := make([]resolvedBill, len(bills))
+	for i, bill := range bills {
+		bill.Date = humanizeDate(bill.Date, now)
+		result[i] = bill
+	}
+	return result
+}
+
+// humanizeDate describes a YYYY-MM-DD date relative to now's calendar day,
+// e.g. "today", "yesterday", "3 days ago", "last month", or "in 2 weeks".
+// Dates that can't be parsed are returned unchanged.
+func humanizeDate(date string, now time.Time) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(today.Sub(t).Hours() / 24)
+	future := days < 0
+	earlier, later := t, today
+	if future {
+		days = -days
+		earlier, later = today, t
+	}
+
+	// Whole calendar months between the two dates
+	months := (later.Year()-earlier.Year())*12 + int(later.Month()-earlier.Month())
+	if later.Day() < earlier.Day() {
+		months--
+	}
+
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1 && future:
+		return "tomorrow"
+	case days == 1:
+		return "yesterday"
+	case days < 7:
+		return relativeUnit(days, "day", future)
+	case months < 1:
+		return relativeUnit(days/7, "week", future)
+	case months < 12:
+		return relativeUnit(months, "month", future)
+	default:
+		return relativeUnit(months/12, "year", future)
+	}
+}
+
+// relativeUnit formats a count of units as "last week", "3 weeks ago",
+// "next week", or "in 3 weeks".
+func relativeUnit(n int, unit string, future bool) string {
+	switch {
+	case n == 1 && future:
+		return "next " + unit
+	case n == 1:
+		return "last " + unit
+	case future:
+		return fmt.Sprintf("in %d %ss", n, unit)
+	default:
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
 }
 
 func resolveBillNames(project *api.Project, bills []api.BillResponse) []resolvedBill {
-	// Sort by date (newest first), then by timestamp for same-date entries
+	// Sort by date (newest first), then by timestamp and ID for same-date
+	// entries so bills created in the same second keep a stable order
 	sort.Slice(bills, func(i, j int) bool {
 		if bills[i].Date != bills[j].Date {
 			return bills[i].Date > bills[j].Date
 		}
-		return bills[i].Timestamp > bills[j].Timestamp
+		if bills[i].Timestamp != bills[j].Timestamp {
+			return bills[i].Timestamp > bills[j].Timestamp
+		}
+		return bills[i].ID > bills[j].ID
 	})
 
 	// Apply limit if set
@@ -482,13 +1374,17 @@ func resolveBillNames(project *api.Project, bills []api.BillResponse) []resolved
 	for _, m := range project.Members {
 		memberNames[m.ID] = m.Name
 	}
-	categoryNames := make(map[int]string)
+	categoryNames := map[int]string{api.CategoryReimbursement: reimbursementName}
+	categoryIcons := make(map[int]string)
 	for _, c := range project.Categories {
 		categoryNames[c.ID] = c.Name
+		categoryIcons[c.ID] = c.Icon
 	}
 	paymentModeNames := make(map[int]string)
+	paymentModeIcons := make(map[int]string)
 	for _, pm := range project.PaymentModes {
 		paymentModeNames[pm.ID] = pm.Name
+		paymentModeIcons[pm.ID] = pm.Icon
 	}
 
 	var result []resolvedBill
@@ -517,96 +1413,494 @@ func resolveBillNames(project *api.Project, bills []api.BillResponse) []resolved
 			methodName = fmt.Sprintf("#%d", bill.PaymentModeID)
 		}
 
-		name := strings.Map(func(r rune) rune {
-			if r == '\n' || r == '\r' || r == '\t' {
-				return ' '
-			}
-			return r
-		}, strings.TrimSpace(bill.What))
-
 		result = append(result, resolvedBill{
 			ID:            bill.ID,
 			Date:          bill.Date,
-			Name:          name,
+			Name:          strings.TrimSpace(bill.What),
 			Amount:        bill.Amount,
 			PaidBy:        payerName,
 			PaidFor:       owerNames,
 			Category:      catName,
 			PaymentMethod: methodName,
+
+			CategoryIcon:      categoryIcons[bill.CategoryID],
+			PaymentMethodIcon: paymentModeIcons[bill.PaymentModeID],
+			Repeat:            repeatName(bill.Repeat),
+			Comment:           bill.Comment,
+
+			ids: billIDs{
+				PayerID:       bill.PayerID,
+				Owers:         bill.Owers,
+				CategoryID:    bill.CategoryID,
+				PaymentModeID: bill.PaymentModeID,
+			},
 		})
 	}
 	return result
 }
 
-func printBillsTable(cmd *cobra.Command, bills []resolvedBill, formatter *format.AmountFormatter) {
+// repeatName returns the name of a repeat frequency, like "monthly", or ""
+// for bills that don't repeat. Unknown codes are returned as-is.
+func repeatName(repeat string) string {
+	if !isRecurring(repeat) {
+		return ""
+	}
+	if name, ok := api.ValidRepeatFrequencies[repeat]; ok {
+		return name
+	}
+	return repeat
+}
+
+// printBillsTable renders bills as a table. When origFormatter is non-nil, an
+// ORIGINAL column shows each bill's unconverted amount.
+func printBillsTable(out io.Writer, bills []resolvedBill, formatter, origFormatter *format.AmountFormatter) {
 	if len(bills) == 0 {
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No bills found.")
+		_, _ = fmt.Fprintln(out, "No bills found.")
 		return
 	}
 
-	table := NewTable("ID", "DATE", "NAME", "AMOUNT", "PAID BY", "PAID FOR", "CATEGORY", "METHOD")
+	// --compact leaves out the columns least needed at a glance
+	var headers []string
+	if !listCompact {
+		headers = append(headers, "ID")
+	}
+	headers = append(headers, "DATE", "NAME", "AMOUNT")
+	if origFormatter != nil {
+		headers = append(headers, "ORIGINAL")
+	}
+	headers = append(headers, "PAID BY", "PAID FOR", "CATEGORY")
+	if !listCompact {
+		headers = append(headers, "METHOD")
+	}
+	if listShowRepeat {
+		headers = append(headers, "REPEAT")
+	}
+	table := NewTable(headers...)
+	if listCompact {
+		table.SetPadding(0)
+	}
 
-	var totalAmount float64
+	var totalAmount, totalOriginal float64
 	for _, bill := range bills {
 		totalAmount += bill.Amount
+		if bill.OriginalAmount != nil {
+			totalOriginal += *bill.OriginalAmount
+		}
 
 		catName := bill.Category
 		if catName == "" {
 			catName = "-"
+		} else if listShowIcons {
+			catName = withIcon(bill.CategoryIcon, catName)
 		}
 		methodName := bill.PaymentMethod
 		if methodName == "" {
 			methodName = "-"
+		} else if listShowIcons {
+			methodName = withIcon(bill.PaymentMethodIcon, methodName)
 		}
 
-		name := bill.Name
+		name := sanitizeForTable(bill.Name)
 		if len(name) > 30 {
 			name = name[:27] + "..."
 		}
 
-		table.AddRow(
-			fmt.Sprintf("%d", bill.ID),
-			bill.Date,
-			name,
-			formatter.Format(bill.Amount),
-			bill.PaidBy,
-			strings.Join(bill.PaidFor, ", "),
-			catName,
-			methodName,
-		)
+		var row []string
+		if !listCompact {
+			row = append(row, fmt.Sprintf("%d", bill.ID))
+		}
+		row = append(row, bill.Date, name, formatter.Format(bill.Amount))
+		if origFormatter != nil {
+			original := "-"
+			if bill.OriginalAmount != nil {
+				original = origFormatter.Format(*bill.OriginalAmount)
+			}
+			row = append(row, original)
+		}
+		paidFor := strings.Join(bill.PaidFor, ", ")
+		if bill.Shares != nil {
+			shares := make([]string, len(bill.Shares))
+			for i, s := range bill.Shares {
+				shares[i] = s.Member + ": " + formatter.Format(s.Owes)
+			}
+			paidFor = strings.Join(shares, ", ")
+		}
+
+		row = append(row, bill.PaidBy, paidFor, catName)
+		if !listCompact {
+			row = append(row, methodName)
+		}
+		if listShowRepeat {
+			repeat := bill.Repeat
+			if repeat == "" {
+				repeat = "-"
+			}
+			row = append(row, repeat)
+		}
+		table.AddRow(row...)
 	}
 
-	out := cmd.OutOrStdout()
 	table.Render(out)
+	if origFormatter != nil {
+		_, _ = fmt.Fprintf(out, "\nTotal: %d bill(s), %s (original: %s)\n", len(bills), formatter.Format(totalAmount), origFormatter.Format(totalOriginal))
+		return
+	}
 	_, _ = fmt.Fprintf(out, "\nTotal: %d bill(s), %s\n", len(bills), formatter.Format(totalAmount))
 }
 
-func printBillsCSV(cmd *cobra.Command, bills []resolvedBill) {
-	out := cmd.OutOrStdout()
+// withIcon prefixes name with icon, if there is one
+func withIcon(icon, name string) string {
+	if icon == "" {
+		return name
+	}
+	return icon + " " + name
+}
+
+// sanitizeForTable replaces line breaks and tabs with spaces so a value stays on
+// one table row. Only the table needs this; CSV and JSON keep the original text.
+func sanitizeForTable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// printBillsCSV writes bills as delimiter-separated values, using comma as the
+// field separator (',' for CSV, '\t' for TSV). The header row is skipped with --no-header.
+func printBillsCSV(out io.Writer, bills []resolvedBill, comma rune) {
 	w := csv.NewWriter(out)
+	w.Comma = comma
 
-	_ = w.Write([]string{"ID", "Date", "Name", "Amount", "Paid By", "Paid For", "Category", "Payment Method"})
+	if !listNoHeader {
+		_ = w.Write(billCSVHeader())
+	}
 	for _, bill := range bills {
-		_ = w.Write([]string{
-			strconv.Itoa(bill.ID),
-			bill.Date,
-			bill.Name,
-			strconv.FormatFloat(bill.Amount, 'f', 2, 64),
-			bill.PaidBy,
-			strings.Join(bill.PaidFor, ", "),
-			bill.Category,
-			bill.PaymentMethod,
-		})
+		_ = w.Write(billCSVRecord(bill))
 	}
 	w.Flush()
 }
 
-func printBillsJSON(cmd *cobra.Command, bills []resolvedBill) {
-	out := cmd.OutOrStdout()
-	if bills == nil {
-		bills = []resolvedBill{}
+// billCSVHeader returns the column names written by printBillsCSV
+func billCSVHeader() []string {
+	header := []string{"ID", "Date", "Name", "Amount", "Paid By", "Paid For", "Category", "Payment Method"}
+	if listShowOriginal {
+		header = append(header, "Original Amount")
+	}
+	if listShowRepeat {
+		header = append(header, "Repeat")
+	}
+	// Comment stays last so adding it didn't move the other columns
+	return append(header, "Comment")
+}
+
+// billCSVRecord returns a bill's fields in billCSVHeader order
+func billCSVRecord(bill resolvedBill) []string {
+	record := []string{
+		strconv.Itoa(bill.ID),
+		bill.Date,
+		bill.Name,
+		strconv.FormatFloat(bill.Amount, 'f', 2, 64),
+		bill.PaidBy,
+		strings.Join(bill.PaidFor, ", "),
+		bill.Category,
+		bill.PaymentMethod,
+	}
+	if listShowOriginal {
+		original := ""
+		if bill.OriginalAmount != nil {
+			original = strconv.FormatFloat(*bill.OriginalAmount, 'f', 2, 64)
+		}
+		record = append(record, original)
+	}
+	if listShowRepeat {
+		record = append(record, bill.Repeat)
+	}
+	return append(record, bill.Comment)
+}
+
+// parseBillTemplate parses a --template string and checks it against an empty
+// bill, so unknown fields are reported before any bills are fetched. The money
+// function formats amounts with formatter, which may be nil while validating.
+func parseBillTemplate(text string, formatter *format.AmountFormatter) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"money": func(amount float64) string {
+			if formatter == nil {
+				return ""
+			}
+			return formatter.Format(amount)
+		},
+		"join": func(items []string, sep string) string {
+			return strings.Join(items, sep)
+		},
+	}
+
+	tmpl, err := template.New("bill").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, resolvedBill{PaidFor: []string{}}); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printBillsTemplate executes tmpl for each bill, one bill per line
+func printBillsTemplate(out io.Writer, bills []resolvedBill, tmpl *template.Template) error {
+	for _, bill := range bills {
+		if err := tmpl.Execute(out, bill); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+		_, _ = fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// printBillsTotal prints only the sum of the bills' amounts, or the count and
+// total as a JSON object with --format json
+func printBillsTotal(out io.Writer, bills []resolvedBill, formatter *format.AmountFormatter) {
+	var total float64
+	for _, bill := range bills {
+		total += bill.Amount
+	}
+
+	if listFormat == "json" || listFormat == "ndjson" {
+		enc := json.NewEncoder(out)
+		_ = enc.Encode(struct {
+			Count int     `json:"count"`
+			Total float64 `json:"total"`
+		}{len(bills), math.Round(total*100) / 100})
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, formatter.Format(total))
+}
+
+// sumBills totals bills by the given --sum-by dimension. Months are ordered
+// oldest first, and other groups by total, highest first.
+func sumBills(bills []resolvedBill, by string) []statsGroup {
+	switch by {
+	case "month":
+		months := monthlyTotals(bills)
+		groups := make([]statsGroup, len(months))
+		for i, m := range months {
+			groups[i] = statsGroup{Name: m.Month, Count: m.Count, Total: m.Total}
+		}
+		return groups
+	case "payer":
+		return groupBills(bills, func(b resolvedBill) string { return b.PaidBy })
+	case "method":
+		return groupBills(bills, func(b resolvedBill) string { return b.PaymentMethod })
+	default:
+		return groupBills(bills, func(b resolvedBill) string { return b.Category })
+	}
+}
+
+// printBillSums prints --sum-by totals in the selected format. Months are
+// named in the language of locale in the table, and kept as YYYY-MM otherwise.
+func printBillSums(out io.Writer, groups []statsGroup, formatter *format.AmountFormatter, locale string) {
+	columns := sumByColumns[listSumBy]
+
+	switch listFormat {
+	case "json":
+		for i := range groups {
+			groups[i].Total = math.Round(groups[i].Total*100) / 100
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(groups)
+	case "ndjson":
+		enc := json.NewEncoder(out)
+		for _, g := range groups {
+			g.Total = math.Round(g.Total*100) / 100
+			_ = enc.Encode(g)
+		}
+	case "csv", "tsv":
+		w := csv.NewWriter(out)
+		if listFormat == "tsv" {
+			w.Comma = '\t'
+		}
+		if !listNoHeader {
+			_ = w.Write([]string{columns[1], "Count", "Total"})
+		}
+		for _, g := range groups {
+			_ = w.Write([]string{g.Name, strconv.Itoa(g.Count), strconv.FormatFloat(g.Total, 'f', 2, 64)})
+		}
+		w.Flush()
+	default:
+		if len(groups) == 0 {
+			_, _ = fmt.Fprintln(out, "No bills found.")
+			return
+		}
+		table := NewTable(columns[0], "COUNT", "TOTAL")
+		for _, g := range groups {
+			name := g.Name
+			if listSumBy == "month" {
+				name = format.MonthName(name, locale)
+			}
+			table.AddRow(name, strconv.Itoa(g.Count), formatter.Format(g.Total))
+		}
+		table.Render(out)
+	}
+}
+
+// printBillsJSON prints bills as an indented JSON array. The array is written
+// one bill at a time instead of being encoded as a whole, so projects with
+// thousands of bills don't need a second, encoded copy of them in memory.
+func printBillsJSON(out io.Writer, bills []resolvedBill) {
+	if len(bills) == 0 {
+		_, _ = fmt.Fprintln(out, "[]")
+		return
+	}
+
+	w := bufio.NewWriter(out)
+	defer func() { _ = w.Flush() }()
+
+	_, _ = w.WriteString("[")
+	for i, bill := range bills {
+		// Indent each bill as if it were encoded inside the array
+		data, err := json.MarshalIndent(jsonBill(bill), "  ", "  ")
+		if err != nil {
+			continue
+		}
+		if i > 0 {
+			_, _ = w.WriteString(",")
+		}
+		_, _ = w.WriteString("\n  ")
+		_, _ = w.Write(data)
+	}
+	_, _ = w.WriteString("\n]\n")
+}
+
+// printBillsNDJSON prints bills as JSON Lines: one compact object per line,
+// with no enclosing array, for streaming into tools like jq -c
+func printBillsNDJSON(out io.Writer, bills []resolvedBill) {
+	w := bufio.NewWriter(out)
+	defer func() { _ = w.Flush() }()
+
+	enc := json.NewEncoder(w)
+	for _, bill := range bills {
+		_ = enc.Encode(jsonBill(bill))
+	}
+}
+
+// printBillsJSONWithFilters prints bills as JSON with the filters that
+// selected them: {"filters": {...}, "bills": [...]}. Like printBillsJSON,
+// bills are encoded one at a time rather than building the whole report.
+func printBillsJSONWithFilters(out io.Writer, bills []resolvedBill, filters []appliedFilter) {
+	applied := make(map[string]any, len(filters))
+	for _, f := range filters {
+		applied[f.Name] = f.Value
+	}
+	data, err := json.MarshalIndent(applied, "  ", "  ")
+	if err != nil {
+		return
+	}
+
+	w := bufio.NewWriter(out)
+	defer func() { _ = w.Flush() }()
+
+	_, _ = w.WriteString("{\n  \"filters\": ")
+	_, _ = w.Write(data)
+	_, _ = w.WriteString(",\n  \"bills\": [")
+	wrote := false
+	for _, bill := range bills {
+		data, err := json.MarshalIndent(jsonBill(bill), "    ", "  ")
+		if err != nil {
+			continue
+		}
+		if wrote {
+			_, _ = w.WriteString(",")
+		}
+		_, _ = w.WriteString("\n    ")
+		_, _ = w.Write(data)
+		wrote = true
+	}
+	if wrote {
+		_, _ = w.WriteString("\n  ")
+	}
+	_, _ = w.WriteString("]\n}\n")
+}
+
+// jsonBill returns what's encoded for bill in JSON output, which includes
+// its IDs with --raw and only the chosen keys with --fields
+func jsonBill(bill resolvedBill) any {
+	var v any = bill
+	if listRaw {
+		if bill.ids.Owers == nil {
+			bill.ids.Owers = []api.Ower{}
+		}
+		v = rawBill{resolvedBill: bill, billIDs: bill.ids}
 	}
-	enc := json.NewEncoder(out)
-	enc.SetIndent("", "  ")
-	_ = enc.Encode(bills)
+	if len(listFields) == 0 {
+		return v
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return v
+	}
+	// Keys left out when empty are null, so every bill has the same keys
+	selected := fieldsBill{values: make(map[string]json.RawMessage, len(listFields))}
+	for _, field := range listFields {
+		if _, seen := selected.values[field]; seen {
+			continue
+		}
+		value, ok := all[field]
+		if !ok {
+			value = json.RawMessage("null")
+		}
+		selected.keys = append(selected.keys, field)
+		selected.values[field] = value
+	}
+	return selected
+}
+
+// fieldsBill is a bill cut down to the keys chosen with --fields. Unlike a
+// map, it's encoded with the keys in the order they were given.
+type fieldsBill struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func (b fieldsBill) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range b.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(b.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// checkFields reports the first of fields that isn't a JSON bill key. The raw
+// keys need --raw.
+func checkFields(fields []string) error {
+	for _, field := range fields {
+		if slices.Contains(billFields, field) {
+			continue
+		}
+		if slices.Contains(rawBillFields, field) {
+			if !listRaw {
+				return fmt.Errorf("--fields %s requires --raw", field)
+			}
+			continue
+		}
+		return fmt.Errorf("unknown field: %s (expected %s)", field, strings.Join(billFields, ", "))
+	}
+	return nil
 }
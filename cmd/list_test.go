@@ -2,13 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
 	"github.com/chenasraf/cospend-cli/internal/format"
 )
 
@@ -171,7 +179,7 @@ func TestPrintBillsTable(t *testing.T) {
 	cmd.SetOut(buf)
 
 	formatter := format.NewAmountFormatter("en_US", "USD")
-	printBillsTable(cmd, resolved, formatter)
+	printBillsTable(cmd.OutOrStdout(), resolved, formatter, nil)
 
 	output := buf.String()
 
@@ -199,6 +207,135 @@ func TestPrintBillsTable(t *testing.T) {
 	}
 }
 
+func TestPrintBillsTableRefund(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		Members: []api.Member{{ID: 1, Name: "Alice"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Refund", Amount: -45, Date: "2026-02-03", PayerID: 1, Owers: []api.Ower{{ID: 1, Weight: 1}}, CategoryID: api.CategoryReimbursement},
+	}
+	resolved := resolveBillNames(project, bills)
+	if resolved[0].Category != "Reimbursement" {
+		t.Errorf("Category = %q, want Reimbursement", resolved[0].Category)
+	}
+
+	var buf bytes.Buffer
+	printBillsTable(&buf, resolved, format.NewAmountFormatter("en_US", "USD"), nil)
+	if !strings.Contains(buf.String(), "-$ 45.00") {
+		t.Errorf("Output should contain the negative amount, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintBillsTableShowIcons(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		Categories:   []api.Category{{ID: 1, Name: "Food", Icon: "🍕"}, {ID: 2, Name: "Rent"}},
+		PaymentModes: []api.PaymentMode{{ID: 1, Name: "Cash", Icon: "💵"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Pizza", Amount: 20, Date: "2026-02-03", CategoryID: 1, PaymentModeID: 1},
+		{ID: 2, What: "March rent", Amount: 900, Date: "2026-02-01", CategoryID: 2},
+	}
+	resolved := resolveBillNames(project, bills)
+	formatter := format.NewAmountFormatter("en_US", "USD")
+
+	var plain bytes.Buffer
+	printBillsTable(&plain, resolved, formatter, nil)
+	if strings.Contains(plain.String(), "🍕") || strings.Contains(plain.String(), "💵") {
+		t.Errorf("Icons should be hidden by default, got:\n%s", plain.String())
+	}
+
+	listShowIcons = true
+	var withIcons bytes.Buffer
+	printBillsTable(&withIcons, resolved, formatter, nil)
+	output := withIcons.String()
+	if !strings.Contains(output, "🍕 Food") || !strings.Contains(output, "💵 Cash") {
+		t.Errorf("Expected icon-prefixed category and method, got:\n%s", output)
+	}
+	if !strings.Contains(output, " Rent ") {
+		t.Errorf("Category without an icon should be shown as-is, got:\n%s", output)
+	}
+
+	var jsonBuf bytes.Buffer
+	printBillsJSON(&jsonBuf, resolved)
+	if strings.Contains(jsonBuf.String(), "🍕") {
+		t.Errorf("Icons should not appear in JSON, got:\n%s", jsonBuf.String())
+	}
+}
+
+func TestPrintBillsShowRepeat(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	bills := []api.BillResponse{
+		{ID: 1, What: "Netflix", Amount: 15, Date: "2026-02-03", Repeat: "m"},
+		{ID: 2, What: "Pizza", Amount: 20, Date: "2026-02-01", Repeat: "n"},
+	}
+	resolved := resolveBillNames(&api.Project{}, bills)
+	formatter := format.NewAmountFormatter("en_US", "USD")
+
+	var plain bytes.Buffer
+	printBillsTable(&plain, resolved, formatter, nil)
+	if strings.Contains(plain.String(), "REPEAT") {
+		t.Errorf("Repeat column should be hidden by default, got:\n%s", plain.String())
+	}
+
+	listShowRepeat = true
+	var table bytes.Buffer
+	printBillsTable(&table, resolved, formatter, nil)
+	if !strings.Contains(table.String(), "REPEAT") || !strings.Contains(table.String(), "monthly") {
+		t.Errorf("Expected a REPEAT column with monthly, got:\n%s", table.String())
+	}
+
+	var csvBuf bytes.Buffer
+	printBillsCSV(&csvBuf, resolved, ',')
+	lines := strings.Split(strings.TrimSpace(csvBuf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Repeat,Comment") {
+		t.Errorf("CSV header should end with Repeat and Comment, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",monthly,") || !strings.HasSuffix(lines[2], ",,") {
+		t.Errorf("CSV rows should end with the repeat name, got %q and %q", lines[1], lines[2])
+	}
+}
+
+func TestPrintBillsTableCompact(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	bills := []resolvedBill{
+		{ID: 42, Date: "2026-02-03", Name: "Lunch", Amount: 20, PaidBy: "Alice", PaidFor: []string{"Alice"}, Category: "Food", PaymentMethod: "Card"},
+	}
+	formatter := format.NewAmountFormatter("en_US", "")
+
+	var regular bytes.Buffer
+	printBillsTable(&regular, bills, formatter, nil)
+
+	listCompact = true
+	var compact bytes.Buffer
+	printBillsTable(&compact, bills, formatter, nil)
+
+	output := compact.String()
+	if !strings.Contains(output, "│DATE      │NAME │") || !strings.Contains(output, "│2026-02-03│Lunch│") {
+		t.Errorf("Expected unpadded cells, got:\n%s", output)
+	}
+	if strings.Contains(output, "METHOD") || strings.Contains(output, "Card") || strings.Contains(output, "42") {
+		t.Errorf("Compact table should drop the ID and METHOD columns, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Total: 1 bill(s)") {
+		t.Errorf("Compact table should keep the total, got:\n%s", output)
+	}
+
+	firstLine := func(s string) string { return strings.SplitN(s, "\n", 2)[0] }
+	if len([]rune(firstLine(output))) >= len([]rune(firstLine(regular.String()))) {
+		t.Errorf("Compact table should be narrower:\n%s\n%s", regular.String(), output)
+	}
+}
+
 func TestPrintBillsTableEmpty(t *testing.T) {
 	resetListFlags()
 
@@ -207,7 +344,7 @@ func TestPrintBillsTableEmpty(t *testing.T) {
 	cmd.SetOut(buf)
 
 	formatter := format.NewAmountFormatter("en_US", "")
-	printBillsTable(cmd, nil, formatter)
+	printBillsTable(cmd.OutOrStdout(), nil, formatter, nil)
 
 	output := buf.String()
 	if !bytes.Contains([]byte(output), []byte("No bills found")) {
@@ -223,7 +360,7 @@ func TestBuildFiltersNameFilter(t *testing.T) {
 	// Set name filter
 	listName = "grocery"
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -246,6 +383,146 @@ func TestBuildFiltersNameFilter(t *testing.T) {
 	resetListFlags()
 }
 
+func TestBuildFiltersMine(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		ID: "test-project",
+		Members: []api.Member{
+			{ID: 1, Name: "Me", UserID: "me", Activated: true},
+			{ID: 2, Name: "Alice", UserID: "alice", Activated: true},
+		},
+	}
+
+	listMine = true
+	listPaidBy = "alice"
+
+	filters, err := buildFilters(project, "me", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+
+	bills := []api.BillResponse{
+		{ID: 1, PayerID: 1, Owers: []api.Ower{{ID: 2}}},
+		{ID: 2, PayerID: 2, Owers: []api.Ower{{ID: 1}, {ID: 2}}},
+		{ID: 3, PayerID: 2, Owers: []api.Ower{{ID: 2}}},
+	}
+
+	// --mine composes with --by alice: only bill 2 involves both
+	got := applyFilters(bills, filters)
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("applyFilters() = %+v, want only bill 2", got)
+	}
+
+	listPaidBy = ""
+	filters, err = buildFilters(project, "me", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+	if got := applyFilters(bills, filters); len(got) != 2 {
+		t.Errorf("applyFilters() returned %d bills, want 2", len(got))
+	}
+
+	_, err = buildFilters(project, "stranger", time.Local)
+	if err == nil || !strings.Contains(err.Error(), "stranger is not a member of project test-project") {
+		t.Errorf("buildFilters() error = %v, want not a member", err)
+	}
+}
+
+func TestBuildFiltersInvolves(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		ID: "test-project",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice", Activated: true},
+			{ID: 2, Name: "Bob", UserID: "bob", Activated: true},
+			{ID: 3, Name: "Carol", UserID: "carol", Activated: true},
+		},
+	}
+
+	bills := []api.BillResponse{
+		{ID: 1, PayerID: 1, Owers: []api.Ower{{ID: 3}}},
+		{ID: 2, PayerID: 3, Owers: []api.Ower{{ID: 1}, {ID: 3}}},
+		{ID: 3, PayerID: 3, Owers: []api.Ower{{ID: 2}}},
+		{ID: 4, PayerID: 3, Owers: []api.Ower{{ID: 3}}},
+	}
+
+	tests := []struct {
+		involves []string
+		want     []int
+	}{
+		{[]string{"alice"}, []int{1, 2}},
+		{[]string{"alice", "bob"}, []int{1, 2, 3}},
+		{[]string{"carol"}, []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		listInvolves = tt.involves
+		filters, err := buildFilters(project, "", time.Local)
+		if err != nil {
+			t.Fatalf("buildFilters() error = %v", err)
+		}
+		var ids []int
+		for _, bill := range applyFilters(bills, filters) {
+			ids = append(ids, bill.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Errorf("--involves %v matched %v, want %v", tt.involves, ids, tt.want)
+		}
+	}
+
+	listInvolves = []string{"dave"}
+	if _, err := buildFilters(project, "", time.Local); err == nil {
+		t.Error("Expected an error for an unknown member")
+	}
+}
+
+func TestBuildFiltersAmountOriginal(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		Currencies: []api.Currency{{ID: 2, Name: "EUR", ExchangeRate: 1.14}},
+	}
+	bills := []api.BillResponse{
+		// The €50 dinner, stored as $57
+		{ID: 1, Amount: 57, OriginalCurrencyID: 2},
+		{ID: 2, Amount: 57},
+		// A currency that was since removed keeps the stored amount
+		{ID: 3, Amount: 45, OriginalCurrencyID: 9},
+	}
+
+	tests := []struct {
+		filter string
+		want   []int
+	}{
+		{"50", []int{1}},
+		{">50", []int{2}},
+		{"<=50", []int{1, 3}},
+	}
+	for _, tt := range tests {
+		listAmountOrig = tt.filter
+		filters, err := buildFilters(project, "", time.Local)
+		if err != nil {
+			t.Fatalf("buildFilters() error = %v", err)
+		}
+		var ids []int
+		for _, bill := range applyFilters(bills, filters) {
+			ids = append(ids, bill.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Errorf("--amount-original %s matched %v, want %v", tt.filter, ids, tt.want)
+		}
+	}
+
+	listAmountOrig = "abc"
+	if _, err := buildFilters(project, "", time.Local); err == nil {
+		t.Error("Expected an error for an invalid amount")
+	}
+}
+
 func TestBuildFiltersAmountFilter(t *testing.T) {
 	resetListFlags()
 
@@ -254,7 +531,7 @@ func TestBuildFiltersAmountFilter(t *testing.T) {
 	// Set amount filter
 	listAmount = ">50"
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -295,9 +572,9 @@ func TestParseDateFilter(t *testing.T) {
 		{"full date lte", "<=2026-12-31", "<=", "2026-12-31", false},
 		{"full date gt", ">2026-06-15", ">", "2026-06-15", false},
 		{"full date lt", "<2026-03-01", "<", "2026-03-01", false},
-		{"short date", "01-15", "=", fmt.Sprintf("%d-01-15", time.Now().Year()), false},
-		{"short date gte", ">=01-01", ">=", fmt.Sprintf("%d-01-01", time.Now().Year()), false},
-		{"short date lte", "<=12-31", "<=", fmt.Sprintf("%d-12-31", time.Now().Year()), false},
+		{"short date", "01-15", "=", "2026-01-15", false},
+		{"short date gte", ">=01-01", ">=", "2026-01-01", false},
+		{"short date lte", "<=12-31", "<=", "2026-12-31", false},
 		{"with spaces", " >= 2026-01-01 ", ">=", "2026-01-01", false},
 		{"invalid date", "not-a-date", "", "", true},
 		{"invalid short", "13-40", "", "", true},
@@ -305,7 +582,7 @@ func TestParseDateFilter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			df, err := parseDateFilter(tt.input)
+			df, err := parseDateFilter(tt.input, time.Local)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseDateFilter() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -353,7 +630,7 @@ func TestMatchDate(t *testing.T) {
 }
 
 func TestParseRecent(t *testing.T) {
-	now := time.Now()
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
 
 	tests := []struct {
 		name    string
@@ -361,10 +638,10 @@ func TestParseRecent(t *testing.T) {
 		wantDay string
 		wantErr bool
 	}{
-		{"7 days", "7d", now.AddDate(0, 0, -7).Format("2006-01-02"), false},
-		{"2 weeks", "2w", now.AddDate(0, 0, -14).Format("2006-01-02"), false},
-		{"1 month", "1m", now.AddDate(0, -1, 0).Format("2006-01-02"), false},
-		{"3 months", "3m", now.AddDate(0, -3, 0).Format("2006-01-02"), false},
+		{"7 days", "7d", "2026-03-03", false},
+		{"2 weeks", "2w", "2026-02-24", false},
+		{"1 month", "1m", "2026-02-10", false},
+		{"3 months", "3m", "2025-12-10", false},
 		{"invalid unit", "7x", "", true},
 		{"invalid value", "abcd", "", true},
 		{"too short", "d", "", true},
@@ -372,7 +649,7 @@ func TestParseRecent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseRecent(tt.input)
+			got, err := parseRecent(tt.input, time.Local)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseRecent() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -391,10 +668,13 @@ func TestBuildFiltersToday(t *testing.T) {
 	resetListFlags()
 	defer resetListFlags()
 
+	// Just after midnight, so yesterday is only minutes ago
+	freezeNow(t, time.Date(2026, 3, 10, 0, 5, 0, 0, time.Local))
+
 	project := &api.Project{}
 	listToday = true
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -403,8 +683,8 @@ func TestBuildFiltersToday(t *testing.T) {
 		t.Fatalf("buildFilters() returned %d filters, want 1", len(filters))
 	}
 
-	today := time.Now().Format("2006-01-02")
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	today := "2026-03-10"
+	yesterday := "2026-03-09"
 
 	if !filters[0](api.BillResponse{Date: today}) {
 		t.Errorf("Filter should match today: %s", today)
@@ -421,7 +701,7 @@ func TestBuildFiltersDateFilter(t *testing.T) {
 	project := &api.Project{}
 	listDate = ">=2026-01-15"
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -445,14 +725,263 @@ func TestBuildFiltersDateFilter(t *testing.T) {
 	}
 }
 
+func TestBuildFiltersDateRange(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{}
+	freezeNow(t, time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local))
+
+	listFrom = "2026-01-01"
+	listTo = "03-31"
+
+	filters, err := buildFilters(project, "", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+	if len(filters) != 2 {
+		t.Fatalf("buildFilters() returned %d filters, want 2", len(filters))
+	}
+
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2025-12-31", false},
+		{"2026-01-01", true},
+		{"2026-03-31", true},
+		{"2026-04-01", false},
+	}
+	for _, tt := range tests {
+		got := len(applyFilters([]api.BillResponse{{Date: tt.date}}, filters)) == 1
+		if got != tt.want {
+			t.Errorf("date %s matched = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFiltersDateRangeConflicts(t *testing.T) {
+	conflicts := map[string]func(){
+		"--date":       func() { listDate = ">=2026-01-01" },
+		"--today":      func() { listToday = true },
+		"--this-month": func() { listThisMonth = true },
+		"--this-week":  func() { listThisWeek = true },
+		"--recent":     func() { listRecent = "7d" },
+	}
+	for name, set := range conflicts {
+		t.Run(name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+
+			listFrom = "2026-01-01"
+			set()
+			if _, err := buildFilters(&api.Project{}, "", time.Local); err == nil {
+				t.Errorf("Expected error combining --from with %s", name)
+			}
+		})
+	}
+
+	resetListFlags()
+	defer resetListFlags()
+	listTo = "not-a-date"
+	if _, err := buildFilters(&api.Project{}, "", time.Local); err == nil {
+		t.Error("Expected error for invalid --to date")
+	}
+}
+
+func TestValidBillDate(t *testing.T) {
+	for _, s := range []string{"2026-01-15", "2024-02-29"} {
+		if !validBillDate(s) {
+			t.Errorf("validBillDate(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"", "2026-1-5", "2026-13-01", "2025-02-29", "garbage", "01-15"} {
+		if validBillDate(s) {
+			t.Errorf("validBillDate(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestDateFiltersExcludeEmptyDates(t *testing.T) {
+	setters := map[string]func(){
+		"--date <=":    func() { listDate = "<=2026-12-31" },
+		"--date <":     func() { listDate = "<2026-12-31" },
+		"--to":         func() { listTo = "2026-12-31" },
+		"--from":       func() { listFrom = "2000-01-01" },
+		"--recent":     func() { listRecent = "1m" },
+		"--this-week":  func() { listThisWeek = true },
+		"--this-month": func() { listThisMonth = true },
+		"--today":      func() { listToday = true },
+		"--year":       func() { listYear = 2026 },
+	}
+
+	bills := []api.BillResponse{
+		{ID: 1, Date: ""},
+		{ID: 2, Date: "2026-xx-yy"},
+	}
+	for name, set := range setters {
+		t.Run(name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+
+			set()
+			filters, err := buildFilters(&api.Project{}, "", time.Local)
+			if err != nil {
+				t.Fatalf("buildFilters() error = %v", err)
+			}
+			if got := applyFilters(bills, filters); len(got) != 0 {
+				t.Errorf("Bills with invalid dates should be excluded, got %v", got)
+			}
+		})
+	}
+}
+
+func TestBuildFiltersWeekday(t *testing.T) {
+	// 2026-01-05 is a Monday
+	dates := map[string]string{
+		"mon": "2026-01-05",
+		"tue": "2026-01-06",
+		"wed": "2026-01-07",
+		"thu": "2026-01-08",
+		"fri": "2026-01-09",
+		"sat": "2026-01-10",
+		"sun": "2026-01-11",
+	}
+
+	for day := range dates {
+		t.Run(day, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+
+			listWeekday = strings.ToUpper(day)
+			filters, err := buildFilters(&api.Project{}, "", time.Local)
+			if err != nil {
+				t.Fatalf("buildFilters() error = %v", err)
+			}
+			for other, otherDate := range dates {
+				got := filters[0](api.BillResponse{Date: otherDate})
+				if got != (other == day) {
+					t.Errorf("--weekday %s on %s (%s) = %v", day, otherDate, other, got)
+				}
+			}
+			if filters[0](api.BillResponse{Date: "not-a-date"}) {
+				t.Error("Malformed date should not match")
+			}
+		})
+	}
+}
+
+func TestBuildFiltersWeekends(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	listWeekends = true
+	filters, err := buildFilters(&api.Project{}, "", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+	if !filters[0](api.BillResponse{Date: "2026-01-10"}) || !filters[0](api.BillResponse{Date: "2026-01-11"}) {
+		t.Error("--weekends should match Saturday and Sunday")
+	}
+	if filters[0](api.BillResponse{Date: "2026-01-09"}) {
+		t.Error("--weekends should not match Friday")
+	}
+
+	listWeekday = "mon"
+	if _, err := buildFilters(&api.Project{}, "", time.Local); err == nil {
+		t.Error("Expected error combining --weekday and --weekends")
+	}
+}
+
+func TestBuildFiltersRecurring(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	bills := []api.BillResponse{
+		{ID: 1, Repeat: "m"},
+		{ID: 2, Repeat: "n"},
+		{ID: 3, Repeat: ""},
+		{ID: 4, Repeat: "w"},
+	}
+
+	listRecurring = true
+	filters, err := buildFilters(&api.Project{}, "", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+	if got := applyFilters(bills, filters); len(got) != 2 || got[0].ID != 1 || got[1].ID != 4 {
+		t.Errorf("--recurring = %v, want bills 1 and 4", got)
+	}
+
+	listRecurring = false
+	listOneTime = true
+	filters, err = buildFilters(&api.Project{}, "", time.Local)
+	if err != nil {
+		t.Fatalf("buildFilters() error = %v", err)
+	}
+	if got := applyFilters(bills, filters); len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Errorf("--one-time = %v, want bills 2 and 3", got)
+	}
+
+	listRecurring = true
+	if _, err := buildFilters(&api.Project{}, "", time.Local); err == nil {
+		t.Error("Expected error combining --recurring and --one-time")
+	}
+}
+
+func TestBuildFiltersTodayTimezone(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	// These zones are 25 hours apart, so it's never the same day in both
+	ahead, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	behind, err := time.LoadLocation("Pacific/Pago_Pago")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	bill := api.BillResponse{Date: time.Now().In(ahead).Format("2006-01-02")}
+
+	listToday = true
+	for loc, want := range map[*time.Location]bool{ahead: true, behind: false} {
+		filters, err := buildFilters(&api.Project{}, "", loc)
+		if err != nil {
+			t.Fatalf("buildFilters() error = %v", err)
+		}
+		if got := filters[0](bill); got != want {
+			t.Errorf("--today in %s on %s = %v, want %v", loc, bill.Date, got, want)
+		}
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	days, err := parseWeekdays("sat, Sunday")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(days) != 2 || !days[time.Saturday] || !days[time.Sunday] {
+		t.Errorf("parseWeekdays() = %v", days)
+	}
+
+	for _, bad := range []string{"funday", "sat,xyz", ","} {
+		if _, err := parseWeekdays(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
 func TestBuildFiltersThisMonth(t *testing.T) {
 	resetListFlags()
 	defer resetListFlags()
 
+	freezeNow(t, time.Date(2026, 3, 1, 0, 5, 0, 0, time.Local))
+
 	project := &api.Project{}
 	listThisMonth = true
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -461,9 +990,8 @@ func TestBuildFiltersThisMonth(t *testing.T) {
 		t.Fatalf("buildFilters() returned %d filters, want 1", len(filters))
 	}
 
-	now := time.Now()
-	thisMonth := now.Format("2006-01-02")
-	lastMonth := now.AddDate(0, -1, 0).Format("2006-01-02")
+	thisMonth := "2026-03-01"
+	lastMonth := "2026-02-28"
 
 	if !filters[0](api.BillResponse{Date: thisMonth}) {
 		t.Errorf("Filter should match date in current month: %s", thisMonth)
@@ -477,10 +1005,12 @@ func TestBuildFiltersThisWeek(t *testing.T) {
 	resetListFlags()
 	defer resetListFlags()
 
+	freezeNow(t, time.Date(2026, 3, 11, 12, 0, 0, 0, time.Local))
+
 	project := &api.Project{}
 	listThisWeek = true
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -489,12 +1019,21 @@ func TestBuildFiltersThisWeek(t *testing.T) {
 		t.Fatalf("buildFilters() returned %d filters, want 1", len(filters))
 	}
 
-	today := time.Now().Format("2006-01-02")
-	twoWeeksAgo := time.Now().AddDate(0, 0, -14).Format("2006-01-02")
+	// 2026-03-11 is a Wednesday; its week starts on Monday the 9th
+	today := "2026-03-11"
+	monday := "2026-03-09"
+	lastSunday := "2026-03-08"
+	twoWeeksAgo := "2026-02-25"
 
 	if !filters[0](api.BillResponse{Date: today}) {
 		t.Errorf("Filter should match today: %s", today)
 	}
+	if !filters[0](api.BillResponse{Date: monday}) {
+		t.Errorf("Filter should match Monday: %s", monday)
+	}
+	if filters[0](api.BillResponse{Date: lastSunday}) {
+		t.Errorf("Filter should not match last Sunday: %s", lastSunday)
+	}
 	if filters[0](api.BillResponse{Date: twoWeeksAgo}) {
 		t.Errorf("Filter should not match two weeks ago: %s", twoWeeksAgo)
 	}
@@ -504,10 +1043,12 @@ func TestBuildFiltersRecent(t *testing.T) {
 	resetListFlags()
 	defer resetListFlags()
 
+	freezeNow(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
 	project := &api.Project{}
 	listRecent = "7d"
 
-	filters, err := buildFilters(project)
+	filters, err := buildFilters(project, "", time.Local)
 	if err != nil {
 		t.Fatalf("buildFilters() error = %v", err)
 	}
@@ -516,9 +1057,9 @@ func TestBuildFiltersRecent(t *testing.T) {
 		t.Fatalf("buildFilters() returned %d filters, want 1", len(filters))
 	}
 
-	today := time.Now().Format("2006-01-02")
-	threeDaysAgo := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
-	tenDaysAgo := time.Now().AddDate(0, 0, -10).Format("2006-01-02")
+	today := "2026-03-10"
+	threeDaysAgo := "2026-03-07"
+	tenDaysAgo := "2026-02-28"
 
 	if !filters[0](api.BillResponse{Date: today}) {
 		t.Error("Filter should match today")
@@ -557,6 +1098,7 @@ func TestPrintBillsCSV(t *testing.T) {
 			Owers:         []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}},
 			CategoryID:    1,
 			PaymentModeID: 1,
+			Comment:       "Weekly shop",
 		},
 		{
 			ID:      2,
@@ -574,7 +1116,7 @@ func TestPrintBillsCSV(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 
-	printBillsCSV(cmd, resolved)
+	printBillsCSV(cmd.OutOrStdout(), resolved, ',')
 
 	output := buf.String()
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -582,30 +1124,126 @@ func TestPrintBillsCSV(t *testing.T) {
 	if len(lines) != 3 {
 		t.Fatalf("Expected 3 lines (header + 2 rows), got %d:\n%s", len(lines), output)
 	}
-	if lines[0] != "ID,Date,Name,Amount,Paid By,Paid For,Category,Payment Method" {
+	if lines[0] != "ID,Date,Name,Amount,Paid By,Paid For,Category,Payment Method,Comment" {
 		t.Errorf("Wrong CSV header: %s", lines[0])
 	}
 	if !strings.Contains(lines[1], "Coffee") {
 		t.Errorf("First data row should contain 'Coffee' (newest first), got: %s", lines[1])
 	}
-	if !strings.Contains(lines[2], "Groceries") {
-		t.Errorf("Second data row should contain 'Groceries', got: %s", lines[2])
+	if !strings.Contains(lines[2], "Groceries") || !strings.HasSuffix(lines[2], ",Weekly shop") {
+		t.Errorf("Second data row should contain 'Groceries' and end with its comment, got: %s", lines[2])
 	}
 }
 
-func TestPrintBillsJSON(t *testing.T) {
+func TestResolveBillNamesSameTimestampOrder(t *testing.T) {
 	resetListFlags()
 
-	project := &api.Project{
-		Members: []api.Member{
-			{ID: 1, Name: "Alice", UserID: "alice"},
-		},
-		Categories: []api.Category{
-			{ID: 1, Name: "Food"},
-		},
-		PaymentModes: []api.PaymentMode{
-			{ID: 1, Name: "Cash"},
-		},
+	// Same date and timestamp: the higher (newer) ID comes first, regardless of input order
+	for _, ids := range [][]int{{1, 2, 3}, {3, 1, 2}, {2, 3, 1}} {
+		var bills []api.BillResponse
+		for _, id := range ids {
+			bills = append(bills, api.BillResponse{ID: id, Date: "2026-02-03", Timestamp: 1770000000})
+		}
+		resolved := resolveBillNames(&api.Project{}, bills)
+		for i, want := range []int{3, 2, 1} {
+			if resolved[i].ID != want {
+				t.Errorf("input %v: position %d has ID %d, want %d", ids, i, resolved[i].ID, want)
+			}
+		}
+	}
+}
+
+func TestPrintBillsTSV(t *testing.T) {
+	resetListFlags()
+
+	bills := []resolvedBill{
+		{ID: 1, Date: "2026-02-03", Name: "Dinner, drinks", Amount: 1234.5, PaidBy: "Alice", PaidFor: []string{"Alice", "Bob"}},
+	}
+
+	buf := new(bytes.Buffer)
+	printBillsCSV(buf, bills, '\t')
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines (header + 1 row), got %d:\n%s", len(lines), buf.String())
+	}
+	if lines[0] != "ID\tDate\tName\tAmount\tPaid By\tPaid For\tCategory\tPayment Method\tComment" {
+		t.Errorf("Wrong TSV header: %q", lines[0])
+	}
+	if lines[1] != "1\t2026-02-03\tDinner, drinks\t1234.50\tAlice\tAlice, Bob\t\t\t" {
+		t.Errorf("Wrong TSV row: %q", lines[1])
+	}
+}
+
+func TestPrintBillsCSVNoHeader(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+	listNoHeader = true
+
+	bills := []resolvedBill{
+		{ID: 1, Date: "2026-02-03", Name: "Coffee", Amount: 5.5, PaidBy: "Alice", PaidFor: []string{"Alice"}},
+	}
+
+	buf := new(bytes.Buffer)
+	printBillsCSV(buf, bills, ',')
+
+	if got := buf.String(); got != "1,2026-02-03,Coffee,5.50,Alice,Alice,,,\n" {
+		t.Errorf("Expected only the data row, got: %q", got)
+	}
+}
+
+func TestListNoHeaderRequiresCSV(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+	ProjectID = "test-project"
+
+	cmd := NewListCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--no-header", "--format", "json"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--no-header") {
+		t.Errorf("Expected --no-header error, got: %v", err)
+	}
+}
+
+func TestPrintBillsTotal(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	bills := []resolvedBill{
+		{ID: 1, Amount: 20.10},
+		{ID: 2, Amount: 5.20},
+	}
+	formatter := format.NewAmountFormatter("en_US", "USD")
+
+	buf := new(bytes.Buffer)
+	printBillsTotal(buf, bills, formatter)
+	if got, want := buf.String(), formatter.Format(25.30)+"\n"; got != want {
+		t.Errorf("printBillsTotal() = %q, want %q", got, want)
+	}
+
+	listFormat = "json"
+	buf.Reset()
+	printBillsTotal(buf, bills, formatter)
+	if got := buf.String(); got != `{"count":2,"total":25.3}`+"\n" {
+		t.Errorf("printBillsTotal() JSON = %q", got)
+	}
+}
+
+func TestPrintBillsJSON(t *testing.T) {
+	resetListFlags()
+
+	project := &api.Project{
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice"},
+		},
+		Categories: []api.Category{
+			{ID: 1, Name: "Food"},
+		},
+		PaymentModes: []api.PaymentMode{
+			{ID: 1, Name: "Cash"},
+		},
 	}
 
 	bills := []api.BillResponse{
@@ -627,7 +1265,7 @@ func TestPrintBillsJSON(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 
-	printBillsJSON(cmd, resolved)
+	printBillsJSON(cmd.OutOrStdout(), resolved)
 
 	var result []resolvedBill
 	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
@@ -654,6 +1292,274 @@ func TestPrintBillsJSON(t *testing.T) {
 	}
 }
 
+func TestMultilineNamePreservedOutsideTable(t *testing.T) {
+	resetListFlags()
+
+	bills := []api.BillResponse{{ID: 1, What: "Dinner\nwith\tfriends", Amount: 20, Date: "2026-02-03"}}
+	resolved := resolveBillNames(&api.Project{}, bills)
+
+	var jsonBuf bytes.Buffer
+	printBillsJSON(&jsonBuf, resolved)
+	var result []resolvedBill
+	if err := json.Unmarshal(jsonBuf.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, jsonBuf.String())
+	}
+	if len(result) != 1 || result[0].Name != "Dinner\nwith\tfriends" {
+		t.Errorf("JSON should keep the original name, got %q", result[0].Name)
+	}
+
+	var tableBuf bytes.Buffer
+	printBillsTable(&tableBuf, resolved, format.NewAmountFormatter("en_US", "USD"), nil)
+	if !strings.Contains(tableBuf.String(), "Dinner with friends") {
+		t.Errorf("Table should flatten the name, got:\n%s", tableBuf.String())
+	}
+}
+
+func TestSanitizeForTable(t *testing.T) {
+	if got := sanitizeForTable("a\r\nb\tc"); got != "a  b c" {
+		t.Errorf("sanitizeForTable() = %q, want %q", got, "a  b c")
+	}
+}
+
+func TestPrintBillsJSONWithFilters(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{Members: []api.Member{{ID: 1, Name: "Alice"}}}
+	bills := resolveBillNames(project, []api.BillResponse{
+		{ID: 1, What: "Groceries", Amount: 50, Date: "2026-02-03", PayerID: 1, Owers: []api.Ower{{ID: 1, Weight: 1}}},
+		{ID: 2, What: "Dinner <out>", Amount: 30, Date: "2026-02-04", PayerID: 1},
+	})
+	filters := []appliedFilter{{Name: "payer", Value: "Alice"}, {Name: "date", Value: []string{">=2026-01-01"}}}
+
+	// The streamed output matches encoding the whole report at once
+	encode := func(bills []resolvedBill) string {
+		report := struct {
+			Filters map[string]any `json:"filters"`
+			Bills   []any          `json:"bills"`
+		}{Filters: map[string]any{}, Bills: []any{}}
+		for _, f := range filters {
+			report.Filters[f.Name] = f.Value
+		}
+		for _, bill := range bills {
+			report.Bills = append(report.Bills, bill)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+		return buf.String()
+	}
+
+	for _, tt := range [][]resolvedBill{bills, nil} {
+		var buf bytes.Buffer
+		printBillsJSONWithFilters(&buf, tt, filters)
+		if want := encode(tt); buf.String() != want {
+			t.Errorf("printBillsJSONWithFilters() =\n%s\nwant:\n%s", buf.String(), want)
+		}
+	}
+}
+
+func TestPrintBillsJSONRaw(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		Members:      []api.Member{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}},
+		Categories:   []api.Category{{ID: 3, Name: "Food"}},
+		PaymentModes: []api.PaymentMode{{ID: 4, Name: "Cash"}},
+	}
+	bills := []api.BillResponse{{
+		ID: 1, What: "Groceries", Amount: 50, Date: "2026-02-03", PayerID: 1,
+		Owers: []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 2}}, CategoryID: 3, PaymentModeID: 4,
+	}}
+	resolved := resolveBillNames(project, bills)
+
+	var lean bytes.Buffer
+	printBillsJSON(&lean, resolved)
+	if strings.Contains(lean.String(), "payer_id") {
+		t.Errorf("Default JSON should not include IDs, got:\n%s", lean.String())
+	}
+
+	listRaw = true
+	var buf bytes.Buffer
+	printBillsJSON(&buf, resolved)
+
+	var result []struct {
+		Name          string     `json:"name"`
+		PaidBy        string     `json:"paid_by"`
+		PayerID       int        `json:"payer_id"`
+		Owers         []api.Ower `json:"owers"`
+		CategoryID    int        `json:"category_id"`
+		PaymentModeID int        `json:"payment_mode_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 bill, got %d", len(result))
+	}
+	got := result[0]
+	if got.Name != "Groceries" || got.PaidBy != "Alice" {
+		t.Errorf("Resolved names missing: %+v", got)
+	}
+	if got.PayerID != 1 || got.CategoryID != 3 || got.PaymentModeID != 4 {
+		t.Errorf("Wrong IDs: %+v", got)
+	}
+	if len(got.Owers) != 2 || got.Owers[1].ID != 2 || got.Owers[1].Weight != 2 {
+		t.Errorf("Wrong owers: %+v", got.Owers)
+	}
+}
+
+func TestPrintBillsJSONComment(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	bills := []api.BillResponse{
+		{ID: 1, What: "Dinner", Date: "2026-02-03", Comment: "Anna's birthday"},
+		{ID: 2, What: "Coffee", Date: "2026-02-02"},
+	}
+	resolved := resolveBillNames(&api.Project{}, bills)
+
+	var buf bytes.Buffer
+	printBillsJSON(&buf, resolved)
+
+	var result []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 bills, got %d", len(result))
+	}
+	if result[0]["comment"] != "Anna's birthday" {
+		t.Errorf("comment = %v, want the bill's comment", result[0]["comment"])
+	}
+	// The key is always present, so consumers don't have to check for it
+	if comment, ok := result[1]["comment"]; !ok || comment != "" {
+		t.Errorf("comment = %v (present: %v), want an empty string", comment, ok)
+	}
+}
+
+func TestPrintBillsNDJSON(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{Members: []api.Member{{ID: 1, Name: "Alice"}}}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-02-02", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+		{ID: 2, What: "Dinner", Amount: 45, Date: "2026-02-03", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+	}
+	resolved := resolveBillNames(project, bills)
+
+	var buf bytes.Buffer
+	printBillsNDJSON(&buf, resolved)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per bill, got:\n%s", buf.String())
+	}
+	for i, want := range []string{"Dinner", "Lunch"} {
+		var bill resolvedBill
+		if err := json.Unmarshal([]byte(lines[i]), &bill); err != nil {
+			t.Fatalf("Line %d isn't a JSON object: %v\n%s", i+1, err, lines[i])
+		}
+		if bill.Name != want || bill.PaidBy != "Alice" {
+			t.Errorf("Line %d = %+v, want %s paid by Alice", i+1, bill, want)
+		}
+	}
+
+	// --raw adds the IDs to each line
+	listRaw = true
+	buf.Reset()
+	printBillsNDJSON(&buf, resolved)
+	if !strings.Contains(buf.String(), `"payer_id":1`) {
+		t.Errorf("Expected IDs with --raw, got:\n%s", buf.String())
+	}
+
+	// No bills prints nothing, which is an empty stream
+	buf.Reset()
+	printBillsNDJSON(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for no bills, got %q", buf.String())
+	}
+}
+
+func TestPrintBillsJSONFields(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{Members: []api.Member{{ID: 1, Name: "Alice"}}}
+	bills := []api.BillResponse{{ID: 7, What: "Lunch", Amount: 20, Date: "2026-02-02", PayerID: 1, Owers: []api.Ower{{ID: 1}}}}
+	resolved := resolveBillNames(project, bills)
+
+	listFields = []string{"id", "amount", "original_amount"}
+	var buf bytes.Buffer
+	printBillsNDJSON(&buf, resolved)
+	if got := strings.TrimSpace(buf.String()); got != `{"id":7,"amount":20,"original_amount":null}` {
+		t.Errorf("Selected fields = %s, want them in the given order", got)
+	}
+
+	// The indented output keeps the order too, and repeated fields appear once
+	listFields = []string{"name", "id", "name"}
+	buf.Reset()
+	printBillsJSON(&buf, resolved)
+	if want := "[\n  {\n    \"name\": \"Lunch\",\n    \"id\": 7\n  }\n]\n"; buf.String() != want {
+		t.Errorf("Selected fields = %q, want %q", buf.String(), want)
+	}
+
+	listRaw = true
+	listFields = []string{"name", "payer_id"}
+	buf.Reset()
+	printBillsJSON(&buf, resolved)
+	var result []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(result) != 1 || len(result[0]) != 2 || result[0]["name"] != "Lunch" || result[0]["payer_id"] != float64(1) {
+		t.Errorf("Selected fields = %v", result)
+	}
+}
+
+func TestCheckFields(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	if err := checkFields([]string{"id", "name", "comment"}); err != nil {
+		t.Errorf("checkFields() error = %v", err)
+	}
+	if err := checkFields([]string{"nmae"}); err == nil || !strings.Contains(err.Error(), "unknown field: nmae") {
+		t.Errorf("Expected an unknown field error, got %v", err)
+	}
+	if err := checkFields([]string{"payer_id"}); err == nil || !strings.Contains(err.Error(), "requires --raw") {
+		t.Errorf("Expected a --raw error, got %v", err)
+	}
+
+	// The known fields are exactly the JSON keys of a bill
+	var keys []string
+	for _, typ := range []reflect.Type{reflect.TypeOf(resolvedBill{}), reflect.TypeOf(billIDs{})} {
+		for i := 0; i < typ.NumField(); i++ {
+			if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+				keys = append(keys, name)
+			}
+		}
+	}
+	if want := append(slices.Clone(billFields), rawBillFields...); !slices.Equal(keys, want) {
+		t.Errorf("JSON keys = %v, want billFields and rawBillFields %v", keys, want)
+	}
+}
+
+func TestListRawRequiresJSON(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	ProjectID = "myproject"
+	cmd := NewListCommand()
+	cmd.SetArgs([]string{"--raw"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--raw") {
+		t.Errorf("Expected --raw error for table format, got %v", err)
+	}
+}
+
 func TestPrintBillsJSONEmpty(t *testing.T) {
 	resetListFlags()
 
@@ -661,7 +1567,7 @@ func TestPrintBillsJSONEmpty(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmd.SetOut(buf)
 
-	printBillsJSON(cmd, nil)
+	printBillsJSON(cmd.OutOrStdout(), nil)
 
 	var result []resolvedBill
 	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
@@ -674,9 +1580,12 @@ func TestPrintBillsJSONEmpty(t *testing.T) {
 
 func resetListFlags() {
 	ProjectID = ""
+	listSumBy = ""
+	listNew = false
 	listPaidBy = ""
 	listPaidFor = nil
 	listAmount = ""
+	listAmountOrig = ""
 	listName = ""
 	listPaymentMethod = ""
 	listCategory = ""
@@ -686,5 +1595,1003 @@ func resetListFlags() {
 	listThisMonth = false
 	listThisWeek = false
 	listRecent = ""
+	listYear = 0
+	listFrom = ""
+	listTo = ""
+	listWeekday = ""
+	listWeekends = false
+	listMine = false
+	listInvolves = nil
+	listRecurring = false
+	listOneTime = false
+	listShowRepeat = false
+	listShowFilters = false
+	listCompact = false
 	listFormat = "table"
+	listIn = ""
+	listShowOriginal = false
+	listOutput = ""
+	listWatch = 0
+	listNoHeader = false
+	listTotalOnly = false
+	listShowIcons = false
+	listRelativeDates = false
+	listPerPerson = false
+	listRaw = false
+	listFields = nil
+	listTemplate = ""
+}
+
+func TestConvertBills(t *testing.T) {
+	bills := []resolvedBill{
+		{ID: 1, Amount: 110},
+		{ID: 2, Amount: 55},
+	}
+
+	t.Run("converts amounts", func(t *testing.T) {
+		result := convertBills(bills, 1.1, false)
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 bills, got %d", len(result))
+		}
+		if diff := result[0].Amount - 100; diff > 0.0001 || diff < -0.0001 {
+			t.Errorf("Amount = %f, want 100", result[0].Amount)
+		}
+		if result[0].OriginalAmount != nil {
+			t.Error("OriginalAmount should be nil when not requested")
+		}
+		if bills[0].Amount != 110 {
+			t.Error("convertBills should not modify the input slice")
+		}
+	})
+
+	t.Run("keeps original", func(t *testing.T) {
+		result := convertBills(bills, 1.1, true)
+		if result[1].OriginalAmount == nil || *result[1].OriginalAmount != 55 {
+			t.Errorf("OriginalAmount = %v, want 55", result[1].OriginalAmount)
+		}
+	})
+}
+
+func TestResolveDisplayCurrency(t *testing.T) {
+	project := &api.Project{
+		CurrencyName: "USD",
+		Currencies: []api.Currency{
+			{ID: 1, Name: "€", ExchangeRate: 1.1},
+			{ID: 2, Name: "Points", ExchangeRate: 0},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		wantRate float64
+		wantErr  bool
+	}{
+		{"by code", "eur", 1.1, false},
+		{"by ID", "1", 1.1, false},
+		{"main currency", "usd", 1, false},
+		{"no exchange rate", "points", 0, true},
+		{"unknown", "gbp", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency, err := resolveDisplayCurrency(project, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveDisplayCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && currency.ExchangeRate != tt.wantRate {
+				t.Errorf("ExchangeRate = %f, want %f", currency.ExchangeRate, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestPrintBillsTableShowOriginal(t *testing.T) {
+	resetListFlags()
+
+	original := 110.0
+	bills := []resolvedBill{
+		{ID: 1, Date: "2026-02-03", Name: "Hotel", Amount: 100, OriginalAmount: &original, PaidBy: "Alice"},
+	}
+
+	cmd := NewListCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	printBillsTable(cmd.OutOrStdout(), bills, format.NewAmountFormatter("en_US", "EUR"), format.NewAmountFormatter("en_US", "USD"))
+
+	output := buf.String()
+	if !strings.Contains(output, "ORIGINAL") {
+		t.Errorf("Output should contain ORIGINAL column, got:\n%s", output)
+	}
+	if !strings.Contains(output, "€ 100.00") {
+		t.Errorf("Output should contain converted amount, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Total: 1 bill(s), € 100.00 (original: $ 110.00)") {
+		t.Errorf("Output should contain converted and original totals, got:\n%s", output)
+	}
+}
+
+func TestListShowOriginalRequiresIn(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	cmd.SetArgs([]string{"--show-original"})
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetOut(new(bytes.Buffer))
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--in") {
+		t.Errorf("Expected error about --in, got: %v", err)
+	}
+}
+
+func TestListOutputFile(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetListFlags()
+	defer resetListFlags()
+
+	path := filepath.Join(t.TempDir(), "nested", "dir", "expenses.csv")
+
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"--format", "csv", "-O", path})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(data), "1,2026-01-10,Lunch,20.00,Alice,Alice") {
+		t.Errorf("Unexpected file contents:\n%s", data)
+	}
+	if got := buf.String(); got != "Wrote 1 bill(s) to "+path+"\n" {
+		t.Errorf("Unexpected stdout: %q", got)
+	}
+
+	// A failed write is an error, not a success message
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full not available")
+	}
+	resetListFlags()
+	ProjectID = "test-project"
+	cmd = NewListCommand()
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--format", "csv", "-O", "/dev/full"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "writing /dev/full") {
+		t.Errorf("Execute() error = %v, want a write error", err)
+	}
+	if strings.Contains(buf.String(), "Wrote") {
+		t.Errorf("Unexpected success message: %q", buf.String())
+	}
+}
+
+func TestListShowFilters(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}, {ID: 2, Name: "Bob", UserID: "bob"}},
+		Categories:   []api.Category{{ID: 3, Name: "Food"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1}}, CategoryID: 3},
+		{ID: 2, What: "Taxi", Amount: 15, Date: "2026-01-11", PayerID: 2, Owers: []api.Ower{{ID: 2}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	run := func(args ...string) string {
+		t.Helper()
+		resetListFlags()
+		ProjectID = "test-project"
+		cmd := NewListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+		return buf.String()
+	}
+	defer resetListFlags()
+
+	output := run("--show-filters", "-c", "food", "--date", ">=2026-01-01", "-b", "alice", "--for", "alice", "--mine=false")
+	if !strings.HasPrefix(output, "Filters: by=alice, for=alice, category=food, date>=2026-01-01\n") {
+		t.Errorf("Expected the filters header, got:\n%s", output)
+	}
+
+	output = run("--show-filters")
+	if !strings.HasPrefix(output, "Filters: none\n") {
+		t.Errorf("Expected no filters, got:\n%s", output)
+	}
+
+	output = run("--show-filters", "--format", "json", "--for", "alice", "--today")
+	var report struct {
+		Filters map[string]any   `json:"filters"`
+		Bills   []map[string]any `json:"bills"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, output)
+	}
+	if fmt.Sprint(report.Filters["for"]) != "[alice]" || report.Filters["today"] != true {
+		t.Errorf("Filters = %v", report.Filters)
+	}
+	if report.Bills == nil {
+		t.Errorf("Bills should be an empty list, got:\n%s", output)
+	}
+
+	// Without the flag, the JSON output is still a bare list
+	if output := run("--format", "json"); !strings.HasPrefix(output, "[") {
+		t.Errorf("Expected a JSON array, got:\n%s", output)
+	}
+}
+
+func TestShowFiltersValidation(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	for _, args := range [][]string{
+		{"--show-filters", "--format", "csv"},
+		{"--show-filters", "--template", "{{.Name}}"},
+		{"--show-filters", "--format", "json", "--total-only"},
+	} {
+		resetListFlags()
+		ProjectID = "myproject"
+		cmd := NewListCommand()
+		cmd.SetArgs(args)
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--show-filters") {
+			t.Errorf("%v: expected a --show-filters error, got %v", args, err)
+		}
+	}
+}
+
+func TestListArchivedProject(t *testing.T) {
+	archivedTS := int64(1700000000)
+	project := api.Project{ID: "old", Name: "Old Trip", CurrencyName: "USD", Members: []api.Member{{ID: 1, Name: "Alice"}}, ArchivedTS: &archivedTS}
+	bills := []api.BillResponse{{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1}}
+
+	var projectRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/old":
+			projectRequests++
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/old/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	run := func(args ...string) string {
+		t.Helper()
+		resetListFlags()
+		ProjectID = "old"
+		cmd := NewListCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+		return buf.String()
+	}
+	defer resetListFlags()
+
+	output := run("--show-filters")
+	if !strings.HasPrefix(output, "Project: Old Trip (archived)\nFilters: none\n\n") {
+		t.Errorf("Expected the archived note above the filters, got:\n%s", output)
+	}
+
+	// Machine-readable output stays unchanged
+	if output := run("--format", "json"); !strings.HasPrefix(output, "[") {
+		t.Errorf("JSON output shouldn't carry the note, got:\n%s", output)
+	}
+
+	// The archived state comes with the cached project
+	if output := run(); !strings.Contains(output, "(archived)") || projectRequests != 1 {
+		t.Errorf("Expected the note from the cached project (%d project requests), got:\n%s", projectRequests, output)
+	}
+
+	// An active project gets no note
+	project.ArchivedTS = nil
+	if err := cache.Invalidate("old"); err != nil {
+		t.Fatal(err)
+	}
+	if output := run(); strings.Contains(output, "archived") {
+		t.Errorf("Active project shouldn't be noted as archived, got:\n%s", output)
+	}
+}
+
+func TestFilterFlagsExist(t *testing.T) {
+	cmd := NewListCommand()
+	for _, name := range filterFlags {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("filterFlags lists --%s, which list doesn't have", name)
+		}
+	}
+}
+
+func TestBillShares(t *testing.T) {
+	names := []string{"Alice", "Bob", "Charlie"}
+
+	tests := []struct {
+		name   string
+		amount float64
+		owers  []api.Ower
+		want   []billShare
+	}{
+		{
+			"weighted",
+			90,
+			[]api.Ower{{ID: 1, Weight: 2}, {ID: 2, Weight: 1}},
+			[]billShare{{"Alice", 60}, {"Bob", 30}},
+		},
+		{
+			"equal weights",
+			30,
+			[]api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}, {ID: 3, Weight: 1}},
+			[]billShare{{"Alice", 10}, {"Bob", 10}, {"Charlie", 10}},
+		},
+		{
+			"no weights",
+			50,
+			[]api.Ower{{ID: 1}, {ID: 2}},
+			[]billShare{{"Alice", 25}, {"Bob", 25}},
+		},
+		{
+			"one zero weight",
+			40,
+			[]api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 0}},
+			[]billShare{{"Alice", 40}, {"Bob", 0}},
+		},
+		{
+			"no owers",
+			40,
+			nil,
+			[]billShare{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := billShares(tt.amount, names[:len(tt.owers)], tt.owers)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("billShares() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPerPerson(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}, {ID: 2, Name: "Bob", UserID: "bob"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Dinner", Amount: 37.5, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1, Weight: 2}, {ID: 2, Weight: 1}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"table", []string{"--per-person"}, "Alice: $ 25.00, Bob: $ 12.50"},
+		{"json", []string{"--per-person", "--format", "json"}, `"shares": [
+      {
+        "member": "Alice",
+        "owes": 25
+      },
+      {
+        "member": "Bob",
+        "owes": 12.5
+      }
+    ]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+
+			ProjectID = "test-project"
+			cmd := NewListCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("Output missing %q:\n%s", tt.want, stdout.String())
+			}
+		})
+	}
+
+	resetListFlags()
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--per-person", "--format", "csv"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --per-person with csv")
+	}
+}
+
+func TestHumanizeDate(t *testing.T) {
+	now := time.Date(2026, 3, 15, 18, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2026-03-15", "today"},
+		{"2026-03-14", "yesterday"},
+		{"2026-03-16", "tomorrow"},
+		{"2026-03-12", "3 days ago"},
+		{"2026-03-09", "6 days ago"},
+		{"2026-03-08", "last week"},
+		{"2026-03-01", "2 weeks ago"},
+		{"2026-02-16", "3 weeks ago"},
+		{"2026-02-15", "last month"},
+		{"2026-01-16", "last month"},
+		{"2026-01-15", "2 months ago"},
+		{"2025-03-16", "11 months ago"},
+		{"2025-03-15", "last year"},
+		{"2023-01-01", "3 years ago"},
+		{"2026-03-20", "in 5 days"},
+		{"2026-03-22", "next week"},
+		{"2026-05-15", "in 2 months"},
+		{"2028-03-15", "in 2 years"},
+		{"not-a-date", "not-a-date"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeDate(tt.date, now); got != tt.want {
+			t.Errorf("humanizeDate(%q) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestListDateFormat(t *testing.T) {
+	project := api.Project{
+		ID:      "test-project",
+		Name:    "Test",
+		Members: []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-31", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	defer func() { DateFormat = "" }()
+
+	tests := []struct {
+		dateFormat string
+		args       []string
+		want       string
+	}{
+		{"", []string{"--format", "json"}, `"date": "2026-01-31"`},
+		// JSON stays ISO, so import can read it back
+		{"eu", []string{"--format", "json"}, `"date": "2026-01-31"`},
+		{"eu", []string{"--format", "ndjson"}, `"date":"2026-01-31"`},
+		{"eu", nil, "31/01/2026"},
+		{"us", []string{"--format", "csv"}, "1,01/31/2026,Lunch"},
+		{"Jan 2, 2006", nil, "Jan 31, 2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dateFormat, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+			DateFormat = tt.dateFormat
+
+			ProjectID = "test-project"
+			cmd := NewListCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("Output missing %q:\n%s", tt.want, stdout.String())
+			}
+		})
+	}
+
+	resetListFlags()
+	DateFormat = "european"
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid date format") {
+		t.Errorf("Execute() error = %v, want invalid date format", err)
+	}
+}
+
+func TestListSumBy(t *testing.T) {
+	project := api.Project{
+		ID:         "test-project",
+		Name:       "Test",
+		Members:    []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}, {ID: 2, Name: "Bob", UserID: "bob"}},
+		Categories: []api.Category{{ID: 1, Name: "Food"}, {ID: 2, Name: "Rent"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-31", PayerID: 1, Owers: []api.Ower{{ID: 1}}, CategoryID: 1},
+		{ID: 2, What: "Rent", Amount: 900, Date: "2026-02-01", PayerID: 2, Owers: []api.Ower{{ID: 1}, {ID: 2}}, CategoryID: 2},
+		{ID: 3, What: "Dinner", Amount: 35.5, Date: "2026-02-03", PayerID: 1, Owers: []api.Ower{{ID: 2}}, CategoryID: 1},
+		{ID: 4, What: "Snacks", Amount: 4.5, Date: "2026-02-04", PayerID: 2, Owers: []api.Ower{{ID: 2}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	defer func() { DateFormat = "" }()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"category table", []string{"--sum-by", "category"}, []string{"CATEGORY", "Rent", "900.00", "Food", "55.50", "-", "4.50"}},
+		{"payer csv", []string{"--sum-by", "payer", "--format", "csv"}, []string{"Paid By,Count,Total\nBob,2,904.50\nAlice,2,55.50\n"}},
+		{"month tsv", []string{"--sum-by", "month", "--format", "tsv", "--no-header"}, []string{"2026-01\t1\t20.00\n2026-02\t3\t940.00\n"}},
+		{"month table", []string{"--sum-by", "month"}, []string{"January 2026", "February 2026", "940.00"}},
+		{"category json", []string{"--sum-by", "category", "--format", "json"}, []string{`"name": "Food"`, `"total": 55.5`}},
+		{"filtered", []string{"--sum-by", "category", "--by", "alice"}, []string{"Food", "55.50"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+			// Months come from the bill dates, whatever the display format
+			DateFormat = "eu"
+
+			ProjectID = "test-project"
+			cmd := NewListCommand()
+			var stdout bytes.Buffer
+			cmd.SetOut(&stdout)
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, stdout.String())
+				}
+			}
+			if strings.Contains(stdout.String(), "Lunch") {
+				t.Errorf("Output should only contain totals:\n%s", stdout.String())
+			}
+		})
+	}
+
+	for _, args := range [][]string{
+		{"--sum-by", "weekday"},
+		{"--sum-by", "category", "--format", "html"},
+		{"--sum-by", "category", "--total-only"},
+		{"--sum-by", "payer", "--per-person"},
+	} {
+		resetListFlags()
+		ProjectID = "test-project"
+		cmd := NewListCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+	resetListFlags()
+}
+
+func TestListTemplate(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}, {ID: 2, Name: "Bob", UserID: "bob"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1}, {ID: 2}}},
+		{ID: 2, What: "Taxi", Amount: 1234.5, Date: "2026-01-11", PayerID: 2, Owers: []api.Ower{{ID: 2}}},
+	}
+
+	var fetched bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetListFlags()
+	defer resetListFlags()
+
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--template", `{{.Date}} {{.Name}} {{printf "%.2f" .Amount}} {{money .Amount}} {{join .PaidFor "+"}}`})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "2026-01-11 Taxi 1234.50 $ 1,234.50 Bob\n2026-01-10 Lunch 20.00 $ 20.00 Alice+Bob\n"
+	if stdout.String() != want {
+		t.Errorf("Unexpected output:\n%q\nwant:\n%q", stdout.String(), want)
+	}
+
+	// Invalid templates fail before anything is fetched
+	for _, text := range []string{"{{.Name", "{{.Nope}}", "{{upper .Name}}"} {
+		resetListFlags()
+		ProjectID = "test-project"
+		fetched = false
+
+		cmd := NewListCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"--template", text})
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid template") {
+			t.Errorf("Template %q: expected invalid template error, got %v", text, err)
+		}
+		if fetched {
+			t.Errorf("Template %q: API was called before the template was validated", text)
+		}
+	}
+}
+
+func TestListTemplateConflicts(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	for _, args := range [][]string{
+		{"--template", "{{.Name}}", "--format", "csv"},
+		{"--template", "{{.Name}}", "--total-only"},
+	} {
+		ProjectID = "myproject"
+		cmd := NewListCommand()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--template") {
+			t.Errorf("%v: expected --template error, got %v", args, err)
+		}
+		resetListFlags()
+	}
+}
+
+func TestMergeBills(t *testing.T) {
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 10},
+		{ID: 2, What: "Taxi", Amount: 20},
+		{ID: 3, What: "Hotel", Amount: 100},
+	}
+	update := &api.BillsUpdate{
+		Bills: []api.BillResponse{
+			{ID: 2, What: "Taxi", Amount: 25},
+			{ID: 4, What: "Museum", Amount: 15},
+		},
+		AllBillIDs: []int{1, 2, 4},
+	}
+
+	merged := mergeBills(bills, update)
+
+	want := []struct {
+		id     int
+		amount float64
+	}{{1, 10}, {2, 25}, {4, 15}}
+	if len(merged) != len(want) {
+		t.Fatalf("mergeBills() = %v, want %d bills", merged, len(want))
+	}
+	for i, w := range want {
+		if merged[i].ID != w.id || merged[i].Amount != w.amount {
+			t.Errorf("merged[%d] = #%d %v, want #%d %v", i, merged[i].ID, merged[i].Amount, w.id, w.amount)
+		}
+	}
+}
+
+func TestListWatchValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"csv format", []string{"--watch", "30s", "--format", "csv"}},
+		{"json format", []string{"--watch", "30s", "--format", "json"}},
+		{"with output", []string{"--watch", "30s", "-O", "out.txt"}},
+		{"interval too short", []string{"--watch", "1s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+
+			ProjectID = "test-project"
+			cmd := NewListCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+
+			if err := cmd.Execute(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestListWatchStopsOnCancel(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "Alice", UserID: "alice"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills, "allBillIds": []int{1}, "timestamp": 1000}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetListFlags()
+	defer resetListFlags()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	ProjectID = "test-project"
+	cmd := NewListCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"--watch", "30s"})
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("ExecuteContext() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Last refresh:", "every 30s", "Lunch", "Total: 1 bill(s)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestListNew(t *testing.T) {
+	project := api.Project{ID: "test-project", Name: "Test", Members: []api.Member{{ID: 1, Name: "Alice"}}}
+	lastSeen := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bills := []api.BillResponse{
+		{ID: 1, What: "Seen", Amount: 10, Date: "2026-02-27", PayerID: 1, Timestamp: lastSeen.Add(-time.Hour).Unix()},
+		{ID: 2, What: "Edited", Amount: 20, Date: "2026-02-28", PayerID: 1, Timestamp: lastSeen.Add(time.Minute).Unix()},
+		{ID: 3, What: "Added", Amount: 30, Date: "2026-03-01", PayerID: 1, Timestamp: lastSeen.Add(time.Hour).Unix()},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	run := func(args ...string) string {
+		t.Helper()
+		resetListFlags()
+		defer resetListFlags()
+
+		ProjectID = "test-project"
+		cmd := NewListCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		return stdout.String()
+	}
+
+	// Without a marker, every bill is new
+	if out := run("--new"); !strings.Contains(out, "Seen") || !strings.Contains(out, "Added") {
+		t.Errorf("First --new should list all bills:\n%s", out)
+	}
+
+	if err := cache.SaveLastSeen("test-project", lastSeen); err != nil {
+		t.Fatalf("SaveLastSeen() error = %v", err)
+	}
+	out := run("--new")
+	if strings.Contains(out, "Seen") || !strings.Contains(out, "Edited") || !strings.Contains(out, "Added") {
+		t.Errorf("--new should list only bills changed since the last view:\n%s", out)
+	}
+
+	// Listing moves the marker to the newest change on the server
+	if seen, ok := cache.LoadLastSeen("test-project"); !ok || seen.Unix() != bills[2].Timestamp {
+		t.Errorf("Marker = %v, %v, want the newest bill timestamp", seen, ok)
+	}
+	if out := run("--new"); strings.Contains(out, "Added") {
+		t.Errorf("Bills should no longer be new:\n%s", out)
+	}
+
+	// --no-cache leaves the marker alone
+	if err := cache.SaveLastSeen("test-project", lastSeen); err != nil {
+		t.Fatalf("SaveLastSeen() error = %v", err)
+	}
+	NoCache = true
+	defer func() { NoCache = false }()
+	run()
+	if seen, _ := cache.LoadLastSeen("test-project"); !seen.Equal(lastSeen) {
+		t.Errorf("--no-cache shouldn't move the marker, got %v", seen)
+	}
+}
+
+func TestPrintBillsJSONStreamsSameOutput(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+
+	project := &api.Project{
+		Members:    []api.Member{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}},
+		Categories: []api.Category{{ID: 3, Name: "Food & Drinks"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Groceries <bulk>", Amount: 50, Date: "2026-02-03", PayerID: 1, Owers: []api.Ower{{ID: 1}, {ID: 2}}, CategoryID: 3},
+		{ID: 2, What: "Taxi", Amount: 12.5, Date: "2026-02-04", PayerID: 2},
+		{ID: 3, What: "Rent", Amount: 900, Date: "2026-02-01", PayerID: 1, Owers: []api.Ower{{ID: 2, Weight: 2}}},
+	}
+	resolved := resolveBillNames(project, bills)
+
+	// Streaming bill by bill gives the same bytes as encoding the whole array
+	for _, raw := range []bool{false, true} {
+		listRaw = raw
+		var want bytes.Buffer
+		enc := json.NewEncoder(&want)
+		enc.SetIndent("", "  ")
+		if raw {
+			rawBills := make([]rawBill, len(resolved))
+			for i, bill := range resolved {
+				rawBills[i] = rawBill{resolvedBill: bill, billIDs: bill.ids}
+				if rawBills[i].Owers == nil {
+					rawBills[i].Owers = []api.Ower{}
+				}
+			}
+			_ = enc.Encode(rawBills)
+		} else {
+			_ = enc.Encode(resolved)
+		}
+
+		var got bytes.Buffer
+		printBillsJSON(&got, resolved)
+		if got.String() != want.String() {
+			t.Errorf("raw=%v: streamed JSON differs:\ngot:\n%s\nwant:\n%s", raw, got.String(), want.String())
+		}
+	}
 }
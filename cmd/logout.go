@@ -31,6 +31,15 @@ func runLogout(cmd *cobra.Command, _ []string) error {
 	// Remove config file
 	configPath := config.GetConfigPath()
 	if configPath != "" {
+		// Remove keyring entry if the password was stored there
+		if cfg, err := config.LoadFromFile(configPath); err == nil && cfg.Password == config.KeyringPassword {
+			if err := config.DeletePassword(cfg); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+			} else {
+				_, _ = fmt.Fprintln(out, "Removed password from OS keyring")
+			}
+		}
+
 		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("removing config file: %w", err)
 		}
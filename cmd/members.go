@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	memberUserID string
+	memberYes    bool
+)
+
+// NewMembersCommand creates the members command with subcommands
+func NewMembersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "members",
+		Aliases: []string{"member"},
+		Short:   "Manage project members",
+		Long:    `Add or remove members of a Cospend project.`,
+	}
+
+	cmd.AddCommand(newMembersAddCommand())
+	cmd.AddCommand(newMembersRemoveCommand())
+
+	return cmd
+}
+
+func newMembersAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a member to a project",
+		Long: `Add a member to a Cospend project.
+
+Examples:
+  cospend members add Charlie -p myproject
+  cospend members add Charlie -p myproject --user charlie`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMembersAdd,
+	}
+
+	cmd.Flags().StringVar(&memberUserID, "user", "", "Nextcloud username to link the member to")
+
+	return cmd
+}
+
+func newMembersRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <name|id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a member from a project",
+		Long: `Remove a member from a Cospend project.
+
+Members that are still part of existing bills are deactivated instead.
+
+Examples:
+  cospend members remove charlie -p myproject
+  cospend members remove 3 -p myproject --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMembersRemove,
+	}
+
+	cmd.Flags().BoolVarP(&memberYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func runMembersAdd(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("member name is required")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	memberID, err := client.CreateMember(ProjectID, name, memberUserID)
+	if err != nil {
+		return fmt.Errorf("creating member: %w", err)
+	}
+
+	// Drop the cached project so the new member can be resolved by name
+	if err := cache.Invalidate(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Added member #%d: %s\n", memberID, name)
+	return nil
+}
+
+func runMembersRemove(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	member, err := findMember(project, args[0])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if !memberYes {
+		if !confirm(cmd.InOrStdin(), out, fmt.Sprintf("Remove member #%d (%s)?", member.ID, member.Name)) {
+			_, _ = fmt.Fprintln(out, "Cancelled.")
+			return nil
+		}
+	}
+
+	if err := client.DeleteMember(ProjectID, member.ID); err != nil {
+		return fmt.Errorf("removing member: %w", err)
+	}
+
+	if err := cache.Invalidate(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "Removed member #%d: %s\n", member.ID, member.Name)
+	return nil
+}
+
+// findMember finds a project member by ID, name, or username
+func findMember(project *api.Project, nameOrID string) (*api.Member, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for i := range project.Members {
+			if project.Members[i].ID == id {
+				return &project.Members[i], nil
+			}
+		}
+	}
+
+	memberID, err := cache.ResolveMember(project, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range project.Members {
+		if project.Members[i].ID == memberID {
+			return &project.Members[i], nil
+		}
+	}
+	return nil, fmt.Errorf("member not found: %s", nameOrID)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+)
+
+func membersTestProject() api.Project {
+	return api.Project{
+		ID:   "test-project",
+		Name: "Test",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice", Activated: true},
+			{ID: 3, Name: "Charlie", Activated: true},
+		},
+	}
+}
+
+func TestFindMember(t *testing.T) {
+	project := membersTestProject()
+
+	tests := []struct {
+		input   string
+		wantID  int
+		wantErr bool
+	}{
+		{"3", 3, false},
+		{"charlie", 3, false},
+		{"alice", 1, false},
+		{"Dave", 0, true},
+		{"99", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			member, err := findMember(&project, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findMember(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && member.ID != tt.wantID {
+				t.Errorf("findMember(%q) = #%d, want #%d", tt.input, member.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestMembersAddCommand(t *testing.T) {
+	var gotName, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/members" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_ = r.ParseForm()
+		gotName = r.PostForm.Get("name")
+		gotUser = r.PostForm.Get("userId")
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, api.Member{ID: 4, Name: gotName, Activated: true}))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	if err := cache.Save("test-project", &api.Project{ID: "test-project"}); err != nil {
+		t.Fatalf("Failed to seed cache: %v", err)
+	}
+
+	ProjectID = "test-project"
+	cmd := NewMembersCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"add", "Dave", "--user", "dave"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if gotName != "Dave" || gotUser != "dave" {
+		t.Errorf("Unexpected form data: name=%q userId=%q", gotName, gotUser)
+	}
+	if !strings.Contains(buf.String(), "Added member #4: Dave") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+	if _, ok := cache.Load("test-project"); ok {
+		t.Error("Project cache should be invalidated")
+	}
+}
+
+func TestMembersRemoveCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		input       string
+		wantDeleted bool
+		wantOutput  string
+	}{
+		{"confirmed", []string{"remove", "charlie"}, "y\n", true, "Removed member #3: Charlie"},
+		{"declined", []string{"remove", "charlie"}, "n\n", false, "Cancelled."},
+		{"yes flag", []string{"remove", "3", "--yes"}, "", true, "Removed member #3: Charlie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := membersTestProject()
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "DELETE" && r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/members/3":
+					deleted = true
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, "DELETED"))
+				case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+					_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+				default:
+					t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			cleanup := setupTestEnv(t, server.URL)
+			defer cleanup()
+
+			ProjectID = "test-project"
+			cmd := NewMembersCommand()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("Output should contain %q, got: %s", tt.wantOutput, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	methodIcon  string
+	methodColor string
+)
+
+// NewMethodsCommand creates the methods command with subcommands
+func NewMethodsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "methods",
+		Aliases: []string{"method"},
+		Short:   "Manage project payment methods",
+		Long:    `Manage the payment methods of a Cospend project.`,
+	}
+
+	cmd.AddCommand(newMethodsAddCommand())
+
+	return cmd
+}
+
+func newMethodsAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a payment method to a project",
+		Long: `Add a payment method to a Cospend project.
+
+Examples:
+  cospend methods add Venmo -p myproject
+  cospend methods add Revolut -p myproject --icon 💳 --color "#0666eb"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMethodsAdd,
+	}
+
+	cmd.Flags().StringVar(&methodIcon, "icon", "", "Payment method icon (usually an emoji)")
+	cmd.Flags().StringVar(&methodColor, "color", "", "Payment method color as a hex string (e.g., #ff0000)")
+
+	return cmd
+}
+
+func runMethodsAdd(cmd *cobra.Command, args []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("payment method name is required")
+	}
+
+	color, err := normalizeHexColor(methodColor)
+	if err != nil {
+		return err
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	methodID, err := client.CreatePaymentMode(ProjectID, api.PaymentMode{
+		Name:  name,
+		Icon:  methodIcon,
+		Color: color,
+	})
+	if err != nil {
+		return fmt.Errorf("creating payment method: %w", err)
+	}
+
+	// Drop the cached project so the new method can be resolved by name
+	if err := cache.Invalidate(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Added payment method #%d: %s\n", methodID, name)
+	return nil
+}
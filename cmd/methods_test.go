@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+)
+
+func TestMethodsAddCommand(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/paymode" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_ = r.ParseForm()
+		form = map[string]string{
+			"name":  r.PostForm.Get("name"),
+			"icon":  r.PostForm.Get("icon"),
+			"color": r.PostForm.Get("color"),
+		}
+		_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 8))
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	if err := cache.Save("test-project", &api.Project{ID: "test-project"}); err != nil {
+		t.Fatalf("Failed to seed cache: %v", err)
+	}
+
+	ProjectID = "test-project"
+	cmd := NewMethodsCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"add", "Venmo", "--icon", "💳"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if form["name"] != "Venmo" || form["icon"] != "💳" || form["color"] != "" {
+		t.Errorf("Unexpected form data: %v", form)
+	}
+	if !strings.Contains(buf.String(), "Added payment method #8: Venmo") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+	if _, ok := cache.Load("test-project"); ok {
+		t.Error("Project cache should be invalidated")
+	}
+}
+
+func TestMethodsAddInvalidColor(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	ProjectID = "test-project"
+	cmd := NewMethodsCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"add", "Venmo", "--color", "#12"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for invalid color")
+	}
+}
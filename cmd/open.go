@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var openNoBrowser bool
+
+// NewOpenCommand creates the open command
+func NewOpenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "open",
+		Short: "Open a project in the Cospend web UI",
+		Long: `Open a Cospend project in your browser. Without a project, the Cospend app
+itself is opened. The URL is always printed, so it can be copied on machines
+without a browser.
+
+Examples:
+  cospend open -p myproject
+  cospend open
+  cospend open -p myproject --no-browser`,
+		Args: cobra.NoArgs,
+		RunE: runOpen,
+	}
+
+	cmd.Flags().BoolVar(&openNoBrowser, "no-browser", false, "Only print the URL, don't open a browser")
+
+	return cmd
+}
+
+func runOpen(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	link := cospendWebURL(cfg.Domain, ProjectID)
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), link)
+
+	if openNoBrowser || detectHeadless() {
+		return nil
+	}
+	if err := openBrowser(link); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: couldn't open browser: %v\n", err)
+	}
+	return nil
+}
+
+// cospendWebURL returns the web UI address of a project on domain, or of the
+// Cospend app when projectID is empty
+func cospendWebURL(domain, projectID string) string {
+	link := config.NormalizeURL(domain) + "/index.php/apps/cospend/"
+	if projectID != "" {
+		link += "p/" + url.PathEscape(projectID)
+	}
+	return link
+}
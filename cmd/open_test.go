@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCospendWebURL(t *testing.T) {
+	tests := []struct {
+		domain, project, want string
+	}{
+		{"cloud.example.com", "trip", "https://cloud.example.com/index.php/apps/cospend/p/trip"},
+		{"https://cloud.example.com/nc/", "trip", "https://cloud.example.com/nc/index.php/apps/cospend/p/trip"},
+		{"https://cloud.example.com", "", "https://cloud.example.com/index.php/apps/cospend/"},
+		{"https://cloud.example.com", "a b", "https://cloud.example.com/index.php/apps/cospend/p/a%20b"},
+	}
+	for _, tt := range tests {
+		if got := cospendWebURL(tt.domain, tt.project); got != tt.want {
+			t.Errorf("cospendWebURL(%q, %q) = %s, want %s", tt.domain, tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestOpenCommand(t *testing.T) {
+	cleanup := setupTestEnv(t, "https://cloud.example.com")
+	defer cleanup()
+	defer func() { openNoBrowser = false }()
+
+	openedURL, restore := mockOpenBrowser()
+	defer restore()
+
+	ProjectID = "trip"
+	cmd := NewOpenCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+
+	want := "https://cloud.example.com/index.php/apps/cospend/p/trip"
+	if *openedURL != want {
+		t.Errorf("openBrowser URL = %s, want %s", *openedURL, want)
+	}
+	if strings.TrimSpace(buf.String()) != want {
+		t.Errorf("Output = %q, want the URL", buf.String())
+	}
+
+	// --no-browser only prints the URL
+	*openedURL = ""
+	cmd = NewOpenCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--no-browser"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("open --no-browser failed: %v", err)
+	}
+	if *openedURL != "" {
+		t.Errorf("--no-browser should not open a browser, opened %s", *openedURL)
+	}
+}
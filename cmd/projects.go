@@ -4,11 +4,17 @@ import (
 	"fmt"
 
 	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
 	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
 	"github.com/spf13/cobra"
 )
 
-var showAllProjects bool
+var (
+	showAllProjects      bool
+	showArchivedProjects bool
+	projectsStats        bool
+)
 
 // NewProjectsCommand creates the projects command
 func NewProjectsCommand() *cobra.Command {
@@ -16,16 +22,35 @@ func NewProjectsCommand() *cobra.Command {
 		Use:     "projects",
 		Aliases: []string{"proj"},
 		Short:   "List available Cospend projects",
-		Long:    `List all Cospend projects you have access to.`,
-		RunE:    runProjects,
+		Long: `List all Cospend projects you have access to.
+
+Use --stats to add each project's bill count and total spend. This fetches
+the bills of every project, so it's slower; results are cached for a few
+minutes.
+
+Examples:
+  cospend projects
+  cospend projects --all
+  cospend projects --archived-only
+  cospend projects --stats
+  cospend projects template export trip > template.json`,
+		RunE: runProjects,
 	}
 
 	cmd.Flags().BoolVarP(&showAllProjects, "all", "a", false, "Show all projects including archived")
+	cmd.Flags().BoolVar(&showArchivedProjects, "archived-only", false, "Show only archived projects")
+	cmd.Flags().BoolVar(&projectsStats, "stats", false, "Show bill count and total spend per project")
+
+	cmd.AddCommand(newProjectsTemplateCommand())
 
 	return cmd
 }
 
 func runProjects(cmd *cobra.Command, _ []string) error {
+	if showAllProjects && showArchivedProjects {
+		return fmt.Errorf("--archived-only can't be combined with --all")
+	}
+
 	// Parameters validated, silence usage for subsequent errors
 	cmd.SilenceUsage = true
 
@@ -36,9 +61,7 @@ func runProjects(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Get API client
-	client := api.NewClient(cfg)
-	client.Debug = Debug
-	client.DebugWriter = cmd.ErrOrStderr()
+	client := newClient(cmd, cfg)
 
 	// Fetch projects
 	projects, err := client.GetProjects()
@@ -46,10 +69,11 @@ func runProjects(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("fetching projects: %w", err)
 	}
 
-	// Filter out archived projects unless --all is set
+	// Filter out archived projects unless --all is set, or keep only those
+	// with --archived-only
 	var filtered []api.ProjectSummary
 	for _, proj := range projects {
-		if showAllProjects || !proj.IsArchived() {
+		if showAllProjects || proj.IsArchived() == showArchivedProjects {
 			filtered = append(filtered, proj)
 		}
 	}
@@ -61,6 +85,28 @@ func runProjects(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if projectsStats {
+		stats := fetchProjectStats(cmd, client, filtered, cfg.Workers)
+		locale := loadLocale(cmd, client, cfg)
+
+		table := NewTable("ID", "NAME", "CURRENCY", "BILLS", "TOTAL")
+		for i, proj := range filtered {
+			currency := proj.CurrName
+			if currency == "" {
+				currency = "-"
+			}
+			count, total := "-", "-"
+			if stats[i] != nil {
+				count = fmt.Sprintf("%d", stats[i].BillCount)
+				total = format.NewAmountFormatter(locale, proj.CurrName).Format(stats[i].Total)
+			}
+			table.AddRow(proj.ID, proj.Name, currency, count, total)
+		}
+		table.Render(out)
+		_, _ = fmt.Fprintf(out, "\nTotal: %d project(s)\n", len(filtered))
+		return nil
+	}
+
 	table := NewTable("ID", "NAME", "CURRENCY")
 	for _, proj := range filtered {
 		currency := proj.CurrName
@@ -75,3 +121,62 @@ func runProjects(cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// fetchProjectStats returns the bill count and total for each project, using
+// cached stats when available and fetching the rest concurrently. Entries for
+// projects whose bills couldn't be fetched are nil.
+func fetchProjectStats(cmd *cobra.Command, client *api.Client, projects []api.ProjectSummary, workers int) []*cache.ProjectStats {
+	stats := make([]*cache.ProjectStats, len(projects))
+	errs := make([]error, len(projects))
+	fetched := make([]bool, len(projects))
+
+	// Index by ID so each worker writes only its own slot
+	index := make(map[string]int, len(projects))
+	var ids []string
+	for i, proj := range projects {
+		if !NoCache {
+			if cached, ok := cache.LoadProjectStats(proj.ID); ok {
+				stats[i] = cached
+				continue
+			}
+		}
+		fetched[i] = true
+		index[proj.ID] = i
+		ids = append(ids, proj.ID)
+	}
+
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching project stats...")
+	_ = api.ForEachProject(ids, workers, func(id string) error {
+		i := index[id]
+		bills, err := client.GetBills(id)
+		if err != nil {
+			errs[i] = err
+			return err
+		}
+		s := &cache.ProjectStats{BillCount: len(bills)}
+		for _, bill := range bills {
+			s.Total += bill.Amount
+		}
+		stats[i] = s
+		return nil
+	})
+	stop()
+
+	for i, proj := range projects {
+		if !fetched[i] {
+			continue
+		}
+		if errs[i] != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to fetch bills for %s: %v\n", proj.ID, errs[i])
+			continue
+		}
+		if NoCache {
+			continue
+		}
+		if err := cache.SaveProjectStats(proj.ID, stats[i]); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache project stats: %v\n", err)
+		}
+	}
+
+	return stats
+}
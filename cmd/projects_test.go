@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func newProjectsTestServer(t *testing.T, billRequests *int32) *httptest.Server {
+	t.Helper()
+	archived := int64(1700000000)
+	projects := []api.ProjectSummary{
+		{ID: "home", Name: "Home", CurrName: "USD"},
+		{ID: "trip", Name: "Trip", CurrName: "EUR"},
+		{ID: "old", Name: "Old", ArchivedTS: &archived},
+	}
+	bills := map[string][]api.BillResponse{
+		"home": {{ID: 1, Amount: 10}, {ID: 2, Amount: 15.5}},
+		"trip": {{ID: 3, Amount: 100}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/ocs/v2.php/apps/cospend/api/v1/projects"
+		switch {
+		case r.URL.Path == prefix:
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, projects))
+		case strings.HasSuffix(r.URL.Path, "/bills"):
+			atomic.AddInt32(billRequests, 1)
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix+"/"), "/bills")
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills[id]}))
+		case r.URL.Path == "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestProjectsCommand(t *testing.T) {
+	var billRequests int32
+	server := newProjectsTestServer(t, &billRequests)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	showAllProjects, showArchivedProjects, projectsStats = false, false, false
+
+	cmd := NewProjectsCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "Home") || !strings.Contains(output, "Trip") {
+		t.Errorf("Expected active projects, got:\n%s", output)
+	}
+	if strings.Contains(output, "Old") {
+		t.Errorf("Archived project should be hidden, got:\n%s", output)
+	}
+	if strings.Contains(output, "BILLS") || billRequests != 0 {
+		t.Errorf("Bills should not be fetched without --stats (%d requests), got:\n%s", billRequests, output)
+	}
+}
+
+func TestProjectsCommandArchivedOnly(t *testing.T) {
+	var billRequests int32
+	server := newProjectsTestServer(t, &billRequests)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	showAllProjects, showArchivedProjects, projectsStats = false, false, false
+	defer func() { showAllProjects, showArchivedProjects = false, false }()
+
+	cmd := NewProjectsCommand()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--archived-only"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "Old") || strings.Contains(output, "Home") || strings.Contains(output, "Trip") {
+		t.Errorf("Expected only the archived project, got:\n%s", output)
+	}
+
+	cmd = NewProjectsCommand()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--archived-only", "--all"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --archived-only with --all")
+	}
+}
+
+func TestProjectsCommandStats(t *testing.T) {
+	var billRequests int32
+	server := newProjectsTestServer(t, &billRequests)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	showAllProjects, showArchivedProjects, projectsStats = false, false, false
+	defer func() { projectsStats = false }()
+
+	run := func() string {
+		cmd := NewProjectsCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"--stats"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return stdout.String()
+	}
+
+	output := run()
+	if !strings.Contains(output, "BILLS") || !strings.Contains(output, "TOTAL") {
+		t.Errorf("Expected stats columns, got:\n%s", output)
+	}
+	if !strings.Contains(output, "25.50") || !strings.Contains(output, "100.00") {
+		t.Errorf("Expected per-project totals, got:\n%s", output)
+	}
+	if billRequests != 2 {
+		t.Errorf("Expected 2 bill requests, got %d", billRequests)
+	}
+
+	// Second run is served from the stats cache
+	run()
+	if billRequests != 2 {
+		t.Errorf("Expected cached stats on second run, got %d bill requests", billRequests)
+	}
+}
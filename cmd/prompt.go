@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/chenasraf/cospend-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+// prompters holds one prompter per input, so input buffered by one prompt
+// isn't lost to the next
+var prompters = map[io.Reader]*prompt.Prompter{}
+
+// prompter returns the shared prompter for the command's input, writing to the
+// command's output
+func prompter(cmd *cobra.Command) *prompt.Prompter {
+	in := cmd.InOrStdin()
+	p, ok := prompters[in]
+	if !ok {
+		p = prompt.New(in, cmd.OutOrStdout())
+		prompters[in] = p
+	}
+	p.Out = cmd.OutOrStdout()
+	return p
+}
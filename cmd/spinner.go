@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerElapsedAfter is how long a spinner runs before it shows the elapsed time
+const spinnerElapsedAfter = 2 * time.Second
+
+// startSpinner shows an animated spinner with message on w until the returned
+// stop function is called, with the elapsed time once the wait gets long.
+// Nothing is shown with --quiet or unless w is a terminal, so piped and
+// redirected output stays clean.
+func startSpinner(w io.Writer, message string) (stop func()) {
+	f, ok := w.(*os.File)
+	if Quiet || !ok || !term.IsTerminal(int(f.Fd())) {
+		return func() {}
+	}
+	started := time.Now()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			line := fmt.Sprintf("%s %s", spinnerFrames[i%len(spinnerFrames)], message)
+			if elapsed := time.Since(started); elapsed >= spinnerElapsedAfter {
+				line += fmt.Sprintf(" (%ds)", int(elapsed.Seconds()))
+			}
+			_, _ = fmt.Fprint(w, "\r"+line)
+			select {
+			case <-done:
+				_, _ = fmt.Fprint(w, "\r\033[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	statsFormat  string
+	statsByMonth bool
+	statsChart   bool
+)
+
+// defaultChartWidth is used when the terminal width can't be determined
+const defaultChartWidth = 80
+
+// statsGroup holds aggregated totals for a single group of bills
+type statsGroup struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	Total float64 `json:"total"`
+}
+
+// monthTotal holds the spending total for a single YYYY-MM month
+type monthTotal struct {
+	Month string  `json:"month"`
+	Count int     `json:"count"`
+	Total float64 `json:"total"`
+}
+
+// memberStats holds what a single member paid and spent over a set of bills.
+// Balance is what they paid minus what they spent.
+type memberStats struct {
+	Name    string  `json:"name"`
+	Paid    float64 `json:"paid"`
+	Spent   float64 `json:"spent"`
+	Balance float64 `json:"balance"`
+}
+
+// billStats holds spending totals for a set of bills
+type billStats struct {
+	Count      int           `json:"count"`
+	Total      float64       `json:"total"`
+	Average    float64       `json:"average"`
+	ByCategory []statsGroup  `json:"by_category"`
+	ByPayer    []statsGroup  `json:"by_payer"`
+	ByMember   []memberStats `json:"by_member"`
+}
+
+// NewStatsCommand creates the stats command
+func NewStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show spending summaries for a Cospend project",
+		Long: `Show spending totals by category and by payer, what each member paid and
+spent, and the overall total, bill count, and average bill amount.
+
+Accepts the same filters as the list command. Member totals come from the
+server's statistics when the only filters are --by, --category, --method,
+--from, and --to, and are computed locally otherwise.
+
+Examples:
+  cospend stats -p myproject
+  cospend stats -p myproject --this-month
+  cospend stats -p myproject -b alice --recent 2w
+  cospend stats -p myproject --format json
+  cospend stats -p myproject --by-month --recent 12m
+  cospend stats -p myproject --by-month --chart
+  cospend stats -p myproject --by-month --format csv`,
+		RunE: runStats,
+	}
+
+	addFilterFlags(cmd)
+	cmd.Flags().StringVar(&statsFormat, "format", "table", "Output format: table, json, csv (csv requires --by-month)")
+	cmd.Flags().BoolVar(&statsByMonth, "by-month", false, "Show totals per month")
+	cmd.Flags().BoolVar(&statsChart, "chart", false, "Render monthly totals as a bar chart (requires --by-month)")
+
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	switch statsFormat {
+	case "table", "json":
+	case "csv":
+		if !statsByMonth {
+			return fmt.Errorf("csv format requires --by-month")
+		}
+	default:
+		return fmt.Errorf("unsupported format: %s (expected table, json, or csv)", statsFormat)
+	}
+
+	if statsChart && !statsByMonth {
+		return fmt.Errorf("--chart requires --by-month")
+	}
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	loc, err := dateLocation(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, ProjectID)
+	if err != nil {
+		return err
+	}
+
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching bills...")
+	bills, err := client.GetBills(ProjectID)
+	stop()
+	if err != nil {
+		return fmt.Errorf("fetching bills: %w", err)
+	}
+
+	locale := loadLocale(cmd, client, cfg)
+
+	filters, err := buildFilters(project, cfg.User, loc)
+	if err != nil {
+		return err
+	}
+
+	resolved := resolveBillNames(project, applyFilters(bills, filters))
+	formatter := format.NewAmountFormatter(locale, displayCurrency(cfg, project))
+
+	if statsByMonth {
+		months := monthlyTotals(resolved)
+		switch statsFormat {
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(months)
+		case "csv":
+			printMonthlyCSV(cmd.OutOrStdout(), months)
+		default:
+			if statsChart {
+				printMonthlyChart(cmd.OutOrStdout(), months, formatter, locale, terminalWidth(cmd.OutOrStdout()))
+			} else {
+				printMonthlyTable(cmd.OutOrStdout(), months, formatter, locale)
+			}
+		}
+		return nil
+	}
+
+	stats := computeStats(resolved)
+	if stats.Count > 0 {
+		if opts, ok := serverStatsOptions(cmd, project, loc); ok {
+			stop := startSpinner(cmd.ErrOrStderr(), "Fetching statistics...")
+			serverStats, err := client.GetStatistics(ProjectID, opts)
+			stop()
+			// Older servers don't have the endpoint; the local totals stand
+			if err == nil {
+				stats.ByMember = memberStatsFromServer(serverStats)
+			}
+		}
+	}
+
+	if statsFormat == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
+	printStatsTable(cmd, stats, formatter)
+	return nil
+}
+
+// computeStats aggregates totals over the given bills
+func computeStats(bills []resolvedBill) billStats {
+	stats := billStats{
+		ByCategory: groupBills(bills, func(b resolvedBill) string { return b.Category }),
+		ByPayer:    groupBills(bills, func(b resolvedBill) string { return b.PaidBy }),
+		ByMember:   memberTotals(bills),
+	}
+	for _, bill := range bills {
+		stats.Count++
+		stats.Total += bill.Amount
+	}
+	if stats.Count > 0 {
+		stats.Average = stats.Total / float64(stats.Count)
+	}
+	return stats
+}
+
+// groupBills sums bills by the given key, sorted by total (highest first).
+// Bills with an empty key are grouped under "-".
+func groupBills(bills []resolvedBill, key func(resolvedBill) string) []statsGroup {
+	index := make(map[string]int)
+	groups := []statsGroup{}
+	for _, bill := range bills {
+		name := key(bill)
+		if name == "" {
+			name = "-"
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, statsGroup{Name: name})
+		}
+		groups[i].Count++
+		groups[i].Total += bill.Amount
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Total != groups[j].Total {
+			return groups[i].Total > groups[j].Total
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// memberTotals sums what each member paid and spent over the given bills,
+// splitting each bill between its owers by weight
+func memberTotals(bills []resolvedBill) []memberStats {
+	index := make(map[string]int)
+	members := []memberStats{}
+	member := func(name string) *memberStats {
+		i, ok := index[name]
+		if !ok {
+			i = len(members)
+			index[name] = i
+			members = append(members, memberStats{Name: name})
+		}
+		return &members[i]
+	}
+
+	for _, bill := range splitBills(bills) {
+		if bill.PaidBy != "" {
+			member(bill.PaidBy).Paid += bill.Amount
+		}
+		for _, share := range bill.Shares {
+			member(share.Member).Spent += share.Owes
+		}
+	}
+
+	for i := range members {
+		members[i].Paid = math.Round(members[i].Paid*100) / 100
+		members[i].Spent = math.Round(members[i].Spent*100) / 100
+		members[i].Balance = math.Round((members[i].Paid-members[i].Spent)*100) / 100
+	}
+	sortMemberStats(members)
+	return members
+}
+
+// memberStatsFromServer converts the server's member statistics, leaving out
+// members who neither paid nor spent anything
+func memberStatsFromServer(stats *api.Statistics) []memberStats {
+	members := []memberStats{}
+	for _, m := range stats.Members {
+		if m.Paid == 0 && m.Spent == 0 {
+			continue
+		}
+		members = append(members, memberStats{
+			Name:    m.Member.Name,
+			Paid:    m.Paid,
+			Spent:   m.Spent,
+			Balance: m.FilteredBalance,
+		})
+	}
+	sortMemberStats(members)
+	return members
+}
+
+// sortMemberStats orders members by what they spent (highest first)
+func sortMemberStats(members []memberStats) {
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Spent != members[j].Spent {
+			return members[i].Spent > members[j].Spent
+		}
+		return members[i].Name < members[j].Name
+	})
+}
+
+// serverStatsOptions maps the filters given to cmd onto the statistics
+// endpoint's options. It reports false when a filter is given that the
+// endpoint can't apply, in which case the totals are computed locally.
+func serverStatsOptions(cmd *cobra.Command, project *api.Project, loc *time.Location) (api.StatsOptions, bool) {
+	var opts api.StatsOptions
+	for _, f := range activeFilters(cmd) {
+		var err error
+		switch f.Name {
+		case "by":
+			opts.PayerID, err = cache.ResolveMember(project, listPaidBy)
+		case "category":
+			opts.CategoryID, err = cache.ResolveCategory(project, listCategory)
+		case "method":
+			opts.PaymentModeID, err = cache.ResolvePaymentMode(project, listPaymentMethod)
+		case "from":
+			opts.From, err = filterDay(listFrom, loc)
+		case "to":
+			var day time.Time
+			day, err = filterDay(listTo, loc)
+			// Up to the last second of the day
+			opts.To = day.AddDate(0, 0, 1).Add(-time.Second)
+		default:
+			return opts, false
+		}
+		if err != nil {
+			return opts, false
+		}
+	}
+	return opts, true
+}
+
+// filterDay returns the start of the day named by a --from or --to value
+func filterDay(s string, loc *time.Location) (time.Time, error) {
+	date, err := parseFilterDate(s, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation("2006-01-02", date, loc)
+}
+
+func printStatsTable(cmd *cobra.Command, stats billStats, formatter *format.AmountFormatter) {
+	out := cmd.OutOrStdout()
+	if stats.Count == 0 {
+		_, _ = fmt.Fprintln(out, "No bills found.")
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "Bills:    %d\n", stats.Count)
+	_, _ = fmt.Fprintf(out, "Total:    %s\n", formatter.Format(stats.Total))
+	_, _ = fmt.Fprintf(out, "Average:  %s\n", formatter.Format(stats.Average))
+
+	printGroups := func(title, header string, groups []statsGroup) {
+		_, _ = fmt.Fprintln(out)
+		_, _ = fmt.Fprintf(out, "%s:\n", title)
+		table := NewTable(header, "COUNT", "TOTAL")
+		for _, g := range groups {
+			table.AddRow(g.Name, strconv.Itoa(g.Count), formatter.Format(g.Total))
+		}
+		table.Render(out)
+	}
+
+	printGroups("By category", "CATEGORY", stats.ByCategory)
+	printGroups("By payer", "PAID BY", stats.ByPayer)
+
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "By member:")
+	table := NewTable("MEMBER", "PAID", "SPENT", "BALANCE")
+	for _, m := range stats.ByMember {
+		table.AddRow(m.Name, formatter.Format(m.Paid), formatter.Format(m.Spent), formatter.Format(m.Balance))
+	}
+	table.Render(out)
+}
+
+// monthlyTotals buckets bills by the YYYY-MM prefix of their date, ordered
+// oldest month first. Bills without a valid date are skipped.
+func monthlyTotals(bills []resolvedBill) []monthTotal {
+	index := make(map[string]int)
+	months := []monthTotal{}
+	for _, bill := range bills {
+		if !validBillDate(bill.Date) {
+			continue
+		}
+		month := bill.Date[:7]
+		i, ok := index[month]
+		if !ok {
+			i = len(months)
+			index[month] = i
+			months = append(months, monthTotal{Month: month})
+		}
+		months[i].Count++
+		months[i].Total += bill.Amount
+	}
+
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Month < months[j].Month
+	})
+	return months
+}
+
+// printMonthlyTable prints monthly totals, naming the months in the language of locale
+func printMonthlyTable(out io.Writer, months []monthTotal, formatter *format.AmountFormatter, locale string) {
+	if len(months) == 0 {
+		_, _ = fmt.Fprintln(out, "No bills found.")
+		return
+	}
+
+	table := NewTable("MONTH", "COUNT", "TOTAL")
+	for _, m := range months {
+		table.AddRow(format.MonthName(m.Month, locale), strconv.Itoa(m.Count), formatter.Format(m.Total))
+	}
+	table.Render(out)
+}
+
+func printMonthlyCSV(out io.Writer, months []monthTotal) {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"Month", "Count", "Total"})
+	for _, m := range months {
+		_ = w.Write([]string{m.Month, strconv.Itoa(m.Count), strconv.FormatFloat(m.Total, 'f', 2, 64)})
+	}
+	w.Flush()
+}
+
+// printMonthlyChart renders monthly totals as a horizontal bar chart that fits
+// within width columns, naming the months in the language of locale
+func printMonthlyChart(out io.Writer, months []monthTotal, formatter *format.AmountFormatter, locale string, width int) {
+	if len(months) == 0 {
+		_, _ = fmt.Fprintln(out, "No bills found.")
+		return
+	}
+
+	labels := make([]string, len(months))
+	labelWidth := 0
+	amounts := make([]string, len(months))
+	amountWidth := 0
+	maxTotal := 0.0
+	for i, m := range months {
+		labels[i] = format.MonthName(m.Month, locale)
+		if w := runewidth.StringWidth(labels[i]); w > labelWidth {
+			labelWidth = w
+		}
+		amounts[i] = formatter.Format(m.Total)
+		if w := runewidth.StringWidth(amounts[i]); w > amountWidth {
+			amountWidth = w
+		}
+		if m.Total > maxTotal {
+			maxTotal = m.Total
+		}
+	}
+
+	// "<month>  <amount>  <bar>"
+	barWidth := width - labelWidth - amountWidth - 4
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	for i, m := range months {
+		bar := 0
+		if maxTotal > 0 && m.Total > 0 {
+			bar = int(m.Total / maxTotal * float64(barWidth))
+		}
+		_, _ = fmt.Fprintf(out, "%s  %s  %s\n", runewidth.FillRight(labels[i], labelWidth), runewidth.FillLeft(amounts[i], amountWidth), strings.Repeat("#", bar))
+	}
+}
+
+// terminalWidth returns the width of the terminal w writes to, or defaultChartWidth
+func terminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	return defaultChartWidth
+}
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/format"
+)
+
+func TestComputeStats(t *testing.T) {
+	bills := []resolvedBill{
+		{ID: 1, Amount: 50, PaidBy: "Alice", Category: "Food"},
+		{ID: 2, Amount: 30, PaidBy: "Bob", Category: "Food"},
+		{ID: 3, Amount: 100, PaidBy: "Alice", Category: "Transport"},
+		{ID: 4, Amount: 20, PaidBy: "Bob"},
+	}
+
+	stats := computeStats(bills)
+
+	if stats.Count != 4 {
+		t.Errorf("Count = %d, want 4", stats.Count)
+	}
+	if stats.Total != 200 {
+		t.Errorf("Total = %f, want 200", stats.Total)
+	}
+	if stats.Average != 50 {
+		t.Errorf("Average = %f, want 50", stats.Average)
+	}
+
+	wantCategories := []statsGroup{
+		{Name: "Transport", Count: 1, Total: 100},
+		{Name: "Food", Count: 2, Total: 80},
+		{Name: "-", Count: 1, Total: 20},
+	}
+	if len(stats.ByCategory) != len(wantCategories) {
+		t.Fatalf("ByCategory = %v, want %v", stats.ByCategory, wantCategories)
+	}
+	for i, want := range wantCategories {
+		if stats.ByCategory[i] != want {
+			t.Errorf("ByCategory[%d] = %v, want %v", i, stats.ByCategory[i], want)
+		}
+	}
+
+	wantPayers := []statsGroup{
+		{Name: "Alice", Count: 2, Total: 150},
+		{Name: "Bob", Count: 2, Total: 50},
+	}
+	for i, want := range wantPayers {
+		if stats.ByPayer[i] != want {
+			t.Errorf("ByPayer[%d] = %v, want %v", i, stats.ByPayer[i], want)
+		}
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	stats := computeStats(nil)
+	if stats.Count != 0 || stats.Total != 0 || stats.Average != 0 {
+		t.Errorf("Expected zero stats, got %+v", stats)
+	}
+	if stats.ByCategory == nil || stats.ByPayer == nil {
+		t.Error("Groups should be empty slices, not nil")
+	}
+}
+
+func TestPrintStatsTable(t *testing.T) {
+	stats := computeStats([]resolvedBill{
+		{ID: 1, Amount: 50, PaidBy: "Alice", Category: "Food"},
+		{ID: 2, Amount: 25, PaidBy: "Bob", Category: "Food"},
+	})
+
+	cmd := NewStatsCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	printStatsTable(cmd, stats, format.NewAmountFormatter("en_US", "USD"))
+
+	output := buf.String()
+	for _, want := range []string{"Bills:    2", "Total:    $ 75.00", "Average:  $ 37.50", "CATEGORY", "PAID BY", "MEMBER", "BALANCE", "Alice", "Bob"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestStatsCommandJSON(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice"},
+			{ID: 2, Name: "Bob", UserID: "bob"},
+		},
+		Categories: []api.Category{{ID: 1, Name: "Food"}},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, CategoryID: 1},
+		{ID: 2, What: "Taxi", Amount: 40, Date: "2026-01-11", PayerID: 2},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	resetListFlags()
+	defer resetListFlags()
+	defer resetStatsFlags()
+
+	ProjectID = "test-project"
+	cmd := NewStatsCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"--format", "json", "-b", "bob"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var result billStats
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if result.Count != 1 || result.Total != 40 {
+		t.Errorf("Expected 1 bill totaling 40, got %+v", result)
+	}
+	if len(result.ByPayer) != 1 || result.ByPayer[0].Name != "Bob" {
+		t.Errorf("ByPayer = %v, want only Bob", result.ByPayer)
+	}
+}
+
+func TestMemberTotals(t *testing.T) {
+	bills := []resolvedBill{
+		{Amount: 60, PaidBy: "Alice", PaidFor: []string{"Alice", "Bob"}, ids: billIDs{Owers: []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 2}}}},
+		{Amount: 10, PaidBy: "Bob", PaidFor: []string{"Alice"}, ids: billIDs{Owers: []api.Ower{{ID: 1, Weight: 1}}}},
+	}
+
+	want := []memberStats{
+		{Name: "Bob", Paid: 10, Spent: 40, Balance: -30},
+		{Name: "Alice", Paid: 60, Spent: 30, Balance: 30},
+	}
+	got := memberTotals(bills)
+	if len(got) != len(want) {
+		t.Fatalf("memberTotals() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("memberTotals()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStatsCommandServerMembers(t *testing.T) {
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test",
+		CurrencyName: "USD",
+		Members: []api.Member{
+			{ID: 1, Name: "Alice", UserID: "alice"},
+			{ID: 2, Name: "Bob", UserID: "bob"},
+			{ID: 3, Name: "Carol", UserID: "carol"},
+		},
+	}
+	bills := []api.BillResponse{
+		{ID: 1, What: "Lunch", Amount: 20, Date: "2026-01-10", PayerID: 1, Owers: []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}}},
+	}
+	statistics := map[string]any{
+		"stats": []map[string]any{
+			{"member": map[string]any{"id": 1, "name": "Alice"}, "paid": 20, "spent": 10, "filtered_balance": 10},
+			{"member": map[string]any{"id": 2, "name": "Bob"}, "paid": 0, "spent": 10, "filtered_balance": -10},
+			{"member": map[string]any{"id": 3, "name": "Carol"}, "paid": 0, "spent": 0, "filtered_balance": 0},
+		},
+		"categoryStats":    []any{},
+		"paymentModeStats": []any{},
+	}
+
+	var statsQueries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]any{"bills": bills}))
+		case "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/statistics":
+			statsQueries = append(statsQueries, r.URL.RawQuery)
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, statistics))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	defer resetListFlags()
+	defer resetStatsFlags()
+
+	runStatsJSON := func(args ...string) billStats {
+		t.Helper()
+		resetListFlags()
+		resetStatsFlags()
+		ProjectID = "test-project"
+		cmd := NewStatsCommand()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs(append([]string{"--format", "json"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		var result billStats
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+		}
+		return result
+	}
+
+	result := runStatsJSON("-b", "alice")
+	if len(statsQueries) != 1 || statsQueries[0] != "payerId=1" {
+		t.Fatalf("Statistics queries = %v, want one with payerId=1", statsQueries)
+	}
+	want := []memberStats{
+		{Name: "Alice", Paid: 20, Spent: 10, Balance: 10},
+		{Name: "Bob", Spent: 10, Balance: -10},
+	}
+	if len(result.ByMember) != len(want) || result.ByMember[0] != want[0] || result.ByMember[1] != want[1] {
+		t.Errorf("ByMember = %+v, want %+v without idle members", result.ByMember, want)
+	}
+
+	// The endpoint can't filter by name, so the totals are computed locally
+	result = runStatsJSON("--name", "lunch")
+	if len(statsQueries) != 1 {
+		t.Errorf("Statistics shouldn't be fetched with --name, queries = %v", statsQueries)
+	}
+	if len(result.ByMember) != 2 || result.ByMember[0].Paid != 20 {
+		t.Errorf("ByMember = %+v, want local totals", result.ByMember)
+	}
+}
+
+func TestStatsCommandInvalidFormat(t *testing.T) {
+	resetListFlags()
+	defer resetListFlags()
+	defer resetStatsFlags()
+
+	ProjectID = "test-project"
+	cmd := NewStatsCommand()
+	cmd.SetArgs([]string{"--format", "xml"})
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestMonthlyTotals(t *testing.T) {
+	bills := []resolvedBill{
+		{ID: 1, Date: "2026-03-02", Amount: 10},
+		{ID: 2, Date: "2026-01-15", Amount: 20},
+		{ID: 3, Date: "2026-03-20", Amount: 5},
+		{ID: 4, Date: "2025-12-31", Amount: 7},
+		{ID: 5, Date: "", Amount: 99},
+	}
+
+	months := monthlyTotals(bills)
+
+	want := []monthTotal{
+		{Month: "2025-12", Count: 1, Total: 7},
+		{Month: "2026-01", Count: 1, Total: 20},
+		{Month: "2026-03", Count: 2, Total: 15},
+	}
+	if len(months) != len(want) {
+		t.Fatalf("monthlyTotals() = %v, want %v", months, want)
+	}
+	for i := range want {
+		if months[i] != want[i] {
+			t.Errorf("months[%d] = %v, want %v", i, months[i], want[i])
+		}
+	}
+}
+
+func TestPrintMonthlyCSV(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printMonthlyCSV(buf, []monthTotal{
+		{Month: "2026-01", Count: 2, Total: 20.5},
+		{Month: "2026-02", Count: 1, Total: 3},
+	})
+
+	want := "Month,Count,Total\n2026-01,2,20.50\n2026-02,1,3.00\n"
+	if buf.String() != want {
+		t.Errorf("printMonthlyCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintMonthlyChart(t *testing.T) {
+	buf := new(bytes.Buffer)
+	formatter := format.NewAmountFormatter("en_US", "")
+	printMonthlyChart(buf, []monthTotal{
+		{Month: "2026-01", Count: 1, Total: 100},
+		{Month: "2026-02", Count: 1, Total: 50},
+		{Month: "2026-03", Count: 1, Total: 0},
+	}, formatter, "", 40) // no locale: YYYY-MM labels
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+
+	// Width 40 leaves 40 - 7 - 6 - 4 = 23 columns for the bar
+	if got := strings.Count(lines[0], "#"); got != 23 {
+		t.Errorf("Largest month bar = %d, want 23", got)
+	}
+	if got := strings.Count(lines[1], "#"); got != 11 {
+		t.Errorf("Half month bar = %d, want 11", got)
+	}
+	if strings.Contains(lines[2], "#") {
+		t.Errorf("Zero month should have no bar, got: %s", lines[2])
+	}
+	for _, line := range lines {
+		if len(line) > 40 {
+			t.Errorf("Line exceeds chart width: %q", line)
+		}
+	}
+}
+
+func TestPrintMonthlyChartLocalized(t *testing.T) {
+	buf := new(bytes.Buffer)
+	formatter := format.NewAmountFormatter("fr_FR", "")
+	printMonthlyChart(buf, []monthTotal{
+		{Month: "2026-05", Count: 1, Total: 100},
+		{Month: "2026-09", Count: 1, Total: 50},
+	}, formatter, "fr_FR", 40)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "mai 2026        ") || !strings.HasPrefix(lines[1], "septembre 2026  ") {
+		t.Fatalf("Expected aligned French month names, got:\n%s", buf.String())
+	}
+	// Width 40 leaves 40 - 14 - 6 - 4 = 16 columns for the bar
+	if got := strings.Count(lines[0], "#"); got != 16 {
+		t.Errorf("Largest month bar = %d, want 16", got)
+	}
+}
+
+func TestStatsCommandFlagValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"csv without by-month", []string{"--format", "csv"}},
+		{"chart without by-month", []string{"--chart"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+			defer resetStatsFlags()
+
+			ProjectID = "test-project"
+			cmd := NewStatsCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+
+			if err := cmd.Execute(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func resetStatsFlags() {
+	statsFormat = "table"
+	statsByMonth = false
+	statsChart = false
+}
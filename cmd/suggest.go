@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// maxSuggestions is the most "Did you mean" suggestions shown for a typo
+const maxSuggestions = 3
+
+// projectNotFoundError is returned when --project names a project that
+// doesn't exist, with the IDs of similarly named projects
+type projectNotFoundError struct {
+	ID          string
+	Suggestions []string
+}
+
+func (e *projectNotFoundError) Error() string {
+	msg := fmt.Sprintf("project %q not found", e.ID)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(". Did you mean: %s?", strings.Join(e.Suggestions, ", "))
+	}
+	return msg
+}
+
+func (e *projectNotFoundError) Unwrap() error {
+	return api.ErrProjectNotFound
+}
+
+// CompleteProjectIDs completes --project with the IDs of the user's projects,
+// described by their names. Nothing is completed when the projects can't be
+// fetched.
+func CompleteProjectIDs(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config.SetConfigPath(ConfigFile)
+	config.SetEnvFile(EnvFile)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	projects, err := newClient(cmd, cfg).GetProjects()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, p := range projects {
+		if strings.HasPrefix(strings.ToLower(p.ID), strings.ToLower(toComplete)) {
+			ids = append(ids, p.ID+"\t"+p.Name)
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// suggestProjects turns a not-found error for projectID into one that lists
+// the closest project IDs. Other errors are returned unchanged.
+func suggestProjects(client *api.Client, projectID string, err error) error {
+	if !errors.Is(err, api.ErrProjectNotFound) {
+		return err
+	}
+
+	notFound := &projectNotFoundError{ID: projectID}
+
+	// Suggestions are best-effort; without the project list, just report the ID
+	projects, listErr := client.GetProjects()
+	if listErr != nil {
+		return notFound
+	}
+
+	// Match on both the ID and the name, but always suggest the ID
+	distances := make(map[string]int)
+	for _, p := range projects {
+		d := min(editDistance(projectID, p.ID), editDistance(projectID, p.Name))
+		if d <= maxEditDistance(projectID) {
+			distances[p.ID] = d
+		}
+	}
+	for id := range distances {
+		notFound.Suggestions = append(notFound.Suggestions, id)
+	}
+	sort.Slice(notFound.Suggestions, func(i, j int) bool {
+		a, b := notFound.Suggestions[i], notFound.Suggestions[j]
+		if distances[a] != distances[b] {
+			return distances[a] < distances[b]
+		}
+		return a < b
+	})
+	if len(notFound.Suggestions) > maxSuggestions {
+		notFound.Suggestions = notFound.Suggestions[:maxSuggestions]
+	}
+	return notFound
+}
+
+// maxEditDistance is how many edits a suggestion may be from query: about
+// one per three characters, so short queries don't match everything
+func maxEditDistance(query string) int {
+	return max(1, len([]rune(query))/3)
+}
+
+// editDistance returns the case-insensitive Levenshtein distance between a
+// and b: the number of rune insertions, deletions, and substitutions needed
+// to turn one into the other.
+func editDistance(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	// Keep a single row of the distance matrix
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			next := min(row[j]+1, row[j-1]+1, diag+cost)
+			diag = row[j]
+			row[j] = next
+		}
+	}
+	return row[len(rb)]
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"myproject", "myproject", 0},
+		{"myprojct", "myproject", 1},
+		{"MyProject", "myproject", 0},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// newProjectNotFoundServer returns a server where every project is missing
+// and the project list has the given summaries
+func newProjectNotFoundServer(projects []api.ProjectSummary) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocs/v2.php/apps/cospend/api/v1/projects":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, projects))
+		case "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestProjectNotFoundSuggestions(t *testing.T) {
+	server := newProjectNotFoundServer([]api.ProjectSummary{
+		{ID: "myproject", Name: "My Project"},
+		{ID: "myproject2", Name: "Second"},
+		{ID: "trip", Name: "Road Trip"},
+		{ID: "household", Name: "Household"},
+	})
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		project string
+		command func() *cobra.Command
+		args    []string
+		want    string
+	}{
+		{"list typo", "myprojct", NewListCommand, nil, `project "myprojct" not found. Did you mean: myproject, myproject2?`},
+		{"add typo", "housheold", NewAddCommand, []string{"Milk", "3"}, `project "housheold" not found. Did you mean: household?`},
+		{"info by name", "road trp", NewInfoCommand, nil, `project "road trp" not found. Did you mean: trip?`},
+		{"no close match", "groceries", NewListCommand, nil, `project "groceries" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+			resetFlags()
+
+			ProjectID = tt.project
+			cmd := tt.command()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("Execute() error = %v, want %q", err, tt.want)
+			}
+			if !errors.Is(err, api.ErrProjectNotFound) {
+				t.Errorf("Error should wrap api.ErrProjectNotFound: %v", err)
+			}
+		})
+	}
+}
+
+func TestCompleteProjectIDs(t *testing.T) {
+	server := newProjectNotFoundServer([]api.ProjectSummary{
+		{ID: "myproject", Name: "My Project"},
+		{ID: "trip", Name: "Road Trip"},
+	})
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	got, directive := CompleteProjectIDs(&cobra.Command{}, nil, "MY")
+	if len(got) != 1 || got[0] != "myproject\tMy Project" {
+		t.Errorf("CompleteProjectIDs() = %q, want [myproject\\tMy Project]", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want NoFileComp", directive)
+	}
+}
@@ -28,6 +28,7 @@ type Table struct {
 	headers   []string
 	rows      [][]string
 	colWidths []int
+	padding   int
 }
 
 // NewTable creates a new table with the given headers
@@ -39,9 +40,15 @@ func NewTable(headers ...string) *Table {
 	return &Table{
 		headers:   headers,
 		colWidths: colWidths,
+		padding:   1,
 	}
 }
 
+// SetPadding sets the number of spaces on each side of a cell (default 1)
+func (t *Table) SetPadding(n int) {
+	t.padding = max(n, 0)
+}
+
 // AddRow adds a row to the table
 func (t *Table) AddRow(values ...string) {
 	// Pad with empty strings if needed
@@ -79,7 +86,7 @@ func (t *Table) Render(w io.Writer) {
 func (t *Table) printBorder(w io.Writer, left, mid, right string) {
 	_, _ = fmt.Fprint(w, left)
 	for i, width := range t.colWidths {
-		_, _ = fmt.Fprint(w, strings.Repeat(borderHorizontal, width+2))
+		_, _ = fmt.Fprint(w, strings.Repeat(borderHorizontal, width+2*t.padding))
 		if i < len(t.colWidths)-1 {
 			_, _ = fmt.Fprint(w, mid)
 		}
@@ -88,10 +95,11 @@ func (t *Table) printBorder(w io.Writer, left, mid, right string) {
 }
 
 func (t *Table) printRow(w io.Writer, values []string) {
+	pad := strings.Repeat(" ", t.padding)
 	_, _ = fmt.Fprint(w, borderVertical)
 	for i, val := range values {
 		padded := runewidth.FillRight(val, t.colWidths[i])
-		_, _ = fmt.Fprintf(w, " %s %s", padded, borderVertical)
+		_, _ = fmt.Fprintf(w, "%s%s%s%s", pad, padded, pad, borderVertical)
 	}
 	_, _ = fmt.Fprintln(w)
 }
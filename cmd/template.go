@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// projectTemplate is the setup of a project that can be applied to another:
+// everything but its bills
+type projectTemplate struct {
+	Categories   []templateStyled   `json:"categories"`
+	PaymentModes []templateStyled   `json:"payment_modes"`
+	Currencies   []templateCurrency `json:"currencies"`
+	Members      []templateMember   `json:"members"`
+}
+
+// templateStyled is a category or payment mode in a template
+type templateStyled struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+type templateCurrency struct {
+	Name         string  `json:"name"`
+	ExchangeRate float64 `json:"exchange_rate"`
+}
+
+type templateMember struct {
+	Name   string `json:"name"`
+	UserID string `json:"userid,omitempty"`
+}
+
+func newProjectsTemplateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Export or apply a project template",
+		Long: `Copy the setup of one project to another. A template holds a project's
+categories, payment methods, currencies, and active members, but no bills.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "export <project>",
+		Short: "Print a project's template as JSON",
+		Long: `Print the categories, payment methods, currencies, and active members of a
+project as JSON.
+
+Examples:
+  cospend projects template export trip > template.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTemplateExport,
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "apply <project> <file>",
+		Short: "Create a template's entities in a project",
+		Long: `Create the categories, payment methods, currencies, and members of a
+template in a project. Entities the project already has, by name, are
+skipped, so applying a template twice is harmless. Use "-" to read the
+template from stdin.
+
+Examples:
+  cospend projects template apply newtrip template.json
+  cospend projects template export trip | cospend projects template apply newtrip -`,
+		Args: cobra.ExactArgs(2),
+		RunE: runTemplateApply,
+	})
+
+	return cmd
+}
+
+func runTemplateExport(cmd *cobra.Command, args []string) error {
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	project, err := loadProject(cmd, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(templateFromProject(project))
+}
+
+func runTemplateApply(cmd *cobra.Command, args []string) error {
+	projectID := args[0]
+
+	// Parameters validated, silence usage for subsequent errors
+	cmd.SilenceUsage = true
+
+	tmpl, err := readTemplateFile(cmd, args[1])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	// Skipping by name needs the current entities, not a cached copy
+	stop := startSpinner(cmd.ErrOrStderr(), "Fetching project...")
+	project, err := client.GetProject(projectID)
+	stop()
+	if err != nil {
+		return fmt.Errorf("fetching project: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	created, skipped := 0, 0
+	// report prints the outcome of one entity
+	report := func(kind, name string, exists bool) {
+		if exists {
+			skipped++
+			_, _ = fmt.Fprintf(out, "Skipped %s %s (already exists)\n", kind, name)
+			return
+		}
+		created++
+		_, _ = fmt.Fprintf(out, "Created %s %s\n", kind, name)
+	}
+	// Whatever was created is stale in the cache, even if a later step fails
+	defer func() {
+		if created == 0 {
+			return
+		}
+		if err := cache.Invalidate(projectID); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear project cache: %v\n", err)
+		}
+	}()
+
+	categories := names{}
+	for _, c := range project.Categories {
+		categories.add(c.Name)
+	}
+	for _, c := range tmpl.Categories {
+		exists := !categories.add(c.Name)
+		if !exists {
+			if _, err := client.CreateCategory(projectID, api.Category{Name: c.Name, Icon: c.Icon, Color: c.Color}); err != nil {
+				return fmt.Errorf("creating category %s: %w", c.Name, err)
+			}
+		}
+		report("category", c.Name, exists)
+	}
+
+	paymentModes := names{}
+	for _, pm := range project.PaymentModes {
+		paymentModes.add(pm.Name)
+	}
+	for _, pm := range tmpl.PaymentModes {
+		exists := !paymentModes.add(pm.Name)
+		if !exists {
+			if _, err := client.CreatePaymentMode(projectID, api.PaymentMode{Name: pm.Name, Icon: pm.Icon, Color: pm.Color}); err != nil {
+				return fmt.Errorf("creating payment method %s: %w", pm.Name, err)
+			}
+		}
+		report("payment method", pm.Name, exists)
+	}
+
+	currencies := names{}
+	for _, cur := range project.Currencies {
+		currencies.add(cur.Name)
+	}
+	for _, cur := range tmpl.Currencies {
+		exists := !currencies.add(cur.Name)
+		if !exists {
+			if _, err := client.CreateCurrency(projectID, cur.Name, cur.ExchangeRate); err != nil {
+				return fmt.Errorf("creating currency %s: %w", cur.Name, err)
+			}
+		}
+		report("currency", cur.Name, exists)
+	}
+
+	members := names{}
+	for _, m := range project.Members {
+		members.add(m.Name)
+	}
+	for _, m := range tmpl.Members {
+		exists := !members.add(m.Name)
+		if !exists {
+			if _, err := client.CreateMember(projectID, m.Name, m.UserID); err != nil {
+				return fmt.Errorf("creating member %s: %w", m.Name, err)
+			}
+		}
+		report("member", m.Name, exists)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nCreated %d, skipped %d\n", created, skipped)
+	return nil
+}
+
+// templateFromProject returns the template of project. Deactivated members
+// are left out.
+func templateFromProject(project *api.Project) projectTemplate {
+	tmpl := projectTemplate{
+		Categories:   []templateStyled{},
+		PaymentModes: []templateStyled{},
+		Currencies:   []templateCurrency{},
+		Members:      []templateMember{},
+	}
+	for _, c := range project.Categories {
+		tmpl.Categories = append(tmpl.Categories, templateStyled{Name: c.Name, Icon: c.Icon, Color: c.Color})
+	}
+	for _, pm := range project.PaymentModes {
+		tmpl.PaymentModes = append(tmpl.PaymentModes, templateStyled{Name: pm.Name, Icon: pm.Icon, Color: pm.Color})
+	}
+	for _, cur := range project.Currencies {
+		tmpl.Currencies = append(tmpl.Currencies, templateCurrency{Name: cur.Name, ExchangeRate: cur.ExchangeRate})
+	}
+	for _, m := range project.Members {
+		if m.Activated {
+			tmpl.Members = append(tmpl.Members, templateMember{Name: m.Name, UserID: m.UserID})
+		}
+	}
+	return tmpl
+}
+
+// readTemplateFile reads a project template from path, or from stdin when path is "-"
+func readTemplateFile(cmd *cobra.Command, path string) (*projectTemplate, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+
+	var tmpl projectTemplate
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// names is a set of entity names, compared ignoring case and surrounding spaces
+type names map[string]bool
+
+// add adds name to the set and reports whether it wasn't there yet
+func (n names) add(name string) bool {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if n[key] {
+		return false
+	}
+	n[key] = true
+	return true
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+)
+
+func TestTemplateFromProject(t *testing.T) {
+	project := &api.Project{
+		Categories:   []api.Category{{ID: 1, Name: "Food", Icon: "🍔", Color: "#ff0000"}},
+		PaymentModes: []api.PaymentMode{{ID: 2, Name: "Cash", OldID: "c"}},
+		Currencies:   []api.Currency{{ID: 3, Name: "EUR", ExchangeRate: 1.1}},
+		Members: []api.Member{
+			{ID: 1, Name: "alice", UserID: "alice", Activated: true},
+			{ID: 2, Name: "bob", Activated: false},
+		},
+	}
+
+	tmpl := templateFromProject(project)
+	if len(tmpl.Categories) != 1 || tmpl.Categories[0] != (templateStyled{Name: "Food", Icon: "🍔", Color: "#ff0000"}) {
+		t.Errorf("Categories = %+v", tmpl.Categories)
+	}
+	if len(tmpl.PaymentModes) != 1 || tmpl.PaymentModes[0].Name != "Cash" {
+		t.Errorf("PaymentModes = %+v", tmpl.PaymentModes)
+	}
+	if len(tmpl.Currencies) != 1 || tmpl.Currencies[0] != (templateCurrency{Name: "EUR", ExchangeRate: 1.1}) {
+		t.Errorf("Currencies = %+v", tmpl.Currencies)
+	}
+	if len(tmpl.Members) != 1 || tmpl.Members[0] != (templateMember{Name: "alice", UserID: "alice"}) {
+		t.Errorf("Members = %+v, want only the active member", tmpl.Members)
+	}
+}
+
+func TestTemplateApply(t *testing.T) {
+	project := api.Project{
+		ID:           "newtrip",
+		Name:         "New Trip",
+		Categories:   []api.Category{{ID: 1, Name: "food"}},
+		PaymentModes: []api.PaymentMode{{ID: 1, Name: "Cash"}},
+		Members:      []api.Member{{ID: 1, Name: "Alice", Activated: true}},
+	}
+
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/ocs/v2.php/apps/cospend/api/v1/projects/newtrip"
+		switch {
+		case r.Method == "POST":
+			_ = r.ParseForm()
+			created = append(created, strings.TrimPrefix(r.URL.Path, prefix+"/")+":"+r.PostForm.Get("name"))
+			if strings.HasSuffix(r.URL.Path, "/members") {
+				_ = json.NewEncoder(w).Encode(makeOCSResponse(200, api.Member{ID: 5}))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 5))
+		case r.URL.Path == prefix:
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "template.json")
+	tmpl := `{
+  "categories": [{"name": "Food"}, {"name": "Transport", "icon": "🚕"}],
+  "payment_modes": [{"name": "cash"}, {"name": "Card"}],
+  "currencies": [{"name": "EUR", "exchange_rate": 1.1}],
+  "members": [{"name": "alice"}, {"name": "bob", "userid": "bob"}]
+}`
+	if err := os.WriteFile(path, []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewProjectsCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"template", "apply", "newtrip", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("template apply failed: %v", err)
+	}
+
+	want := []string{"category:Transport", "paymode:Card", "currency:EUR", "members:bob"}
+	if strings.Join(created, " ") != strings.Join(want, " ") {
+		t.Errorf("Created %v, want %v", created, want)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Skipped category Food (already exists)") {
+		t.Errorf("Expected existing category to be skipped, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Created 4, skipped 3") {
+		t.Errorf("Expected summary, got:\n%s", output)
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+	"github.com/chenasraf/cospend-cli/internal/config"
+	"github.com/chenasraf/cospend-cli/internal/format"
+	"github.com/spf13/cobra"
+)
+
+var undoYes bool
+
+// NewUndoCommand creates the undo command
+func NewUndoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Undo the last add or delete in a project",
+		Long: `Undo the last bill added to or deleted from a Cospend project from this machine.
+
+An added (or copied) bill is deleted again, and a deleted bill is recreated
+with its details. A recreated bill gets a new ID. Only the latest action can
+be undone, and only once.
+
+Examples:
+  cospend undo -p myproject
+  cospend undo -p myproject --yes`,
+		Args: cobra.NoArgs,
+		RunE: runUndo,
+	}
+
+	cmd.Flags().BoolVarP(&undoYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func runUndo(cmd *cobra.Command, _ []string) error {
+	if ProjectID == "" {
+		return fmt.Errorf("project is required (use -p or --project)")
+	}
+
+	cmd.SilenceUsage = true
+
+	out := cmd.OutOrStdout()
+	action, ok := cache.LoadLastAction(ProjectID)
+	if !ok {
+		_, _ = fmt.Fprintln(out, "Nothing to undo.")
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd, cfg)
+
+	var question string
+	switch action.Kind {
+	case cache.ActionAdd:
+		question = fmt.Sprintf("Delete bill #%d, added %s?", action.BillID, action.At.Local().Format("2006-01-02 15:04"))
+	case cache.ActionDelete:
+		project, err := loadProject(cmd, client, ProjectID)
+		if err != nil {
+			return err
+		}
+		formatter := format.NewAmountFormatter(loadLocale(cmd, client, cfg), displayCurrency(cfg, project))
+		bill := action.Bill
+		_, _ = fmt.Fprintf(out, "Deleted bill #%d:\n", bill.ID)
+		_, _ = fmt.Fprintf(out, "  Name:     %s\n", bill.What)
+		_, _ = fmt.Fprintf(out, "  Amount:   %s\n", formatter.Format(bill.Amount))
+		_, _ = fmt.Fprintf(out, "  Date:     %s\n", bill.Date)
+		question = fmt.Sprintf("Recreate bill #%d?", bill.ID)
+	}
+
+	if !undoYes && !confirm(cmd.InOrStdin(), out, question) {
+		_, _ = fmt.Fprintln(out, "Cancelled.")
+		return nil
+	}
+
+	switch action.Kind {
+	case cache.ActionAdd:
+		err := client.DeleteBill(ProjectID, action.BillID)
+		if errors.Is(err, api.ErrBillNotFound) {
+			_, _ = fmt.Fprintf(out, "Bill #%d was already deleted\n", action.BillID)
+		} else if err != nil {
+			return fmt.Errorf("deleting bill: %w", err)
+		} else {
+			_, _ = fmt.Fprintf(out, "Deleted bill #%d\n", action.BillID)
+		}
+	case cache.ActionDelete:
+		newID, err := client.CreateBill(ProjectID, billFromResponse(action.Bill))
+		if err != nil {
+			return fmt.Errorf("recreating bill: %w", err)
+		}
+		_, _ = fmt.Fprintf(out, "Recreated bill #%d as #%d\n", action.BillID, newID)
+	}
+
+	if err := cache.ClearLastAction(ProjectID); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to clear undo information: %v\n", err)
+	}
+	return nil
+}
+
+// billFromResponse converts a fetched bill back into one that can be created
+func billFromResponse(bill *api.BillResponse) api.Bill {
+	created := api.Bill{
+		What:               bill.What,
+		Amount:             bill.Amount,
+		PayerID:            bill.PayerID,
+		Date:               bill.Date,
+		Comment:            bill.Comment,
+		PaymentModeID:      bill.PaymentModeID,
+		CategoryID:         bill.CategoryID,
+		Repeat:             bill.Repeat,
+		OriginalCurrencyID: bill.OriginalCurrencyID,
+	}
+	for _, o := range bill.Owers {
+		created.OwedTo = append(created.OwedTo, o.ID)
+	}
+	return created
+}
+
+// saveLastAction records action so 'cospend undo' can reverse it. Failing to
+// save only loses the undo, so it's a warning.
+func saveLastAction(cmd *cobra.Command, action cache.LastAction) {
+	action.At = time.Now()
+	if err := cache.SaveLastAction(ProjectID, action); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to save undo information: %v\n", err)
+	}
+}
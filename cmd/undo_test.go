@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/cache"
+)
+
+// setupUndoServer serves a project and records the bills deleted and the form
+// of the bill created
+func setupUndoServer(t *testing.T) (*httptest.Server, *[]string, *url.Values) {
+	t.Helper()
+
+	project := api.Project{
+		ID:           "test-project",
+		Name:         "Test Project",
+		CurrencyName: "USD",
+		Members:      []api.Member{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}},
+	}
+
+	var deleted []string
+	created := &url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, "OK"))
+		case r.Method == "POST" && r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills":
+			_ = r.ParseForm()
+			*created = r.PostForm
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, 99))
+		case r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, project))
+		case r.URL.Path == "/ocs/v2.php/cloud/user":
+			_ = json.NewEncoder(w).Encode(makeOCSResponse(200, map[string]string{"locale": "en_US"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &deleted, created
+}
+
+func runUndoCommand(t *testing.T, input string, args ...string) string {
+	t.Helper()
+
+	cmd := NewUndoCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("undo failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestUndoAdd(t *testing.T) {
+	server, deleted, _ := setupUndoServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+	defer func() { undoYes = false }()
+
+	ProjectID = "test-project"
+	if err := cache.SaveLastAction(ProjectID, cache.LastAction{Kind: cache.ActionAdd, BillID: 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Declining leaves the bill and the undo in place
+	output := runUndoCommand(t, "n\n")
+	if !strings.Contains(output, "Delete bill #42") || !strings.Contains(output, "Cancelled.") {
+		t.Errorf("Expected a cancelled confirmation, got:\n%s", output)
+	}
+	if len(*deleted) != 0 {
+		t.Fatalf("Nothing should be deleted when cancelled, deleted %v", *deleted)
+	}
+
+	output = runUndoCommand(t, "", "--yes")
+	if len(*deleted) != 1 || !strings.HasSuffix((*deleted)[0], "/bills/42") {
+		t.Errorf("Expected bill 42 to be deleted, deleted %v", *deleted)
+	}
+	if !strings.Contains(output, "Deleted bill #42") {
+		t.Errorf("Expected deletion message, got:\n%s", output)
+	}
+
+	// An action is only undone once
+	output = runUndoCommand(t, "", "--yes")
+	if !strings.Contains(output, "Nothing to undo.") {
+		t.Errorf("Expected nothing left to undo, got:\n%s", output)
+	}
+}
+
+func TestUndoDelete(t *testing.T) {
+	server, _, created := setupUndoServer(t)
+	defer server.Close()
+
+	cleanup := setupTestEnv(t, server.URL)
+	defer cleanup()
+
+	ProjectID = "test-project"
+	bill := &api.BillResponse{
+		ID:                 10,
+		What:               "Dinner",
+		Amount:             60,
+		Date:               "2026-03-01",
+		PayerID:            1,
+		Owers:              []api.Ower{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}},
+		Comment:            "Birthday",
+		CategoryID:         3,
+		OriginalCurrencyID: 4,
+	}
+	if err := cache.SaveLastAction(ProjectID, cache.LastAction{Kind: cache.ActionDelete, BillID: 10, Bill: bill}); err != nil {
+		t.Fatal(err)
+	}
+
+	output := runUndoCommand(t, "y\n")
+	if !strings.Contains(output, "Dinner") || !strings.Contains(output, "Recreated bill #10 as #99") {
+		t.Errorf("Expected the bill preview and recreation message, got:\n%s", output)
+	}
+
+	want := map[string]string{
+		"what":                 "Dinner",
+		"amount":               "60.00",
+		"date":                 "2026-03-01",
+		"payer":                "1",
+		"payedFor":             "1,2",
+		"comment":              "Birthday",
+		"categoryId":           "3",
+		"original_currency_id": "4",
+	}
+	for key, value := range want {
+		if got := created.Get(key); got != value {
+			t.Errorf("Created %s = %q, want %q", key, got, value)
+		}
+	}
+
+	if _, ok := cache.LoadLastAction(ProjectID); ok {
+		t.Error("Undo information should be cleared after undoing")
+	}
+}
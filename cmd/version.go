@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+var versionJSON bool
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// NewVersionCommand creates the version command. Commit and build date are
+// normally injected at build time via -ldflags; when missing, the commit is
+// taken from the VCS info embedded by the Go toolchain.
+func NewVersionCommand(version, commit, buildDate string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Long: `Show the version, git commit, build date, Go version, and platform.
+
+Include this output when reporting bugs.
+
+Examples:
+  cospend version
+  cospend version --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runVersion(cmd, newBuildInfo(version, commit, buildDate))
+		},
+	}
+
+	cmd.Flags().BoolVar(&versionJSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newBuildInfo(version, commit, buildDate string) BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
+
+func runVersion(cmd *cobra.Command, info BuildInfo) error {
+	cmd.SilenceUsage = true
+
+	out := cmd.OutOrStdout()
+
+	if versionJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	_, _ = fmt.Fprintf(out, "cospend %s\n", info.Version)
+	_, _ = fmt.Fprintf(out, "  commit:   %s\n", info.Commit)
+	_, _ = fmt.Fprintf(out, "  built:    %s\n", info.BuildDate)
+	_, _ = fmt.Fprintf(out, "  go:       %s\n", info.GoVersion)
+	_, _ = fmt.Fprintf(out, "  platform: %s/%s\n", info.OS, info.Arch)
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestVersionCommand(t *testing.T) {
+	versionJSON = false
+	cmd := NewVersionCommand("1.2.3", "abc1234", "2026-01-02T03:04:05Z")
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"cospend 1.2.3", "abc1234", "2026-01-02T03:04:05Z", runtime.Version(), runtime.GOOS + "/" + runtime.GOARCH} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestVersionCommandJSON(t *testing.T) {
+	versionJSON = false
+	defer func() { versionJSON = false }()
+
+	cmd := NewVersionCommand("1.2.3", "abc1234", "2026-01-02T03:04:05Z")
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var info BuildInfo
+	if err := json.Unmarshal(stdout.Bytes(), &info); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	if info.Version != "1.2.3" || info.Commit != "abc1234" || info.BuildDate != "2026-01-02T03:04:05Z" {
+		t.Errorf("Unexpected build info: %+v", info)
+	}
+	if info.OS != runtime.GOOS || info.Arch != runtime.GOARCH {
+		t.Errorf("Platform = %s/%s, want %s/%s", info.OS, info.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+}
+
+func TestNewBuildInfoDefaults(t *testing.T) {
+	info := newBuildInfo("1.2.3", "", "")
+	if info.Commit == "" || info.BuildDate == "" {
+		t.Errorf("Missing commit/build date should fall back, got %+v", info)
+	}
+}
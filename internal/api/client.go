@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,16 +20,61 @@ import (
 type Client struct {
 	config      *config.Config
 	httpClient  *http.Client
-	Debug       bool
+	Verbosity   int
 	DebugWriter io.Writer
+	// RawResponseWriter, when set, receives every response body, pretty-printed
+	// if it's JSON, before it's decoded
+	RawResponseWriter io.Writer
 }
 
+// ErrUnauthorized is returned when the server rejects the configured credentials
+var ErrUnauthorized = errors.New("the server rejected the username or app password")
+
+// ErrProjectNotFound is returned when a project doesn't exist or isn't shared with the user
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrBillNotFound is returned when a bill doesn't exist in the project
+var ErrBillNotFound = errors.New("bill not found")
+
+// ErrAPI matches the errors the server reports for a request, like an
+// unexpected HTTP status or a failed OCS response
+var ErrAPI = errors.New("API error")
+
+// apiError is an error reported by the server. It matches ErrAPI.
+type apiError struct {
+	msg string
+}
+
+func (e *apiError) Error() string {
+	return e.msg
+}
+
+func (e *apiError) Is(target error) bool {
+	return target == ErrAPI
+}
+
+// CategoryReimbursement is the ID of Cospend's built-in Reimbursement category,
+// which marks bills that pay back a debt. It isn't listed in a project's
+// categories.
+const CategoryReimbursement = -11
+
+// Verbosity levels for debug output
+const (
+	// VerbosityRequests logs request and response lines
+	VerbosityRequests = 1
+	// VerbosityBodies also logs request bodies and response data
+	VerbosityBodies = 2
+)
+
 // Member represents a project member
 type Member struct {
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	UserID    string `json:"userid"`
-	Activated bool   `json:"activated"`
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	UserID    string  `json:"userid"`
+	Activated bool    `json:"activated"`
+	Weight    float64 `json:"weight"`
+	// Balance is what the member is owed (positive) or owes (negative)
+	Balance float64 `json:"balance"`
 }
 
 // Category represents a bill category
@@ -44,6 +91,29 @@ type PaymentMode struct {
 	Name  string `json:"name"`
 	Icon  string `json:"icon"`
 	Color string `json:"color"`
+	// OldID is the letter identifying one of Cospend's built-in payment modes
+	// (e.g. "c" for cash). It's empty for custom modes and on servers that
+	// don't report it.
+	OldID string `json:"old_id,omitempty"`
+}
+
+// UnmarshalJSON ignores an old_id that isn't a string, so a server that sends
+// it as null or a number doesn't break parsing the payment modes
+func (pm *PaymentMode) UnmarshalJSON(data []byte) error {
+	type plain PaymentMode
+	aux := struct {
+		*plain
+		OldID json.RawMessage `json:"old_id"`
+	}{plain: (*plain)(pm)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var oldID string
+	if json.Unmarshal(aux.OldID, &oldID) == nil {
+		pm.OldID = oldID
+	}
+	return nil
 }
 
 // Currency represents a currency
@@ -62,6 +132,15 @@ type Project struct {
 	Categories   []Category    // custom unmarshal
 	PaymentModes []PaymentMode // custom unmarshal
 	Currencies   []Currency    `json:"currencies"`
+	// DeletionDisabled is set when the project doesn't allow deleting bills
+	DeletionDisabled bool // custom unmarshal
+	// ArchivedTS is when the project was archived, nil while it's active
+	ArchivedTS *int64 `json:"archived_ts"`
+}
+
+// IsArchived returns true if the project is archived
+func (p *Project) IsArchived() bool {
+	return p.ArchivedTS != nil
 }
 
 // UnmarshalJSON custom unmarshaler to handle categories/paymentmodes as object or array
@@ -88,6 +167,24 @@ func (p *Project) UnmarshalJSON(data []byte) error {
 	if v, ok := raw["currencies"]; ok {
 		_ = json.Unmarshal(v, &p.Currencies)
 	}
+	if v, ok := raw["deletiondisabled"]; ok {
+		p.DeletionDisabled = parseFlag(v)
+	}
+	if v, ok := raw["archived_ts"]; ok {
+		_ = json.Unmarshal(v, &p.ArchivedTS)
+	}
+
+	// Balances come as a project-level object keyed by member ID
+	if v, ok := raw["balance"]; ok {
+		var balances map[string]float64
+		if json.Unmarshal(v, &balances) == nil {
+			for i, m := range p.Members {
+				if b, ok := balances[strconv.Itoa(m.ID)]; ok {
+					p.Members[i].Balance = b
+				}
+			}
+		}
+	}
 
 	// Parse categories (can be array or object)
 	if v, ok := raw["categories"]; ok {
@@ -105,24 +202,38 @@ func (p *Project) UnmarshalJSON(data []byte) error {
 // MarshalJSON custom marshaler for Project
 func (p Project) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		ID           string        `json:"id"`
-		Name         string        `json:"name"`
-		CurrencyName string        `json:"currencyname"`
-		Members      []Member      `json:"members"`
-		Categories   []Category    `json:"categories"`
-		PaymentModes []PaymentMode `json:"paymentmodes"`
-		Currencies   []Currency    `json:"currencies"`
+		ID               string        `json:"id"`
+		Name             string        `json:"name"`
+		CurrencyName     string        `json:"currencyname"`
+		Members          []Member      `json:"members"`
+		Categories       []Category    `json:"categories"`
+		PaymentModes     []PaymentMode `json:"paymentmodes"`
+		Currencies       []Currency    `json:"currencies"`
+		DeletionDisabled bool          `json:"deletiondisabled"`
+		ArchivedTS       *int64        `json:"archived_ts"`
 	}{
-		ID:           p.ID,
-		Name:         p.Name,
-		CurrencyName: p.CurrencyName,
-		Members:      p.Members,
-		Categories:   p.Categories,
-		PaymentModes: p.PaymentModes,
-		Currencies:   p.Currencies,
+		ID:               p.ID,
+		Name:             p.Name,
+		CurrencyName:     p.CurrencyName,
+		Members:          p.Members,
+		Categories:       p.Categories,
+		PaymentModes:     p.PaymentModes,
+		Currencies:       p.Currencies,
+		DeletionDisabled: p.DeletionDisabled,
+		ArchivedTS:       p.ArchivedTS,
 	})
 }
 
+// parseFlag reads a project flag, which servers send as a boolean or as 0/1
+func parseFlag(data json.RawMessage) bool {
+	var b bool
+	if json.Unmarshal(data, &b) == nil {
+		return b
+	}
+	var n float64
+	return json.Unmarshal(data, &n) == nil && n != 0
+}
+
 func parseCategories(data json.RawMessage) []Category {
 	// API returns categories as object keyed by ID
 	var obj map[string]Category
@@ -203,6 +314,10 @@ type BillResponse struct {
 	CategoryID    int     `json:"categoryid"`
 	Repeat        string  `json:"repeat"`
 	Timestamp     int64   `json:"timestamp"`
+	// OriginalCurrencyID is the project currency the bill was entered in
+	// before conversion. It's 0 for bills in the main currency and on servers
+	// that don't report it.
+	OriginalCurrencyID int `json:"original_currency_id"`
 }
 
 // Ower represents a member who owes part of a bill
@@ -223,6 +338,28 @@ type OCSResponse struct {
 	} `json:"ocs"`
 }
 
+// OK reports whether the OCS meta indicates success. Some endpoints return 2xx
+// codes other than 200 (e.g. 201 on create), so either an "ok" status or any
+// 2xx status code is accepted.
+func (r *OCSResponse) OK() bool {
+	return strings.EqualFold(r.OCS.Meta.Status, "ok") || isSuccess(r.OCS.Meta.StatusCode)
+}
+
+// statusError reports an unexpected HTTP status, with the redacted body
+func (c *Client) statusError(status int, body []byte) error {
+	return &apiError{msg: fmt.Sprintf("API returned status %d: %s", status, c.redact(string(body)))}
+}
+
+// ocsError reports a failed OCS response, with its redacted message
+func (c *Client) ocsError(message string) error {
+	return &apiError{msg: "API error: " + c.redact(message)}
+}
+
+// isSuccess reports whether code is a 2xx status code
+func isSuccess(code int) bool {
+	return code >= 200 && code < 300
+}
+
 // NewClient creates a new API client
 func NewClient(cfg *config.Config) *Client {
 	return &Client{
@@ -231,17 +368,52 @@ func NewClient(cfg *config.Config) *Client {
 	}
 }
 
-func (c *Client) debugf(format string, args ...interface{}) {
-	if c.Debug && c.DebugWriter != nil {
-		_, _ = fmt.Fprintf(c.DebugWriter, "[DEBUG] "+format+"\n", args...)
+// debugf logs a message when the client's verbosity is at least level.
+// Credentials are redacted from the output.
+func (c *Client) debugf(level int, format string, args ...interface{}) {
+	if c.Verbosity >= level && c.DebugWriter != nil {
+		_, _ = fmt.Fprintf(c.DebugWriter, "[DEBUG] %s\n", c.redact(fmt.Sprintf(format, args...)))
+	}
+}
+
+// dumpResponse writes a response body to RawResponseWriter, headed by the
+// request it answers. JSON bodies are indented; anything else is written as-is.
+func (c *Client) dumpResponse(method, fullURL string, status int, body []byte) {
+	if c.RawResponseWriter == nil {
+		return
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		pretty.Reset()
+		pretty.Write(body)
+	}
+	_, _ = fmt.Fprintf(c.RawResponseWriter, "# %s %s -> %d\n%s\n", method, fullURL, status, c.redact(strings.TrimSpace(pretty.String())))
+}
+
+// redact masks the password and Basic Auth token in s. It's applied to all
+// debug output and to error messages that include response bodies.
+func (c *Client) redact(s string) string {
+	if c.config == nil || c.config.Password == "" {
+		return s
+	}
+	token := base64.StdEncoding.EncodeToString([]byte(c.config.User + ":" + c.config.Password))
+	for _, secret := range []string{token, c.config.Password, url.QueryEscape(c.config.Password)} {
+		s = strings.ReplaceAll(s, secret, "***")
 	}
+	return s
+}
+
+// endpoint joins the normalized domain with an API path. The domain keeps any
+// port and subpath, so a Nextcloud served from https://example.com/nextcloud
+// gets https://example.com/nextcloud/ocs/...
+func (c *Client) endpoint(path string) string {
+	return config.NormalizeURL(c.config.Domain) + "/" + strings.TrimLeft(path, "/")
 }
 
 func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
-	baseURL := config.NormalizeURL(c.config.Domain)
-	fullURL := fmt.Sprintf("%s%s", baseURL, path)
+	fullURL := c.endpoint(path)
 
-	c.debugf("Request: %s %s", method, fullURL)
+	c.debugf(VerbosityRequests, "Request: %s %s", method, fullURL)
 
 	req, err := http.NewRequest(method, fullURL, body)
 	if err != nil {
@@ -255,15 +427,32 @@ func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response,
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	c.debugf("Headers: OCS-APIRequest=true, Accept=application/json, Auth=Basic %s:***", c.config.User)
+	c.debugf(VerbosityRequests, "Headers: OCS-APIRequest=true, Accept=application/json, Auth=Basic %s:***", c.config.User)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		c.debugf("Request error: %v", err)
+		c.debugf(VerbosityRequests, "Request error: %v", err)
 		return nil, err
 	}
 
-	c.debugf("Response: %d %s", resp.StatusCode, resp.Status)
+	c.debugf(VerbosityRequests, "Response: %d %s", resp.StatusCode, resp.Status)
+
+	if c.Verbosity >= VerbosityBodies || c.RawResponseWriter != nil {
+		// Log the response body, then restore it for the caller
+		bodyBytes, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %w", err)
+		}
+		c.debugf(VerbosityBodies, "Response body: %s", string(bodyBytes))
+		c.dumpResponse(method, fullURL, resp.StatusCode, bodyBytes)
+		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (HTTP %d)", ErrUnauthorized, resp.StatusCode)
+	}
 
 	return resp, nil
 }
@@ -278,9 +467,12 @@ func (c *Client) GetProject(projectID string) (*Project, error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -288,8 +480,11 @@ func (c *Client) GetProject(projectID string) (*Project, error) {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return nil, fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if ocsResp.OCS.Meta.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
 	var project Project
@@ -323,9 +518,9 @@ func (c *Client) GetProjects() ([]ProjectSummary, error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -333,12 +528,10 @@ func (c *Client) GetProjects() ([]ProjectSummary, error) {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return nil, fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
-	c.debugf("Projects response: %s", string(ocsResp.OCS.Data))
-
 	var projects []ProjectSummary
 	if err := json.Unmarshal(ocsResp.OCS.Data, &projects); err != nil {
 		return nil, fmt.Errorf("decoding projects data: %w", err)
@@ -347,8 +540,8 @@ func (c *Client) GetProjects() ([]ProjectSummary, error) {
 	return projects, nil
 }
 
-// CreateBill creates a new bill in the project
-func (c *Client) CreateBill(projectID string, bill Bill) error {
+// CreateBill creates a new bill in the project and returns its ID
+func (c *Client) CreateBill(projectID string, bill Bill) (int, error) {
 	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/bills", url.PathEscape(projectID))
 
 	// Build form data
@@ -384,39 +577,70 @@ func (c *Client) CreateBill(projectID string, bill Bill) error {
 		data.Set("original_currency_id", strconv.Itoa(bill.OriginalCurrencyID))
 	}
 
-	c.debugf("Request body: %s", data.Encode())
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
 
 	resp, err := c.doRequest("POST", path, strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("creating bill: %w", err)
+		return 0, fmt.Errorf("creating bill: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return 0, c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading response body: %w", err)
+		return 0, fmt.Errorf("reading response body: %w", err)
 	}
 
 	if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&ocsResp); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
+		return 0, fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if !ocsResp.OK() {
+		return 0, c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
-	return nil
+	// The API returns the new bill's ID as the response data
+	var billID int
+	if err := json.Unmarshal(ocsResp.OCS.Data, &billID); err != nil {
+		return 0, fmt.Errorf("decoding bill ID: %w", err)
+	}
+	if billID == 0 {
+		return 0, fmt.Errorf("server returned no bill ID")
+	}
+
+	return billID, nil
 }
 
 // GetBills fetches all bills for a project
 func (c *Client) GetBills(projectID string) ([]BillResponse, error) {
+	update, err := c.GetBillsSince(projectID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return update.Bills, nil
+}
+
+// BillsUpdate holds the bills changed since a given time, along with the IDs
+// of all bills that currently exist in the project
+type BillsUpdate struct {
+	Bills      []BillResponse `json:"bills"`
+	AllBillIDs []int          `json:"allBillIds"`
+	Timestamp  int64          `json:"timestamp"`
+}
+
+// GetBillsSince fetches the bills changed after the lastChanged Unix timestamp.
+// A lastChanged of 0 fetches all bills. The returned Timestamp can be passed
+// to the next call to only fetch later changes.
+func (c *Client) GetBillsSince(projectID string, lastChanged int64) (*BillsUpdate, error) {
 	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/bills", url.PathEscape(projectID))
+	if lastChanged > 0 {
+		path += "?lastChanged=" + strconv.FormatInt(lastChanged, 10)
+	}
 
 	resp, err := c.doRequest("GET", path, nil)
 	if err != nil {
@@ -424,9 +648,9 @@ func (c *Client) GetBills(projectID string) ([]BillResponse, error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -434,23 +658,201 @@ func (c *Client) GetBills(projectID string) ([]BillResponse, error) {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return nil, fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
-	// API returns: {"nb_bills": N, "bills": [...], "allBillIds": [...], "timestamp": N}
-	var billsWrapper struct {
-		Bills []BillResponse `json:"bills"`
-	}
-	if err := json.Unmarshal(ocsResp.OCS.Data, &billsWrapper); err != nil {
+	update, err := parseBillsUpdate(ocsResp.OCS.Data)
+	if err != nil {
 		return nil, fmt.Errorf("decoding bills data: %w", err)
 	}
 
-	return billsWrapper.Bills, nil
+	// Without a timestamp, later calls fetch everything again; listing the IDs
+	// of the full list lets callers drop bills deleted since
+	if lastChanged == 0 && update.AllBillIDs == nil {
+		update.AllBillIDs = make([]int, len(update.Bills))
+		for i, bill := range update.Bills {
+			update.AllBillIDs[i] = bill.ID
+		}
+	}
+
+	return update, nil
+}
+
+// parseBillsUpdate decodes the bills endpoint's data, which is usually
+// {"nb_bills": N, "bills": [...], "allBillIds": [...], "timestamp": N} but is
+// a bare array of bills on some server versions. A bare array carries no
+// AllBillIDs or Timestamp.
+func parseBillsUpdate(data json.RawMessage) (*BillsUpdate, error) {
+	var update BillsUpdate
+	err := json.Unmarshal(data, &update)
+	if err == nil {
+		return &update, nil
+	}
+	var bills []BillResponse
+	if json.Unmarshal(data, &bills) == nil {
+		return &BillsUpdate{Bills: bills}, nil
+	}
+	return nil, err
+}
+
+// GetBill fetches a single bill from the project
+func (c *Client) GetBill(projectID string, billID int) (*BillResponse, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/bills/%d", url.PathEscape(projectID), billID)
+
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching bill: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: #%d", ErrBillNotFound, billID)
+	}
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	var bill BillResponse
+	if err := json.Unmarshal(ocsResp.OCS.Data, &bill); err != nil {
+		return nil, fmt.Errorf("decoding bill data: %w", err)
+	}
+
+	return &bill, nil
+}
+
+// StatsOptions narrows the bills counted by GetStatistics. Zero values leave
+// the corresponding filter unset.
+type StatsOptions struct {
+	From          time.Time
+	To            time.Time
+	PayerID       int
+	CategoryID    int
+	PaymentModeID int
+}
+
+// query encodes the options as the statistics endpoint's query parameters
+func (o StatsOptions) query() url.Values {
+	q := url.Values{}
+	if !o.From.IsZero() {
+		q.Set("tsMin", strconv.FormatInt(o.From.Unix(), 10))
+	}
+	if !o.To.IsZero() {
+		q.Set("tsMax", strconv.FormatInt(o.To.Unix(), 10))
+	}
+	if o.PayerID != 0 {
+		q.Set("payerId", strconv.Itoa(o.PayerID))
+	}
+	if o.CategoryID != 0 {
+		q.Set("categoryId", strconv.Itoa(o.CategoryID))
+	}
+	if o.PaymentModeID != 0 {
+		q.Set("paymentModeId", strconv.Itoa(o.PaymentModeID))
+	}
+	return q
+}
+
+// MemberStats holds what a member paid and spent over the counted bills
+type MemberStats struct {
+	Member Member  `json:"member"`
+	Paid   float64 `json:"paid"`
+	Spent  float64 `json:"spent"`
+	// Balance covers all of the project's bills, FilteredBalance only the
+	// counted ones
+	Balance         float64 `json:"balance"`
+	FilteredBalance float64 `json:"filtered_balance"`
+}
+
+// Statistics holds a project's statistics as computed by the server
+type Statistics struct {
+	Members []MemberStats
+	// CategoryTotals and PaymentModeTotals map IDs to the amount spent
+	CategoryTotals    map[int]float64
+	PaymentModeTotals map[int]float64
+}
+
+// UnmarshalJSON reads the statistics endpoint's data, where the totals are
+// objects keyed by ID, or empty arrays when there is nothing to total
+func (s *Statistics) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Stats            []MemberStats   `json:"stats"`
+		CategoryStats    json.RawMessage `json:"categoryStats"`
+		PaymentModeStats json.RawMessage `json:"paymentModeStats"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Members = raw.Stats
+	s.CategoryTotals = parseTotals(raw.CategoryStats)
+	s.PaymentModeTotals = parseTotals(raw.PaymentModeStats)
+	return nil
+}
+
+// parseTotals reads an object of amounts keyed by ID. Anything else, such as
+// the empty array PHP sends for an empty object, gives an empty map.
+func parseTotals(data json.RawMessage) map[int]float64 {
+	totals := make(map[int]float64)
+	var obj map[string]float64
+	if json.Unmarshal(data, &obj) != nil {
+		return totals
+	}
+	for idStr, amount := range obj {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			totals[id] = amount
+		}
+	}
+	return totals
+}
+
+// GetStatistics fetches per-member and per-category totals computed by the
+// server over the bills matching opts
+func (c *Client) GetStatistics(projectID string, opts StatsOptions) (*Statistics, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/statistics", url.PathEscape(projectID))
+	if q := opts.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching statistics: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	var stats Statistics
+	if err := json.Unmarshal(ocsResp.OCS.Data, &stats); err != nil {
+		return nil, fmt.Errorf("decoding statistics data: %w", err)
+	}
+
+	return &stats, nil
 }
 
 // UserInfo represents Nextcloud user information
 type UserInfo struct {
+	ID       string `json:"id"`
 	Locale   string `json:"locale"`
 	Language string `json:"language"`
 }
@@ -463,9 +865,9 @@ func (c *Client) GetUserInfo() (*UserInfo, error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -473,8 +875,8 @@ func (c *Client) GetUserInfo() (*UserInfo, error) {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return nil, fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if !ocsResp.OK() {
+		return nil, c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
 	var userInfo UserInfo
@@ -517,7 +919,7 @@ func (c *Client) EditBill(projectID string, billID int, bill Bill) error {
 		data.Set("categoryId", strconv.Itoa(bill.CategoryID))
 	}
 
-	c.debugf("Request body: %s", data.Encode())
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
 
 	resp, err := c.doRequest("PUT", path, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -525,9 +927,12 @@ func (c *Client) EditBill(projectID string, billID int, bill Bill) error {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: #%d", ErrBillNotFound, billID)
+	}
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -535,8 +940,11 @@ func (c *Client) EditBill(projectID string, billID int, bill Bill) error {
 		return fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if ocsResp.OCS.Meta.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: #%d", ErrBillNotFound, billID)
+	}
+	if !ocsResp.OK() {
+		return c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
 	return nil
@@ -552,9 +960,254 @@ func (c *Client) DeleteBill(projectID string, billID int) error {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: #%d", ErrBillNotFound, billID)
+	}
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	if ocsResp.OCS.Meta.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: #%d", ErrBillNotFound, billID)
+	}
+	if !ocsResp.OK() {
+		return c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	return nil
+}
+
+// CreateCategory creates a new category in the project and returns its ID
+func (c *Client) CreateCategory(projectID string, cat Category) (int, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/category", url.PathEscape(projectID))
+
+	data := url.Values{}
+	data.Set("name", cat.Name)
+	if cat.Icon != "" {
+		data.Set("icon", cat.Icon)
+	}
+	if cat.Color != "" {
+		data.Set("color", cat.Color)
+	}
+
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
+
+	resp, err := c.doRequest("POST", path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("creating category: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return 0, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return 0, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	// The API returns the new category's ID as the response data
+	var categoryID int
+	if err := json.Unmarshal(ocsResp.OCS.Data, &categoryID); err != nil {
+		return 0, fmt.Errorf("decoding category ID: %w", err)
+	}
+
+	return categoryID, nil
+}
+
+// EditCategory updates a category in the project. Only the non-empty fields
+// of cat are sent; the others are left unchanged.
+func (c *Client) EditCategory(projectID string, categoryID int, cat Category) error {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/category/%d", url.PathEscape(projectID), categoryID)
+
+	data := url.Values{}
+	if cat.Name != "" {
+		data.Set("name", cat.Name)
+	}
+	if cat.Icon != "" {
+		data.Set("icon", cat.Icon)
+	}
+	if cat.Color != "" {
+		data.Set("color", cat.Color)
+	}
+
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
+
+	resp, err := c.doRequest("PUT", path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("editing category: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	return nil
+}
+
+// CreatePaymentMode creates a new payment mode in the project and returns its ID
+func (c *Client) CreatePaymentMode(projectID string, pm PaymentMode) (int, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/paymode", url.PathEscape(projectID))
+
+	data := url.Values{}
+	data.Set("name", pm.Name)
+	if pm.Icon != "" {
+		data.Set("icon", pm.Icon)
+	}
+	if pm.Color != "" {
+		data.Set("color", pm.Color)
+	}
+
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
+
+	resp, err := c.doRequest("POST", path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("creating payment mode: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return 0, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return 0, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	// The API returns the new payment mode's ID as the response data
+	var paymentModeID int
+	if err := json.Unmarshal(ocsResp.OCS.Data, &paymentModeID); err != nil {
+		return 0, fmt.Errorf("decoding payment mode ID: %w", err)
+	}
+
+	return paymentModeID, nil
+}
+
+// CreateCurrency adds a currency to the project and returns its ID. rate is
+// how many units of the project's main currency one unit of it is worth.
+func (c *Client) CreateCurrency(projectID, name string, rate float64) (int, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/currency", url.PathEscape(projectID))
+
+	data := url.Values{}
+	data.Set("name", name)
+	data.Set("rate", strconv.FormatFloat(rate, 'f', -1, 64))
+
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
+
+	resp, err := c.doRequest("POST", path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("creating currency: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return 0, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return 0, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	// The API returns the new currency's ID as the response data
+	var currencyID int
+	if err := json.Unmarshal(ocsResp.OCS.Data, &currencyID); err != nil {
+		return 0, fmt.Errorf("decoding currency ID: %w", err)
+	}
+
+	return currencyID, nil
+}
+
+// CreateMember adds a new member to the project and returns its ID.
+// userID optionally links the member to a Nextcloud user.
+func (c *Client) CreateMember(projectID, name, userID string) (int, error) {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/members", url.PathEscape(projectID))
+
+	data := url.Values{}
+	data.Set("name", name)
+	if userID != "" {
+		data.Set("userId", userID)
+	}
+
+	c.debugf(VerbosityBodies, "Request body: %s", data.Encode())
+
+	resp, err := c.doRequest("POST", path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("creating member: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, c.statusError(resp.StatusCode, bodyBytes)
+	}
+
+	var ocsResp OCSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ocsResp); err != nil {
+		return 0, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if !ocsResp.OK() {
+		return 0, c.ocsError(ocsResp.OCS.Meta.Message)
+	}
+
+	// The API returns the new member object
+	var member Member
+	if err := json.Unmarshal(ocsResp.OCS.Data, &member); err != nil {
+		return 0, fmt.Errorf("decoding member data: %w", err)
+	}
+
+	return member.ID, nil
+}
+
+// DeleteMember removes a member from the project. Cospend deactivates members
+// that are still referenced by bills instead of deleting them.
+func (c *Client) DeleteMember(projectID string, memberID int) error {
+	path := fmt.Sprintf("/ocs/v2.php/apps/cospend/api/v1/projects/%s/members/%d", url.PathEscape(projectID), memberID)
+
+	resp, err := c.doRequest("DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("deleting member: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isSuccess(resp.StatusCode) {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return c.statusError(resp.StatusCode, bodyBytes)
 	}
 
 	var ocsResp OCSResponse
@@ -562,8 +1215,8 @@ func (c *Client) DeleteBill(projectID string, billID int) error {
 		return fmt.Errorf("decoding response: %w", err)
 	}
 
-	if ocsResp.OCS.Meta.StatusCode != 200 {
-		return fmt.Errorf("API error: %s", ocsResp.OCS.Meta.Message)
+	if !ocsResp.OK() {
+		return c.ocsError(ocsResp.OCS.Meta.Message)
 	}
 
 	return nil
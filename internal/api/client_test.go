@@ -1,10 +1,15 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/chenasraf/cospend-cli/internal/config"
 )
@@ -56,6 +61,7 @@ func TestGetProject(t *testing.T) {
 		responseStatus int
 		responseBody   any
 		wantErr        bool
+		wantNotFound   bool
 	}{
 		{
 			name:           "successful request",
@@ -90,6 +96,7 @@ func TestGetProject(t *testing.T) {
 			responseStatus: http.StatusNotFound,
 			responseBody:   "Not Found",
 			wantErr:        true,
+			wantNotFound:   true,
 		},
 		{
 			name:           "api error",
@@ -115,7 +122,8 @@ func TestGetProject(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:      true,
+			wantNotFound: true,
 		},
 	}
 
@@ -163,6 +171,9 @@ func TestGetProject(t *testing.T) {
 				t.Errorf("GetProject() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if errors.Is(err, ErrProjectNotFound) != tt.wantNotFound {
+				t.Errorf("GetProject() error = %v, want ErrProjectNotFound %v", err, tt.wantNotFound)
+			}
 
 			if !tt.wantErr && project != nil {
 				if project.ID != projectData.ID {
@@ -216,7 +227,7 @@ func TestCreateBill(t *testing.T) {
 						StatusCode: 200,
 						Message:    "OK",
 					},
-					Data: mustMarshal(map[string]int{"id": 123}),
+					Data: mustMarshal(123),
 				},
 			},
 			wantErr: false,
@@ -274,6 +285,7 @@ func TestCreateBill(t *testing.T) {
 						StatusCode: 200,
 						Message:    "OK",
 					},
+					Data: mustMarshal(124),
 				},
 			},
 			wantErr: false,
@@ -366,7 +378,7 @@ func TestCreateBill(t *testing.T) {
 			}
 			client := NewClient(cfg)
 
-			err := client.CreateBill("test-project", tt.bill)
+			_, err := client.CreateBill("test-project", tt.bill)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateBill() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -399,6 +411,7 @@ func TestCreateBillWithCurrency(t *testing.T) {
 					StatusCode: 200,
 					Message:    "OK",
 				},
+				Data: mustMarshal(7),
 			},
 		}
 		_ = json.NewEncoder(w).Encode(response)
@@ -421,7 +434,7 @@ func TestCreateBillWithCurrency(t *testing.T) {
 		OriginalCurrencyID: 5,
 	}
 
-	err := client.CreateBill("test-project", bill)
+	_, err := client.CreateBill("test-project", bill)
 	if err != nil {
 		t.Errorf("CreateBill() unexpected error: %v", err)
 	}
@@ -619,6 +632,130 @@ func TestProjectUnmarshalObjectKeyed(t *testing.T) {
 	}
 }
 
+func TestProjectMemberDetails(t *testing.T) {
+	projectJSON := `{
+		"id": "test",
+		"deletiondisabled": 1,
+		"members": [
+			{"id": 1, "name": "Alice", "weight": 2, "activated": true},
+			{"id": 2, "name": "Bob", "weight": 1.5},
+			{"id": 3, "name": "Carol"}
+		],
+		"balance": {"1": 25.5, "2": -25.5}
+	}`
+
+	var project Project
+	if err := json.Unmarshal([]byte(projectJSON), &project); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !project.DeletionDisabled {
+		t.Error("DeletionDisabled should be set from 1")
+	}
+	want := []Member{
+		{ID: 1, Name: "Alice", Activated: true, Weight: 2, Balance: 25.5},
+		{ID: 2, Name: "Bob", Weight: 1.5, Balance: -25.5},
+		{ID: 3, Name: "Carol"},
+	}
+	if !reflect.DeepEqual(project.Members, want) {
+		t.Errorf("Members = %+v, want %+v", project.Members, want)
+	}
+
+	// Everything survives caching the project
+	data, err := json.Marshal(project)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var cached Project
+	if err := json.Unmarshal(data, &cached); err != nil {
+		t.Fatalf("Unmarshal cached error: %v", err)
+	}
+	if !cached.DeletionDisabled || !reflect.DeepEqual(cached.Members, want) {
+		t.Errorf("Cached project = %+v", cached)
+	}
+
+	// Older servers send none of these
+	var old Project
+	if err := json.Unmarshal([]byte(`{"id": "old", "deletiondisabled": false, "members": [{"id": 1, "name": "Alice"}]}`), &old); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if old.DeletionDisabled || old.Members[0].Weight != 0 || old.Members[0].Balance != 0 {
+		t.Errorf("Missing fields should stay zero, got %+v", old)
+	}
+}
+
+func TestProjectArchived(t *testing.T) {
+	var project Project
+	if err := json.Unmarshal([]byte(`{"id": "old", "archived_ts": 1700000000}`), &project); err != nil {
+		t.Fatal(err)
+	}
+	if !project.IsArchived() || *project.ArchivedTS != 1700000000 {
+		t.Errorf("Expected an archived project, got %+v", project)
+	}
+
+	// The archived state survives the project cache
+	data, err := json.Marshal(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cached Project
+	if err := json.Unmarshal(data, &cached); err != nil {
+		t.Fatal(err)
+	}
+	if !cached.IsArchived() {
+		t.Errorf("Archived state lost in %s", data)
+	}
+
+	var active Project
+	if err := json.Unmarshal([]byte(`{"id": "home", "archived_ts": null}`), &active); err != nil {
+		t.Fatal(err)
+	}
+	if active.IsArchived() {
+		t.Error("Expected an active project")
+	}
+}
+
+func TestPaymentModeOldID(t *testing.T) {
+	// Built-in modes carry a letter; older servers omit it and some send null
+	projectJSON := `{
+		"id": "test",
+		"paymentmodes": {
+			"1": {"name": "Cash", "old_id": "c"},
+			"2": {"name": "Gift", "old_id": null},
+			"3": {"name": "Voucher", "old_id": 0},
+			"4": {"name": "Crypto"}
+		}
+	}`
+
+	var project Project
+	if err := json.Unmarshal([]byte(projectJSON), &project); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	oldIDs := make(map[string]string)
+	for _, pm := range project.PaymentModes {
+		oldIDs[pm.Name] = pm.OldID
+	}
+	want := map[string]string{"Cash": "c", "Gift": "", "Voucher": "", "Crypto": ""}
+	if !reflect.DeepEqual(oldIDs, want) {
+		t.Errorf("OldIDs = %v, want %v", oldIDs, want)
+	}
+
+	// The letter survives caching the project
+	data, err := json.Marshal(project)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var cached Project
+	if err := json.Unmarshal(data, &cached); err != nil {
+		t.Fatalf("Unmarshal cached error: %v", err)
+	}
+	for _, pm := range cached.PaymentModes {
+		if pm.OldID != want[pm.Name] {
+			t.Errorf("Cached %s OldID = %q, want %q", pm.Name, pm.OldID, want[pm.Name])
+		}
+	}
+}
+
 func mustMarshal(v any) json.RawMessage {
 	data, err := json.Marshal(v)
 	if err != nil {
@@ -626,3 +763,437 @@ func mustMarshal(v any) json.RawMessage {
 	}
 	return data
 }
+
+func TestCreateBillReturnsID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": 42}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	id, err := client.CreateBill("test-project", Bill{What: "Test", Amount: 1, PayerID: 1, OwedTo: []int{1}})
+	if err != nil {
+		t.Fatalf("CreateBill() error = %v", err)
+	}
+	if id != 42 {
+		t.Errorf("CreateBill() id = %d, want 42", id)
+	}
+}
+
+func TestCreateBillInvalidID(t *testing.T) {
+	// Without the new bill's ID, callers would report and record bill #0
+	for _, data := range []string{`{"id": 42}`, `"OK"`, `null`, `0`} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": ` + data + `}}`))
+		}))
+
+		client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+		if id, err := client.CreateBill("test-project", Bill{What: "Test", Amount: 1, PayerID: 1, OwedTo: []int{1}}); err == nil {
+			t.Errorf("CreateBill() with data %s = %d, want an error", data, id)
+		}
+		server.Close()
+	}
+}
+
+func TestGetBill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills/7" {
+			_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": {"id": 7, "what": "Rent", "amount": 1200, "date": "2026-01-01", "payer_id": 1, "owers": [{"id": 1, "weight": 1}, {"id": 2, "weight": 1}], "categoryid": 3}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	bill, err := client.GetBill("test-project", 7)
+	if err != nil {
+		t.Fatalf("GetBill() error = %v", err)
+	}
+	if bill.ID != 7 || bill.What != "Rent" || bill.Amount != 1200 || bill.CategoryID != 3 {
+		t.Errorf("Unexpected bill: %+v", bill)
+	}
+	if len(bill.Owers) != 2 {
+		t.Errorf("Owers = %v, want 2 owers", bill.Owers)
+	}
+
+	if _, err := client.GetBill("test-project", 8); err == nil {
+		t.Error("Expected error for missing bill")
+	}
+}
+
+func TestGetBillsSince(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": {"nb_bills": 1, "bills": [{"id": 2, "what": "Taxi"}], "allBillIds": [1, 2], "timestamp": 1700000100}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	update, err := client.GetBillsSince("test-project", 1700000000)
+	if err != nil {
+		t.Fatalf("GetBillsSince() error = %v", err)
+	}
+	if gotQuery != "lastChanged=1700000000" {
+		t.Errorf("Query = %q, want lastChanged=1700000000", gotQuery)
+	}
+	if len(update.Bills) != 1 || update.Bills[0].ID != 2 {
+		t.Errorf("Bills = %v, want only #2", update.Bills)
+	}
+	if len(update.AllBillIDs) != 2 || update.Timestamp != 1700000100 {
+		t.Errorf("Unexpected update metadata: %+v", update)
+	}
+
+	if _, err := client.GetBills("test-project"); err != nil {
+		t.Fatalf("GetBills() error = %v", err)
+	}
+	if gotQuery != "" {
+		t.Errorf("GetBills() should not send lastChanged, got query %q", gotQuery)
+	}
+}
+
+func TestGetBillsBareArray(t *testing.T) {
+	// Some server versions return the bills without the wrapping object
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": [{"id": 1, "what": "Lunch", "amount": 12.5}, {"id": 2, "what": "Taxi"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	bills, err := client.GetBills("test-project")
+	if err != nil {
+		t.Fatalf("GetBills() error = %v", err)
+	}
+	if len(bills) != 2 || bills[0].What != "Lunch" || bills[0].Amount != 12.5 || bills[1].ID != 2 {
+		t.Errorf("Unexpected bills: %+v", bills)
+	}
+
+	update, err := client.GetBillsSince("test-project", 0)
+	if err != nil {
+		t.Fatalf("GetBillsSince() error = %v", err)
+	}
+	// The full list stands in for the missing bill IDs
+	if !reflect.DeepEqual(update.AllBillIDs, []int{1, 2}) || update.Timestamp != 0 {
+		t.Errorf("Unexpected update metadata: %+v", update)
+	}
+}
+
+func TestParseBillsUpdateInvalid(t *testing.T) {
+	if _, err := parseBillsUpdate(json.RawMessage(`"oops"`)); err == nil {
+		t.Error("Expected error for data that is neither an object nor an array")
+	}
+}
+
+func TestGetStatistics(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": {"stats": [{"member": {"id": 1, "name": "Alice"}, "paid": 60, "spent": 30, "balance": 45, "filtered_balance": 30}, {"member": {"id": 2, "name": "Bob"}, "paid": 0, "spent": 30, "balance": -45, "filtered_balance": -30}], "categoryStats": {"3": 60}, "paymentModeStats": []}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := client.GetStatistics("test-project", StatsOptions{From: from, PayerID: 1})
+	if err != nil {
+		t.Fatalf("GetStatistics() error = %v", err)
+	}
+	if gotPath != "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/statistics" {
+		t.Errorf("Path = %s", gotPath)
+	}
+	if gotQuery != "payerId=1&tsMin=1767225600" {
+		t.Errorf("Query = %q, want payerId=1&tsMin=1767225600", gotQuery)
+	}
+	if len(stats.Members) != 2 || stats.Members[0].Member.Name != "Alice" || stats.Members[0].Paid != 60 || stats.Members[1].FilteredBalance != -30 {
+		t.Errorf("Unexpected members: %+v", stats.Members)
+	}
+	if !reflect.DeepEqual(stats.CategoryTotals, map[int]float64{3: 60}) {
+		t.Errorf("CategoryTotals = %v, want {3: 60}", stats.CategoryTotals)
+	}
+	// PHP sends an empty object as an empty array
+	if stats.PaymentModeTotals == nil || len(stats.PaymentModeTotals) != 0 {
+		t.Errorf("PaymentModeTotals = %v, want an empty map", stats.PaymentModeTotals)
+	}
+}
+
+func TestRawResponseWriter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": {"bills": [{"id": 2, "what": "Taxi"}]}}}`))
+	}))
+	defer server.Close()
+
+	var raw bytes.Buffer
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+	client.RawResponseWriter = &raw
+
+	// The body is still decoded after being dumped
+	bills, err := client.GetBills("test-project")
+	if err != nil {
+		t.Fatalf("GetBills() error = %v", err)
+	}
+	if len(bills) != 1 || bills[0].What != "Taxi" {
+		t.Errorf("Bills = %+v, want Taxi", bills)
+	}
+
+	for _, want := range []string{
+		"# GET " + server.URL + "/ocs/v2.php/apps/cospend/api/v1/projects/test-project/bills -> 200\n",
+		"\n      \"bills\": [\n",
+		"\"what\": \"Taxi\"",
+	} {
+		if !strings.Contains(raw.String(), want) {
+			t.Errorf("Raw response missing %q:\n%s", want, raw.String())
+		}
+	}
+}
+
+func TestDebugVerbosity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": 42}}`))
+	}))
+	defer server.Close()
+
+	bill := Bill{What: "Coffee", Amount: 1, PayerID: 1, OwedTo: []int{1}}
+
+	tests := []struct {
+		name      string
+		verbosity int
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "off",
+			verbosity: 0,
+			notWant:   []string{"[DEBUG]"},
+		},
+		{
+			name:      "requests",
+			verbosity: VerbosityRequests,
+			want:      []string{"Request: POST", "Response: 200"},
+			notWant:   []string{"Request body:", "Response body:"},
+		},
+		{
+			name:      "bodies",
+			verbosity: VerbosityBodies,
+			want:      []string{"Request: POST", "Request body:", "what=Coffee", `Response body: {"ocs"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+			client.Verbosity = tt.verbosity
+			client.DebugWriter = &buf
+
+			id, err := client.CreateBill("test-project", bill)
+			if err != nil {
+				t.Fatalf("CreateBill() error = %v", err)
+			}
+			if id != 42 {
+				t.Errorf("CreateBill() id = %d, want 42 (response body must survive logging)", id)
+			}
+
+			output := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(output, s) {
+					t.Errorf("Debug output missing %q:\n%s", s, output)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(output, s) {
+					t.Errorf("Debug output should not contain %q:\n%s", s, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDebugRedactsCredentials(t *testing.T) {
+	const password = "s3cr&t pass"
+
+	// Echo the request body back so the password shows up in both directions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 200, "message": "OK"}, "data": {"echo": "` + r.FormValue("what") + `"}}}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: password})
+	client.Verbosity = VerbosityBodies
+	client.DebugWriter = &buf
+
+	_, _ = client.CreateBill("test-project", Bill{What: "note " + password, Amount: 1, PayerID: 1, OwedTo: []int{1}})
+
+	output := buf.String()
+	if output == "" {
+		t.Fatal("Expected debug output")
+	}
+	for _, secret := range []string{password, "s3cr%26t+pass", "dGVzdHVzZXI6czNjciZ0IHBhc3M="} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Debug output leaks %q:\n%s", secret, output)
+		}
+	}
+}
+
+func TestErrorsRedactCredentials(t *testing.T) {
+	cfg := &config.Config{User: "testuser", Password: "s3cr&t pass"}
+
+	// A misconfigured server that echoes the Authorization header and form body back
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + " " + r.Form.Encode() + " " + cfg.Password))
+	}))
+	defer server.Close()
+	cfg.Domain = server.URL
+
+	client := NewClient(cfg)
+
+	_, err := client.CreateBill("test-project", Bill{What: cfg.Password, Amount: 1, PayerID: 1, OwedTo: []int{1}})
+	if err == nil {
+		t.Fatal("Expected error for 500 response")
+	}
+	if strings.Contains(err.Error(), cfg.Password) {
+		t.Errorf("Error leaks password: %v", err)
+	}
+	if strings.Contains(err.Error(), "dGVzdHVzZXI6czNjciZ0IHBhc3M=") {
+		t.Errorf("Error leaks Basic Auth token: %v", err)
+	}
+	if !strings.Contains(err.Error(), "status 500") {
+		t.Errorf("Error should still describe the failure: %v", err)
+	}
+}
+
+func TestOCSResponseOK(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		statusCode int
+		want       bool
+	}{
+		{"ok 200", "ok", 200, true},
+		{"ok 201", "ok", 201, true},
+		{"201 without status", "", 201, true},
+		{"ok with OCS v1 code", "ok", 100, true},
+		{"failure 400", "failure", 400, false},
+		{"failure 997", "failure", 997, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp OCSResponse
+			resp.OCS.Meta.Status = tt.status
+			resp.OCS.Meta.StatusCode = tt.statusCode
+			if got := resp.OK(); got != tt.want {
+				t.Errorf("OK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateBillAccepts201(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "ok", "statuscode": 201, "message": "Created"}, "data": 42}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	id, err := client.CreateBill("test-project", Bill{What: "Test", Amount: 1, PayerID: 1, OwedTo: []int{1}})
+	if err != nil {
+		t.Fatalf("CreateBill() error = %v", err)
+	}
+	if id != 42 {
+		t.Errorf("CreateBill() id = %d, want 42", id)
+	}
+}
+
+func TestAPIErrorSurfacesMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ocs": {"meta": {"status": "failure", "statuscode": 400, "message": "Invalid project"}, "data": null}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "testpass"})
+
+	_, err := client.GetProject("test-project")
+	if err == nil || !strings.Contains(err.Error(), "Invalid project") {
+		t.Errorf("Expected error with meta message, got: %v", err)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"message": "Current user is not logged in"}`))
+		}))
+
+		client := NewClient(&config.Config{Domain: server.URL, User: "testuser", Password: "wrong"})
+
+		_, err := client.GetProject("test-project")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("GetProject() with HTTP %d error = %v, want ErrUnauthorized", status, err)
+		}
+		_, err = client.GetBills("test-project")
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("GetBills() with HTTP %d error = %v, want ErrUnauthorized", status, err)
+		}
+
+		server.Close()
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"cloud.example.com", "https://cloud.example.com/ocs/v2.php/cloud/user"},
+		{"https://cloud.example.com/", "https://cloud.example.com/ocs/v2.php/cloud/user"},
+		{"http://host:8080", "http://host:8080/ocs/v2.php/cloud/user"},
+		{"https://example.com/nextcloud", "https://example.com/nextcloud/ocs/v2.php/cloud/user"},
+		{"https://example.com:8443/nextcloud/", "https://example.com:8443/nextcloud/ocs/v2.php/cloud/user"},
+		{"https://example.com/nextcloud/index.php/apps/cospend", "https://example.com/nextcloud/ocs/v2.php/cloud/user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			client := NewClient(&config.Config{Domain: tt.domain, User: "alice", Password: "secret"})
+			if got := client.endpoint("/ocs/v2.php/cloud/user"); got != tt.want {
+				t.Errorf("endpoint() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestsKeepSubpathAndPort(t *testing.T) {
+	var gotPath, gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotHost = r.URL.Path, r.Host
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ocs":{"meta":{"status":"ok","statuscode":200},"data":{"locale":"en_US","language":"en"}}}`))
+	}))
+	defer server.Close()
+
+	// httptest servers listen on a non-standard port
+	client := NewClient(&config.Config{Domain: server.URL + "/nextcloud/", User: "alice", Password: "secret"})
+	if _, err := client.GetUserInfo(); err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+
+	if gotPath != "/nextcloud/ocs/v2.php/cloud/user" {
+		t.Errorf("Request path = %s, want /nextcloud/ocs/v2.php/cloud/user", gotPath)
+	}
+	if want := strings.TrimPrefix(server.URL, "http://"); gotHost != want {
+		t.Errorf("Request host = %s, want %s", gotHost, want)
+	}
+}
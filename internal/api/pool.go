@@ -0,0 +1,35 @@
+package api
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultWorkers is the number of concurrent requests used by ForEachProject
+// when no positive worker count is given
+const DefaultWorkers = 4
+
+// ForEachProject calls fn for every project ID, running at most workers calls
+// at a time. All calls run to completion even if some fail; the returned error
+// joins every non-nil error returned by fn.
+func ForEachProject(ids []string, workers int, fn func(id string) error) error {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachProject(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	var running, peak int32
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	err := ForEachProject(ids, 3, func(id string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		seen[id] = true
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(seen) != len(ids) {
+		t.Errorf("Expected %d projects visited, got %d", len(ids), len(seen))
+	}
+	if peak > 3 {
+		t.Errorf("Expected at most 3 concurrent calls, got %d", peak)
+	}
+}
+
+func TestForEachProjectErrors(t *testing.T) {
+	errB := errors.New("b failed")
+	errD := errors.New("d failed")
+
+	var calls int32
+	err := ForEachProject([]string{"a", "b", "c", "d"}, 0, func(id string) error {
+		atomic.AddInt32(&calls, 1)
+		switch id {
+		case "b":
+			return errB
+		case "d":
+			return errD
+		}
+		return nil
+	})
+	if !errors.Is(err, errB) || !errors.Is(err, errD) {
+		t.Errorf("Expected both errors to be joined, got %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("Expected all projects to run despite errors, got %d calls", calls)
+	}
+}
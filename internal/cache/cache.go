@@ -12,11 +12,19 @@ import (
 
 	"github.com/adrg/xdg"
 	"github.com/chenasraf/cospend-cli/internal/api"
+	"github.com/chenasraf/cospend-cli/internal/config"
 )
 
 const (
 	cacheTTL = 1 * time.Hour
+	// statsTTL is shorter than cacheTTL since bills change more often than project settings
+	statsTTL = 5 * time.Minute
 	appName  = "cospend"
+
+	// schemaVersion is stored in cached projects and user info. Files from
+	// another version are treated as a miss and refetched, so bump it whenever
+	// api.Project or api.UserInfo (or their JSON) changes shape.
+	schemaVersion = 3
 )
 
 // currencyCodeToSymbol maps currency codes to their symbols
@@ -135,10 +143,36 @@ var currencyCodeToSymbol = map[string]string{
 	"zar": "R",
 }
 
+// writeFile replaces the file at path with data atomically: data is written to
+// a temporary file in the same directory, which is then renamed over path. A
+// concurrent reader (or another cospend process writing the same file) sees
+// either the old or the new contents, never a mix of both.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // CachedProject stores project data with timestamp
 type CachedProject struct {
-	Project  *api.Project `json:"project"`
-	CachedAt time.Time    `json:"cached_at"`
+	SchemaVersion int          `json:"schema_version"`
+	Project       *api.Project `json:"project"`
+	CachedAt      time.Time    `json:"cached_at"`
 }
 
 // getCacheHome returns the cache home directory, checking XDG_CACHE_HOME env var first
@@ -149,14 +183,19 @@ func getCacheHome() string {
 	return xdg.CacheHome
 }
 
-// GetCacheDir returns the cache directory path
+// GetCacheDir returns the cache directory path. COSPEND_CACHE_DIR, when set
+// in the environment or the env file, is used as-is; otherwise the directory
+// is placed under the cache home.
 func GetCacheDir() string {
+	if dir := config.Getenv("COSPEND_CACHE_DIR"); dir != "" {
+		return dir
+	}
 	return filepath.Join(getCacheHome(), appName)
 }
 
 // getCachePath returns the cache file path for a project
 func getCachePath(projectID string) (string, error) {
-	cacheDir := filepath.Join(getCacheHome(), appName)
+	cacheDir := GetCacheDir()
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return "", fmt.Errorf("creating cache directory: %w", err)
 	}
@@ -176,7 +215,7 @@ func Load(projectID string) (*api.Project, bool) {
 	}
 
 	var cached CachedProject
-	if err := json.Unmarshal(data, &cached); err != nil {
+	if err := json.Unmarshal(data, &cached); err != nil || cached.SchemaVersion != schemaVersion {
 		return nil, false
 	}
 
@@ -196,8 +235,9 @@ func Save(projectID string, project *api.Project) error {
 	}
 
 	cached := CachedProject{
-		Project:  project,
-		CachedAt: time.Now(),
+		SchemaVersion: schemaVersion,
+		Project:       project,
+		CachedAt:      time.Now(),
 	}
 
 	data, err := json.MarshalIndent(cached, "", "  ")
@@ -205,22 +245,244 @@ func Save(projectID string, project *api.Project) error {
 		return fmt.Errorf("marshaling cache data: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFile(path, data); err != nil {
 		return fmt.Errorf("writing cache file: %w", err)
 	}
 
 	return nil
 }
 
+// Invalidate removes the cached data for a project so the next Load misses
+func Invalidate(projectID string) error {
+	path, err := getCachePath(projectID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing cache file: %w", err)
+	}
+	return nil
+}
+
+// ProjectStats holds a summary of a project's bills
+type ProjectStats struct {
+	BillCount int     `json:"bill_count"`
+	Total     float64 `json:"total"`
+}
+
+// CachedProjectStats stores project stats with timestamp
+type CachedProjectStats struct {
+	Stats    *ProjectStats `json:"stats"`
+	CachedAt time.Time     `json:"cached_at"`
+}
+
+// getStatsPath returns the cache file path for a project's stats
+func getStatsPath(projectID string) (string, error) {
+	cacheDir := GetCacheDir()
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, fmt.Sprintf("%s.stats.json", projectID)), nil
+}
+
+// LoadProjectStats retrieves cached project stats if they exist and are not expired
+func LoadProjectStats(projectID string) (*ProjectStats, bool) {
+	path, err := getStatsPath(projectID)
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached CachedProjectStats
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Stats == nil {
+		return nil, false
+	}
+
+	if time.Since(cached.CachedAt) > statsTTL {
+		return nil, false
+	}
+
+	return cached.Stats, true
+}
+
+// SaveProjectStats stores project stats in the cache
+func SaveProjectStats(projectID string, stats *ProjectStats) error {
+	path, err := getStatsPath(projectID)
+	if err != nil {
+		return err
+	}
+
+	cached := CachedProjectStats{
+		Stats:    stats,
+		CachedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling stats cache: %w", err)
+	}
+
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("writing stats cache: %w", err)
+	}
+
+	return nil
+}
+
+// lastSeen stores when a project's bills were last listed
+type lastSeen struct {
+	SeenAt time.Time `json:"seen_at"`
+}
+
+// getLastSeenPath returns the path of the file recording when a project's bills were last listed
+func getLastSeenPath(projectID string) (string, error) {
+	cacheDir := GetCacheDir()
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, fmt.Sprintf("%s.lastseen.json", projectID)), nil
+}
+
+// LoadLastSeen returns when the project's bills were last listed, or false if
+// they never were. Unlike cached data, it doesn't expire.
+func LoadLastSeen(projectID string) (time.Time, bool) {
+	path, err := getLastSeenPath(projectID)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var seen lastSeen
+	if err := json.Unmarshal(data, &seen); err != nil || seen.SeenAt.IsZero() {
+		return time.Time{}, false
+	}
+
+	return seen.SeenAt, true
+}
+
+// SaveLastSeen records that the project's bills were listed at seenAt
+func SaveLastSeen(projectID string, seenAt time.Time) error {
+	path, err := getLastSeenPath(projectID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(lastSeen{SeenAt: seenAt}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling last seen: %w", err)
+	}
+
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("writing last seen: %w", err)
+	}
+
+	return nil
+}
+
+// Kinds of LastAction
+const (
+	ActionAdd    = "add"
+	ActionDelete = "delete"
+)
+
+// LastAction is the most recent bill added to or deleted from a project,
+// kept so it can be undone
+type LastAction struct {
+	Kind   string `json:"kind"`
+	BillID int    `json:"bill_id"`
+
+	// Bill is the deleted bill, so it can be recreated
+	Bill *api.BillResponse `json:"bill,omitempty"`
+
+	At time.Time `json:"at"`
+}
+
+// getLastActionPath returns the path of the file recording a project's last action
+func getLastActionPath(projectID string) (string, error) {
+	cacheDir := GetCacheDir()
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, fmt.Sprintf("%s.undo.json", projectID)), nil
+}
+
+// LoadLastAction returns the project's last undoable action, or false if
+// there is none. Like the last seen time, it doesn't expire.
+func LoadLastAction(projectID string) (*LastAction, bool) {
+	path, err := getLastActionPath(projectID)
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var action LastAction
+	if err := json.Unmarshal(data, &action); err != nil {
+		return nil, false
+	}
+	switch {
+	case action.Kind == ActionAdd && action.BillID != 0:
+	case action.Kind == ActionDelete && action.Bill != nil:
+	default:
+		return nil, false
+	}
+
+	return &action, true
+}
+
+// SaveLastAction records action as the project's last undoable action,
+// replacing the previous one
+func SaveLastAction(projectID string, action LastAction) error {
+	path, err := getLastActionPath(projectID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(action, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling last action: %w", err)
+	}
+
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("writing last action: %w", err)
+	}
+
+	return nil
+}
+
+// ClearLastAction forgets the project's last action, once it's been undone
+func ClearLastAction(projectID string) error {
+	path, err := getLastActionPath(projectID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing last action: %w", err)
+	}
+	return nil
+}
+
 // CachedUserInfo stores user info data with timestamp
 type CachedUserInfo struct {
-	UserInfo *api.UserInfo `json:"user_info"`
-	CachedAt time.Time     `json:"cached_at"`
+	SchemaVersion int           `json:"schema_version"`
+	UserInfo      *api.UserInfo `json:"user_info"`
+	CachedAt      time.Time     `json:"cached_at"`
 }
 
 // LoadUserInfo retrieves cached user info if it exists and is not expired
 func LoadUserInfo() (*api.UserInfo, bool) {
-	cacheDir := filepath.Join(getCacheHome(), appName)
+	cacheDir := GetCacheDir()
 	path := filepath.Join(cacheDir, "_userinfo.json")
 
 	data, err := os.ReadFile(path)
@@ -229,7 +491,7 @@ func LoadUserInfo() (*api.UserInfo, bool) {
 	}
 
 	var cached CachedUserInfo
-	if err := json.Unmarshal(data, &cached); err != nil {
+	if err := json.Unmarshal(data, &cached); err != nil || cached.SchemaVersion != schemaVersion {
 		return nil, false
 	}
 
@@ -242,15 +504,16 @@ func LoadUserInfo() (*api.UserInfo, bool) {
 
 // SaveUserInfo stores user info in the cache
 func SaveUserInfo(userInfo *api.UserInfo) error {
-	cacheDir := filepath.Join(getCacheHome(), appName)
+	cacheDir := GetCacheDir()
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return fmt.Errorf("creating cache directory: %w", err)
 	}
 	path := filepath.Join(cacheDir, "_userinfo.json")
 
 	cached := CachedUserInfo{
-		UserInfo: userInfo,
-		CachedAt: time.Now(),
+		SchemaVersion: schemaVersion,
+		UserInfo:      userInfo,
+		CachedAt:      time.Now(),
 	}
 
 	data, err := json.MarshalIndent(cached, "", "  ")
@@ -258,7 +521,7 @@ func SaveUserInfo(userInfo *api.UserInfo) error {
 		return fmt.Errorf("marshaling user info cache: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFile(path, data); err != nil {
 		return fmt.Errorf("writing user info cache: %w", err)
 	}
 
@@ -270,12 +533,15 @@ var (
 	symbolToISOOnce sync.Once
 )
 
+// preferredCurrencies picks the currency for symbols shared by several
+// currencies, with one entry per symbol. Every ambiguous symbol needs an entry
+// here, or the result would depend on map iteration order.
+var preferredCurrencies = []string{"usd", "cny", "gbp", "eur", "sek", "sar", "lkr"}
+
 // SymbolToISO returns the uppercase ISO currency code for a given symbol.
 // For ambiguous symbols (e.g. "$"), it prefers USD.
 func SymbolToISO(symbol string) string {
 	symbolToISOOnce.Do(func() {
-		// Preferred codes for ambiguous symbols - first match wins
-		preferred := []string{"usd", "cny", "gbp", "eur"}
 		symbolToISOMap = make(map[string]string)
 
 		// First pass: set all mappings (last write wins)
@@ -283,7 +549,7 @@ func SymbolToISO(symbol string) string {
 			symbolToISOMap[sym] = strings.ToUpper(code)
 		}
 		// Second pass: override with preferred codes for ambiguous symbols
-		for _, code := range preferred {
+		for _, code := range preferredCurrencies {
 			if sym, ok := currencyCodeToSymbol[code]; ok {
 				symbolToISOMap[sym] = strings.ToUpper(code)
 			}
@@ -296,15 +562,80 @@ func SymbolToISO(symbol string) string {
 	return ""
 }
 
-// ResolveMember finds a member by username (case-insensitive) and returns their ID
+// SymbolToISOWithHint is like SymbolToISO, but when the symbol is shared by
+// several currencies and hint (an ISO code, e.g. "CAD" for "$") is one of
+// them, the hint is returned instead of the preferred currency.
+func SymbolToISOWithHint(symbol, hint string) string {
+	if hint != "" && currencyCodeToSymbol[strings.ToLower(hint)] == symbol {
+		return strings.ToUpper(hint)
+	}
+	return SymbolToISO(symbol)
+}
+
+// ResolveMember finds a member by username (case-insensitive) and returns their ID.
+// When several members match, an activated member is preferred.
 func ResolveMember(project *api.Project, username string) (int, error) {
+	member, err := findMember(project, username)
+	if err != nil {
+		return 0, err
+	}
+	return member.ID, nil
+}
+
+// ResolveActiveMember is like ResolveMember, but returns an error if the
+// matched member is deactivated
+func ResolveActiveMember(project *api.Project, username string) (int, error) {
+	member, err := findMember(project, username)
+	if err != nil {
+		return 0, err
+	}
+	if !member.Activated {
+		return 0, fmt.Errorf("member is deactivated: %s", member.Name)
+	}
+	return member.ID, nil
+}
+
+// findMember returns the best match for username, preferring activated members
+func findMember(project *api.Project, username string) (*api.Member, error) {
 	lowerUsername := strings.ToLower(username)
-	for _, m := range project.Members {
-		if strings.ToLower(m.Name) == lowerUsername || strings.ToLower(m.UserID) == lowerUsername {
-			return m.ID, nil
+	var match *api.Member
+	for i := range project.Members {
+		m := &project.Members[i]
+		if strings.ToLower(m.Name) != lowerUsername && strings.ToLower(m.UserID) != lowerUsername {
+			continue
+		}
+		if m.Activated {
+			return m, nil
+		}
+		if match == nil {
+			match = m
 		}
 	}
-	return 0, fmt.Errorf("member not found: %s", username)
+	if match == nil {
+		return nil, fmt.Errorf("member not found: %s", username)
+	}
+	return match, nil
+}
+
+// Match is a named item matched by a partial name
+type Match struct {
+	ID   int
+	Name string
+}
+
+// AmbiguousError is returned when a partial name matches more than one item
+type AmbiguousError struct {
+	Kind    string
+	Query   string
+	Matches []Match
+}
+
+func (e *AmbiguousError) Error() string {
+	names := make([]string, len(e.Matches))
+	for i, m := range e.Matches {
+		names[i] = fmt.Sprintf("%s (%d)", m.Name, m.ID)
+	}
+	return fmt.Sprintf("ambiguous %s: %s matches %s", e.Kind, e.Query, strings.Join(names, ", "))
 }
 
 // ResolveCategory finds a category by name (case-insensitive, substring) or ID and returns the ID
@@ -331,17 +662,25 @@ func ResolveCategory(project *api.Project, nameOrID string) (int, error) {
 		}
 	}
 
-	// Fallback to substring match
+	// Fallback to substring match, which must be unambiguous
+	var matches []Match
 	for _, c := range project.Categories {
 		if strings.Contains(strings.ToLower(c.Name), lowerName) {
-			return c.ID, nil
+			matches = append(matches, Match{ID: c.ID, Name: c.Name})
 		}
 	}
+	if len(matches) == 1 {
+		return matches[0].ID, nil
+	}
+	if len(matches) > 1 {
+		return 0, &AmbiguousError{Kind: "category", Query: nameOrID, Matches: matches}
+	}
 
 	return 0, fmt.Errorf("category not found: %s", nameOrID)
 }
 
-// ResolvePaymentMode finds a payment mode by name (case-insensitive, substring) or ID and returns the ID
+// ResolvePaymentMode finds a payment mode by name (case-insensitive, substring), ID, or the letter of a
+// built-in mode (e.g. "c" for cash) and returns the ID
 func ResolvePaymentMode(project *api.Project, nameOrID string) (int, error) {
 	if nameOrID == "" {
 		return 0, fmt.Errorf("payment mode not found: %s", nameOrID)
@@ -365,13 +704,27 @@ func ResolvePaymentMode(project *api.Project, nameOrID string) (int, error) {
 		}
 	}
 
-	// Fallback to substring match
+	// Then the built-in mode letter, before it's taken as a substring of names
 	for _, pm := range project.PaymentModes {
-		if strings.Contains(strings.ToLower(pm.Name), lowerName) {
+		if pm.OldID != "" && strings.ToLower(pm.OldID) == lowerName {
 			return pm.ID, nil
 		}
 	}
 
+	// Fallback to substring match, which must be unambiguous
+	var matches []Match
+	for _, pm := range project.PaymentModes {
+		if strings.Contains(strings.ToLower(pm.Name), lowerName) {
+			matches = append(matches, Match{ID: pm.ID, Name: pm.Name})
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0].ID, nil
+	}
+	if len(matches) > 1 {
+		return 0, &AmbiguousError{Kind: "payment mode", Query: nameOrID, Matches: matches}
+	}
+
 	return 0, fmt.Errorf("payment mode not found: %s", nameOrID)
 }
 
@@ -1,8 +1,12 @@
 package cache
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -93,12 +97,49 @@ func TestResolveCategory(t *testing.T) {
 	}
 }
 
+func TestResolveAmbiguous(t *testing.T) {
+	project := &api.Project{
+		Categories: []api.Category{
+			{ID: 1, Name: "Food"},
+			{ID: 2, Name: "Fast Food"},
+			{ID: 3, Name: "Seafood"},
+		},
+		PaymentModes: []api.PaymentMode{
+			{ID: 1, Name: "Credit Card"},
+			{ID: 2, Name: "Debit Card"},
+		},
+	}
+
+	// An exact match wins over other substring matches
+	if id, err := ResolveCategory(project, "food"); err != nil || id != 1 {
+		t.Errorf("ResolveCategory(food) = %d, %v; want 1", id, err)
+	}
+
+	_, err := ResolveCategory(project, "foo")
+	var ambErr *AmbiguousError
+	if !errors.As(err, &ambErr) {
+		t.Fatalf("Expected AmbiguousError, got %v", err)
+	}
+	if len(ambErr.Matches) != 3 || ambErr.Kind != "category" {
+		t.Errorf("Unexpected ambiguity: %+v", ambErr)
+	}
+	if !strings.Contains(err.Error(), "Fast Food (2)") || !strings.Contains(err.Error(), "Seafood (3)") {
+		t.Errorf("Error should list candidates, got %q", err.Error())
+	}
+
+	_, err = ResolvePaymentMode(project, "card")
+	if !errors.As(err, &ambErr) || len(ambErr.Matches) != 2 || ambErr.Kind != "payment mode" {
+		t.Errorf("Expected ambiguous payment mode, got %v", err)
+	}
+}
+
 func TestResolvePaymentMode(t *testing.T) {
 	project := &api.Project{
 		PaymentModes: []api.PaymentMode{
-			{ID: 1, Name: "Cash"},
-			{ID: 2, Name: "Credit Card"},
-			{ID: 3, Name: "Bank Transfer"},
+			{ID: 1, Name: "Cash", OldID: "c"},
+			{ID: 2, Name: "Credit Card", OldID: "b"},
+			{ID: 3, Name: "Bank Transfer", OldID: "t"},
+			{ID: 4, Name: "Gift"},
 		},
 	}
 
@@ -118,6 +159,9 @@ func TestResolvePaymentMode(t *testing.T) {
 		{"by substring", "credit", 2, false},
 		{"by substring single word", "card", 2, false},
 		{"by substring case insensitive", "BANK", 3, false},
+		{"by built-in letter", "c", 1, false},
+		{"by built-in letter not in name", "b", 2, false},
+		{"by built-in letter uppercase", "T", 3, false},
 		{"id not found", "99", 0, true},
 		{"name not found", "Bitcoin", 0, true},
 	}
@@ -204,6 +248,76 @@ func TestCurrencyCodeToSymbolMapping(t *testing.T) {
 	}
 }
 
+func TestAmbiguousSymbolsHavePreference(t *testing.T) {
+	codesBySymbol := make(map[string][]string)
+	for code, sym := range currencyCodeToSymbol {
+		codesBySymbol[sym] = append(codesBySymbol[sym], code)
+	}
+
+	for sym, codes := range codesBySymbol {
+		if len(codes) < 2 {
+			continue
+		}
+		found := 0
+		for _, code := range preferredCurrencies {
+			if currencyCodeToSymbol[code] == sym {
+				found++
+			}
+		}
+		if found != 1 {
+			t.Errorf("Symbol %q is shared by %v and needs exactly one entry in preferredCurrencies, has %d", sym, codes, found)
+		}
+	}
+}
+
+func TestSymbolToISOWithHint(t *testing.T) {
+	tests := []struct {
+		symbol string
+		hint   string
+		want   string
+	}{
+		{"$", "", "USD"},
+		{"$", "CAD", "CAD"},
+		{"$", "aud", "AUD"},
+		{"$", "EUR", "USD"}, // hint doesn't use the symbol
+		{"kr", "", "SEK"},
+		{"kr", "NOK", "NOK"},
+		{"kr", "DKK", "DKK"},
+		{"kr", "ISK", "ISK"},
+		{"€", "USD", "EUR"},
+		{"?", "USD", ""},
+	}
+
+	for _, tt := range tests {
+		if got := SymbolToISOWithHint(tt.symbol, tt.hint); got != tt.want {
+			t.Errorf("SymbolToISOWithHint(%q, %q) = %q, want %q", tt.symbol, tt.hint, got, tt.want)
+		}
+	}
+}
+
+func TestGetCacheDir(t *testing.T) {
+	xdgDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", xdgDir)
+	t.Setenv("COSPEND_CACHE_DIR", "")
+
+	if got, want := GetCacheDir(), filepath.Join(xdgDir, appName); got != want {
+		t.Errorf("GetCacheDir() = %q, want %q", got, want)
+	}
+
+	customDir := filepath.Join(t.TempDir(), "custom")
+	t.Setenv("COSPEND_CACHE_DIR", customDir)
+	if got := GetCacheDir(); got != customDir {
+		t.Errorf("GetCacheDir() = %q, want %q (COSPEND_CACHE_DIR used as-is)", got, customDir)
+	}
+
+	if err := Save("p1", &api.Project{ID: "p1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(customDir, "p1.json")); err != nil {
+		t.Errorf("Expected cache file in COSPEND_CACHE_DIR: %v", err)
+	}
+}
+
 func TestSaveAndLoad(t *testing.T) {
 	// Use a temp directory for testing
 	tempDir := t.TempDir()
@@ -288,7 +402,7 @@ func TestLoadExpired(t *testing.T) {
 	// Manually update the cached_at field in the file
 	// Replace the timestamp in the JSON (crude but works for testing)
 	oldTimestamp := time.Now().Add(-2 * time.Hour).Format(time.RFC3339Nano)
-	newData := []byte(`{"project":{"id":"expired-project","name":"Expired Project","members":null,"categories":null,"paymentmodes":null,"currencies":null},"cached_at":"` + oldTimestamp + `"}`)
+	newData := []byte(`{"schema_version":` + strconv.Itoa(schemaVersion) + `,"project":{"id":"expired-project","name":"Expired Project","members":null,"categories":null,"paymentmodes":null,"currencies":null},"cached_at":"` + oldTimestamp + `"}`)
 	_ = os.WriteFile(cachePath, newData, 0644)
 
 	_, ok := Load("expired-project")
@@ -296,3 +410,234 @@ func TestLoadExpired(t *testing.T) {
 		t.Error("Load() returned true for expired cache, expected false")
 	}
 }
+
+func TestLoadSchemaMismatch(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+
+	if err := Save("proj", &api.Project{ID: "proj"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := SaveUserInfo(&api.UserInfo{ID: "alice"}); err != nil {
+		t.Fatalf("SaveUserInfo() error = %v", err)
+	}
+	if _, ok := Load("proj"); !ok {
+		t.Fatal("Load() should hit with the current schema")
+	}
+
+	// Files written before versioning (or by another version) are refetched
+	fresh := time.Now().Format(time.RFC3339Nano)
+	files := map[string]string{
+		"proj.json":      `{"project":{"id":"proj"},"cached_at":"` + fresh + `"}`,
+		"_userinfo.json": `{"schema_version":` + strconv.Itoa(schemaVersion+1) + `,"user_info":{"id":"alice"},"cached_at":"` + fresh + `"}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, "cospend", name), []byte(data), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	if _, ok := Load("proj"); ok {
+		t.Error("Load() should miss for an unversioned cache file")
+	}
+	if _, ok := LoadUserInfo(); ok {
+		t.Error("LoadUserInfo() should miss for another schema version")
+	}
+}
+
+func TestLoadPreArchivedSchema(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+
+	// Version 2 projects were cached without archived_ts, so an archived
+	// project would load as active
+	fresh := time.Now().Format(time.RFC3339Nano)
+	data := `{"schema_version":2,"project":{"id":"old","name":"Old"},"cached_at":"` + fresh + `"}`
+	if err := os.MkdirAll(filepath.Join(tempDir, "cospend"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "cospend", "old.json"), []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, ok := Load("old"); ok {
+		t.Error("Load() should miss for a version 2 cache file")
+	}
+
+	archived := int64(1700000000)
+	if err := Save("old", &api.Project{ID: "old", ArchivedTS: &archived}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	project, ok := Load("old")
+	if !ok || !project.IsArchived() {
+		t.Errorf("Load() = %+v, %v, want the archived project", project, ok)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if err := Save("proj", &api.Project{ID: "proj"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := Invalidate("proj"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, ok := Load("proj"); ok {
+		t.Error("Load() should miss after Invalidate()")
+	}
+	// Invalidating a missing entry is not an error
+	if err := Invalidate("proj"); err != nil {
+		t.Errorf("Invalidate() on missing entry error = %v", err)
+	}
+}
+
+func TestConcurrentSaves(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	// Writers with differently sized projects would leave a mix of both
+	// in the file if writes interleaved
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			project := &api.Project{ID: "proj", Name: strings.Repeat("x", i*100)}
+			for range 10 {
+				if err := Save("proj", project); err != nil {
+					t.Errorf("Save() error = %v", err)
+				}
+				if err := SaveUserInfo(&api.UserInfo{ID: strings.Repeat("u", i*100)}); err != nil {
+					t.Errorf("SaveUserInfo() error = %v", err)
+				}
+				if _, ok := Load("proj"); !ok {
+					t.Error("Load() should always read a complete cache file")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := Load("proj"); !ok {
+		t.Error("Load() after concurrent saves should hit")
+	}
+	if _, ok := LoadUserInfo(); !ok {
+		t.Error("LoadUserInfo() after concurrent saves should hit")
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(GetCacheDir())
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("Leftover temporary file: %s", e.Name())
+		}
+	}
+}
+
+func TestLastSeen(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if _, ok := LoadLastSeen("proj"); ok {
+		t.Error("LoadLastSeen() should miss before SaveLastSeen()")
+	}
+
+	seenAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := SaveLastSeen("proj", seenAt); err != nil {
+		t.Fatalf("SaveLastSeen() error = %v", err)
+	}
+	got, ok := LoadLastSeen("proj")
+	if !ok || !got.Equal(seenAt) {
+		t.Errorf("LoadLastSeen() = %v, %v, want %v", got, ok, seenAt)
+	}
+
+	// The marker outlives the project cache
+	if err := Invalidate("proj"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, ok := LoadLastSeen("proj"); !ok {
+		t.Error("LoadLastSeen() should still hit after Invalidate()")
+	}
+}
+
+func TestLastAction(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if _, ok := LoadLastAction("proj"); ok {
+		t.Error("LoadLastAction() should miss before SaveLastAction()")
+	}
+
+	if err := SaveLastAction("proj", LastAction{Kind: ActionAdd, BillID: 42}); err != nil {
+		t.Fatalf("SaveLastAction() error = %v", err)
+	}
+	got, ok := LoadLastAction("proj")
+	if !ok || got.Kind != ActionAdd || got.BillID != 42 {
+		t.Errorf("LoadLastAction() = %+v, %v, want add #42", got, ok)
+	}
+
+	// A delete replaces the add and keeps the bill
+	deleted := &api.BillResponse{ID: 7, What: "Dinner", Amount: 30, Owers: []api.Ower{{ID: 1, Weight: 1}}}
+	if err := SaveLastAction("proj", LastAction{Kind: ActionDelete, BillID: 7, Bill: deleted}); err != nil {
+		t.Fatalf("SaveLastAction() error = %v", err)
+	}
+	got, ok = LoadLastAction("proj")
+	if !ok || got.Kind != ActionDelete || got.Bill == nil || got.Bill.What != "Dinner" || len(got.Bill.Owers) != 1 {
+		t.Errorf("LoadLastAction() = %+v, %v, want the deleted bill", got, ok)
+	}
+
+	// A delete without the bill can't be undone
+	if err := SaveLastAction("proj", LastAction{Kind: ActionDelete, BillID: 7}); err != nil {
+		t.Fatalf("SaveLastAction() error = %v", err)
+	}
+	if _, ok := LoadLastAction("proj"); ok {
+		t.Error("LoadLastAction() should miss a delete without its bill")
+	}
+
+	if err := ClearLastAction("proj"); err != nil {
+		t.Fatalf("ClearLastAction() error = %v", err)
+	}
+	if _, ok := LoadLastAction("proj"); ok {
+		t.Error("LoadLastAction() should miss after ClearLastAction()")
+	}
+}
+
+func TestResolveMemberPrefersActivated(t *testing.T) {
+	project := &api.Project{
+		Members: []api.Member{
+			{ID: 1, Name: "Alex", Activated: false},
+			{ID: 2, Name: "alex", UserID: "alex2", Activated: true},
+			{ID: 3, Name: "Sam", Activated: false},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		username   string
+		wantID     int
+		wantActive int
+		activeErr  bool
+	}{
+		{"shared name prefers activated", "Alex", 2, 2, false},
+		{"only deactivated match", "Sam", 3, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, err := ResolveMember(project, tt.username)
+			if err != nil {
+				t.Fatalf("ResolveMember() error = %v", err)
+			}
+			if gotID != tt.wantID {
+				t.Errorf("ResolveMember() = %v, want %v", gotID, tt.wantID)
+			}
+
+			gotActive, err := ResolveActiveMember(project, tt.username)
+			if (err != nil) != tt.activeErr {
+				t.Fatalf("ResolveActiveMember() error = %v, wantErr %v", err, tt.activeErr)
+			}
+			if gotActive != tt.wantActive {
+				t.Errorf("ResolveActiveMember() = %v, want %v", gotActive, tt.wantActive)
+			}
+		})
+	}
+}
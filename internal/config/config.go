@@ -1,9 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,14 +17,64 @@ import (
 
 const appName = "cospend"
 
-// NormalizeURL trims trailing slashes and prepends https:// if no scheme is present.
-func NormalizeURL(url string) string {
-	url = strings.TrimRight(url, "/")
-	lower := strings.ToLower(url)
+// nextcloudPaths start Nextcloud's own routes. A pasted URL is cut at the
+// first one, leaving the base URL of the instance (including any subpath).
+var nextcloudPaths = []string{"/index.php", "/remote.php", "/status.php", "/ocs/", "/apps/", "/login"}
+
+// NormalizeURL prepends https:// if no scheme is present, and drops any query,
+// fragment, known Nextcloud path (like /index.php/apps/cospend), and trailing
+// slashes. Ports and subpaths are kept.
+func NormalizeURL(domain string) string {
+	domain = strings.TrimSpace(domain)
+	lower := strings.ToLower(domain)
 	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
-		url = "https://" + url
+		domain = "https://" + domain
+	}
+
+	if i := strings.IndexAny(domain, "?#"); i >= 0 {
+		domain = domain[:i]
+	}
+
+	hostStart := strings.Index(domain, "://") + len("://")
+	end := len(domain)
+	for _, p := range nextcloudPaths {
+		if i := indexPathSegment(domain[hostStart:end], p); i >= 0 {
+			end = hostStart + i
+		}
+	}
+	return strings.TrimRight(domain[:end], "/")
+}
+
+// indexPathSegment returns the index of the first case-insensitive match of
+// the ASCII path p in s, or -1. Unless p ends in a slash, the match must also
+// end a path segment, so "/login" doesn't match "/loginportal".
+func indexPathSegment(s, p string) int {
+	for i := 0; i+len(p) <= len(s); i++ {
+		if !strings.EqualFold(s[i:i+len(p)], p) {
+			continue
+		}
+		rest := s[i+len(p):]
+		if strings.HasSuffix(p, "/") || rest == "" || rest[0] == '/' || rest[0] == '?' {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidateDomain returns an error if domain can't be used as a Nextcloud base
+// URL once normalized, e.g. because it contains spaces or has no host.
+func ValidateDomain(domain string) error {
+	if strings.ContainsAny(strings.TrimSpace(domain), " \t\r\n") {
+		return fmt.Errorf("invalid domain %q: must not contain spaces", domain)
+	}
+	u, err := url.Parse(NormalizeURL(domain))
+	if err != nil {
+		return fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("invalid domain %q: missing host", domain)
 	}
-	return url
+	return nil
 }
 
 // Config holds the Nextcloud configuration
@@ -34,6 +86,60 @@ type Config struct {
 	ConfirmAdd     bool   `json:"confirm_add,omitempty" yaml:"confirm_add,omitempty" toml:"confirm_add,omitempty"`
 	ConfirmDelete  bool   `json:"confirm_delete,omitempty" yaml:"confirm_delete,omitempty" toml:"confirm_delete,omitempty"`
 	ConfirmUpdate  bool   `json:"confirm_update,omitempty" yaml:"confirm_update,omitempty" toml:"confirm_update,omitempty"`
+	Locale         string `json:"locale,omitempty" yaml:"locale,omitempty" toml:"locale,omitempty"`
+	Currency       string `json:"currency,omitempty" yaml:"currency,omitempty" toml:"currency,omitempty"`
+	CurrencyHint   string `json:"currency_hint,omitempty" yaml:"currency_hint,omitempty" toml:"currency_hint,omitempty"`
+	Workers        int    `json:"workers,omitempty" yaml:"workers,omitempty" toml:"workers,omitzero"`
+	DateFormat     string `json:"date_format,omitempty" yaml:"date_format,omitempty" toml:"date_format,omitempty"`
+	Timezone       string `json:"timezone,omitempty" yaml:"timezone,omitempty" toml:"timezone,omitempty"`
+
+	DefaultPaymentMode string `json:"default_payment_mode,omitempty" yaml:"default_payment_mode,omitempty" toml:"default_payment_mode,omitempty"`
+	DefaultCategory    string `json:"default_category,omitempty" yaml:"default_category,omitempty" toml:"default_category,omitempty"`
+
+	// Projects holds settings for individual projects, keyed by project ID
+	Projects map[string]ProjectConfig `json:"projects,omitempty" yaml:"projects,omitempty" toml:"projects,omitempty"`
+}
+
+// ProjectConfig holds settings that override the global ones for one project
+type ProjectConfig struct {
+	DefaultPaymentMode string `json:"default_payment_mode,omitempty" yaml:"default_payment_mode,omitempty" toml:"default_payment_mode,omitempty"`
+	DefaultCategory    string `json:"default_category,omitempty" yaml:"default_category,omitempty" toml:"default_category,omitempty"`
+}
+
+// Project returns the settings for projectID, or zero settings if it has none
+func (c *Config) Project(projectID string) ProjectConfig {
+	return c.Projects[projectID]
+}
+
+// SetProject stores the settings for projectID, dropping the project's entry
+// when all of them are empty
+func (c *Config) SetProject(projectID string, p ProjectConfig) {
+	if p == (ProjectConfig{}) {
+		delete(c.Projects, projectID)
+		return
+	}
+	if c.Projects == nil {
+		c.Projects = make(map[string]ProjectConfig)
+	}
+	c.Projects[projectID] = p
+}
+
+// PaymentModeFor returns the default payment mode for new bills in projectID:
+// the project's own default if set, otherwise the global one
+func (c *Config) PaymentModeFor(projectID string) string {
+	if mode := c.Project(projectID).DefaultPaymentMode; mode != "" {
+		return mode
+	}
+	return c.DefaultPaymentMode
+}
+
+// CategoryFor returns the default category for new bills in projectID: the
+// project's own default if set, otherwise the global one
+func (c *Config) CategoryFor(projectID string) string {
+	if category := c.Project(projectID).DefaultCategory; category != "" {
+		return category
+	}
+	return c.DefaultCategory
 }
 
 // configExtensions lists supported config file extensions in order of preference
@@ -63,8 +169,28 @@ func getConfigDirs() []string {
 	return dirs
 }
 
+// explicitPath is a config file set with SetConfigPath, used instead of searching the config dirs
+var explicitPath string
+
+// SetConfigPath makes Load and GetConfigPath use path instead of searching the
+// default config locations. An empty path restores the default search.
+func SetConfigPath(path string) {
+	explicitPath = path
+}
+
+// ExplicitConfigPath returns the path set with SetConfigPath, or empty string if none
+func ExplicitConfigPath() string {
+	return explicitPath
+}
+
 // GetConfigPath returns the path to an existing config file, or empty string if none found
 func GetConfigPath() string {
+	if explicitPath != "" {
+		if _, err := os.Stat(explicitPath); err == nil {
+			return explicitPath
+		}
+		return ""
+	}
 	for _, configDir := range getConfigDirs() {
 		for _, ext := range configExtensions {
 			path := filepath.Join(configDir, appName+ext)
@@ -76,8 +202,37 @@ func GetConfigPath() string {
 	return ""
 }
 
+// ErrConfig matches the errors Load and LoadFromFile return for configuration
+// that's missing, unreadable, or invalid
+var ErrConfig = errors.New("configuration error")
+
+// configError is a configuration error. It matches ErrConfig.
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configError) Unwrap() error {
+	return e.err
+}
+
+func (e *configError) Is(target error) bool {
+	return target == ErrConfig
+}
+
 // LoadFromFile reads configuration from a config file
 func LoadFromFile(path string) (*Config, error) {
+	cfg, err := loadFromFile(path)
+	if err != nil {
+		return nil, &configError{err}
+	}
+	return cfg, nil
+}
+
+func loadFromFile(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading config file: %w", err)
@@ -108,12 +263,29 @@ func LoadFromFile(path string) (*Config, error) {
 
 // Load reads configuration with the following precedence:
 // 1. Environment variables (override config file)
-// 2. Config file
+// 2. Env file (the path set with SetEnvFile or COSPEND_ENV_FILE, if any)
+// 3. Config file
+//
+// A config file password of KeyringPassword is looked up in the OS keyring,
+// unless NEXTCLOUD_PASSWORD is set. A path set with SetConfigPath must exist.
 func Load() (*Config, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, &configError{err}
+	}
+	return cfg, nil
+}
+
+func load() (*Config, error) {
 	var cfg Config
 
+	configPath := GetConfigPath()
+	if explicitPath != "" {
+		configPath = explicitPath
+	}
+
 	// Try to load from config file first
-	if configPath := GetConfigPath(); configPath != "" {
+	if configPath != "" {
 		fileCfg, err := LoadFromFile(configPath)
 		if err != nil {
 			return nil, err
@@ -121,21 +293,34 @@ func Load() (*Config, error) {
 		cfg = *fileCfg
 	}
 
-	// Environment variables override config file values
-	if domain := os.Getenv("NEXTCLOUD_DOMAIN"); domain != "" {
+	// Read the env file, if one is enabled
+	if err := LoadEnvFile(); err != nil {
+		return nil, err
+	}
+
+	// Environment variables, then the env file, override config file values
+	if domain := Getenv("NEXTCLOUD_DOMAIN"); domain != "" {
 		cfg.Domain = domain
 	}
-	if user := os.Getenv("NEXTCLOUD_USER"); user != "" {
+	if user := Getenv("NEXTCLOUD_USER"); user != "" {
 		cfg.User = user
 	}
-	if password := os.Getenv("NEXTCLOUD_PASSWORD"); password != "" {
+	if password := Getenv("NEXTCLOUD_PASSWORD"); password != "" {
 		cfg.Password = password
 	}
 
+	// Read password from keyring if the config file defers to it
+	if err := ResolvePassword(&cfg); err != nil {
+		return nil, err
+	}
+
 	// Validate required fields
 	if cfg.Domain == "" {
 		return nil, errors.New("domain is required (set in config file or NEXTCLOUD_DOMAIN env var)")
 	}
+	if err := ValidateDomain(cfg.Domain); err != nil {
+		return nil, err
+	}
 	if cfg.User == "" {
 		return nil, errors.New("user is required (set in config file or NEXTCLOUD_USER env var)")
 	}
@@ -221,36 +406,27 @@ func LoadRaw() *Config {
 		}
 	}
 
-	if domain := os.Getenv("NEXTCLOUD_DOMAIN"); domain != "" {
+	_ = LoadEnvFile()
+
+	if domain := Getenv("NEXTCLOUD_DOMAIN"); domain != "" {
 		cfg.Domain = domain
 	}
-	if user := os.Getenv("NEXTCLOUD_USER"); user != "" {
+	if user := Getenv("NEXTCLOUD_USER"); user != "" {
 		cfg.User = user
 	}
-	if password := os.Getenv("NEXTCLOUD_PASSWORD"); password != "" {
+	if password := Getenv("NEXTCLOUD_PASSWORD"); password != "" {
 		cfg.Password = password
 	}
 
 	return &cfg
 }
 
-// tomlMarshal encodes config to TOML format
+// tomlMarshal encodes config to TOML format. Fields are written according to
+// their toml tags, so new config fields are saved without changes here.
 func tomlMarshal(cfg *Config) ([]byte, error) {
-	content := fmt.Sprintf(`domain = %q
-user = %q
-password = %q
-`, cfg.Domain, cfg.User, cfg.Password)
-	if cfg.DefaultProject != "" {
-		content += fmt.Sprintf("default_project = %q\n", cfg.DefaultProject)
-	}
-	if cfg.ConfirmAdd {
-		content += "confirm_add = true\n"
-	}
-	if cfg.ConfirmDelete {
-		content += "confirm_delete = true\n"
-	}
-	if cfg.ConfirmUpdate {
-		content += "confirm_update = true\n"
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, err
 	}
-	return []byte(content), nil
+	return buf.Bytes(), nil
 }
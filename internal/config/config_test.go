@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -275,6 +277,61 @@ func TestSaveTOML(t *testing.T) {
 	}
 }
 
+func TestSaveTOMLRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	cfg := &Config{
+		Domain:         "https://test.example.com",
+		User:           "testuser",
+		Password:       "test\"pass",
+		DefaultProject: "myproject",
+		ConfirmAdd:     true,
+		ConfirmDelete:  true,
+		ConfirmUpdate:  true,
+		Locale:         "de_DE",
+		Currency:       "EUR",
+		CurrencyHint:   "CAD",
+		Workers:        8,
+		Projects: map[string]ProjectConfig{
+			"groceries": {DefaultPaymentMode: "Card", DefaultCategory: "Groceries"},
+		},
+	}
+
+	path, err := Save(cfg, "toml")
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("Round trip mismatch:\n got  %+v\n want %+v", *loaded, *cfg)
+	}
+}
+
+func TestSaveTOMLOmitsEmptyFields(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	path, err := Save(&Config{Domain: "https://test.example.com", User: "u", Password: "p"}, "toml")
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	for _, key := range []string{"default_project", "confirm_add", "locale", "workers"} {
+		if strings.Contains(string(data), key) {
+			t.Errorf("Empty field %s should be omitted, got:\n%s", key, data)
+		}
+	}
+}
+
 func TestGetConfigPath(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Setenv("HOME", tempDir) // Isolate from real home
@@ -421,3 +478,166 @@ func TestXDGTakesPrecedenceOverDotConfig(t *testing.T) {
 		t.Errorf("Domain = %v, want %v (XDG should take precedence)", cfg.Domain, "https://xdg.example.com")
 	}
 }
+
+func TestLoadFromExplicitPath(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("NEXTCLOUD_DOMAIN", "")
+	t.Setenv("NEXTCLOUD_USER", "")
+	t.Setenv("NEXTCLOUD_PASSWORD", "envpass")
+
+	// A config in the default location should be ignored
+	defaultCfg := &Config{Domain: "https://default.example.com", User: "default", Password: "defaultpass"}
+	if _, err := Save(defaultCfg, "json"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	path := filepath.Join(tempDir, "other", "server.yaml")
+	if _, err := SaveToPath(&Config{Domain: "https://other.example.com", User: "other", Password: "otherpass"}, path); err != nil {
+		t.Fatalf("SaveToPath() error = %v", err)
+	}
+
+	SetConfigPath(path)
+	defer SetConfigPath("")
+
+	if got := GetConfigPath(); got != path {
+		t.Errorf("GetConfigPath() = %q, want %q", got, path)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Domain != "https://other.example.com" {
+		t.Errorf("Domain = %v, want %v", cfg.Domain, "https://other.example.com")
+	}
+	if cfg.Password != "envpass" {
+		t.Errorf("Password = %v, want env override %v", cfg.Password, "envpass")
+	}
+}
+
+func TestLoadFromMissingExplicitPath(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("NEXTCLOUD_DOMAIN", "https://env.example.com")
+	t.Setenv("NEXTCLOUD_USER", "envuser")
+	t.Setenv("NEXTCLOUD_PASSWORD", "envpass")
+
+	SetConfigPath(filepath.Join(tempDir, "missing.json"))
+	defer SetConfigPath("")
+
+	if got := GetConfigPath(); got != "" {
+		t.Errorf("GetConfigPath() = %q, want empty for missing file", got)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for missing explicit config file")
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"cloud.example.com", "https://cloud.example.com"},
+		{"  cloud.example.com/  ", "https://cloud.example.com"},
+		{"http://host:8080", "http://host:8080"},
+		{"http://host:8080/", "http://host:8080"},
+		{"https://cloud.example.com/index.php", "https://cloud.example.com"},
+		{"https://cloud.example.com/index.php/apps/cospend/", "https://cloud.example.com"},
+		{"https://cloud.example.com/apps/cospend/p/myproject", "https://cloud.example.com"},
+		{"https://cloud.example.com/ocs/v2.php/apps/cospend/api/v1/projects", "https://cloud.example.com"},
+		{"https://cloud.example.com/remote.php/dav", "https://cloud.example.com"},
+		{"https://cloud.example.com/login?redirect_url=/apps/files", "https://cloud.example.com"},
+		{"https://Cloud.Example.com/Index.php#top", "https://Cloud.Example.com"},
+		{"https://example.com/nextcloud/index.php/apps/cospend", "https://example.com/nextcloud"},
+		{"http://host:8080/nextcloud/ocs/v2.php", "http://host:8080/nextcloud"},
+		{"https://example.com/Ünïcode/INDEX.PHP/apps", "https://example.com/Ünïcode"},
+		{"https://example.com/straße/Login", "https://example.com/straße"},
+		{"https://example.com/İstanbul/index.php", "https://example.com/İstanbul"},
+		{"https://example.com/loginportal", "https://example.com/loginportal"},
+		{"https://example.com/loginportal/login/", "https://example.com/loginportal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeURL(tt.input); got != tt.expected {
+				t.Errorf("NormalizeURL(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	valid := []string{
+		"cloud.example.com",
+		"https://cloud.example.com/index.php/apps/cospend",
+		"http://host:8080",
+		"https://example.com/nextcloud",
+		"192.168.1.10:8443",
+	}
+	for _, domain := range valid {
+		if err := ValidateDomain(domain); err != nil {
+			t.Errorf("ValidateDomain(%q) unexpected error: %v", domain, err)
+		}
+	}
+
+	invalid := []string{
+		"cloud example.com",
+		"https://",
+		"https:///index.php",
+		"http://host:port",
+	}
+	for _, domain := range invalid {
+		if err := ValidateDomain(domain); err == nil {
+			t.Errorf("ValidateDomain(%q) expected error", domain)
+		}
+	}
+}
+
+func TestLoadRejectsMalformedDomain(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("NEXTCLOUD_DOMAIN", "https://cloud example.com")
+	t.Setenv("NEXTCLOUD_USER", "alice")
+	t.Setenv("NEXTCLOUD_PASSWORD", "secret")
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "invalid domain") {
+		t.Errorf("Load() error = %v, want an invalid domain error", err)
+	}
+}
+
+func TestPaymentModeFor(t *testing.T) {
+	cfg := &Config{DefaultPaymentMode: "Cash"}
+	cfg.SetProject("groceries", ProjectConfig{DefaultPaymentMode: "Card"})
+
+	if got := cfg.PaymentModeFor("groceries"); got != "Card" {
+		t.Errorf("PaymentModeFor(groceries) = %q, want project default Card", got)
+	}
+	if got := cfg.PaymentModeFor("trip"); got != "Cash" {
+		t.Errorf("PaymentModeFor(trip) = %q, want global default Cash", got)
+	}
+
+	// Clearing the project's only setting drops its entry
+	cfg.SetProject("groceries", ProjectConfig{})
+	if _, ok := cfg.Projects["groceries"]; ok {
+		t.Error("SetProject() with empty settings should remove the project entry")
+	}
+	if got := cfg.PaymentModeFor("groceries"); got != "Cash" {
+		t.Errorf("PaymentModeFor(groceries) after clearing = %q, want Cash", got)
+	}
+}
+
+func TestCategoryFor(t *testing.T) {
+	cfg := &Config{DefaultCategory: "Misc"}
+	cfg.SetProject("groceries", ProjectConfig{DefaultCategory: "Groceries"})
+
+	if got := cfg.CategoryFor("groceries"); got != "Groceries" {
+		t.Errorf("CategoryFor(groceries) = %q, want project default Groceries", got)
+	}
+	if got := cfg.CategoryFor("trip"); got != "Misc" {
+		t.Errorf("CategoryFor(trip) = %q, want global default Misc", got)
+	}
+}
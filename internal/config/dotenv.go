@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envFileVar names the environment variable that enables an env file when
+// --env-file isn't given
+const envFileVar = "COSPEND_ENV_FILE"
+
+// envFilePath is an env file set with SetEnvFile
+var envFilePath string
+
+// envFileVars holds the variables read by LoadEnvFile. They're kept apart
+// from the process environment and read through Getenv.
+var envFileVars map[string]string
+
+// SetEnvFile makes Load read variables from the env file at path. An empty
+// path falls back to COSPEND_ENV_FILE, and without that no env file is read.
+func SetEnvFile(path string) {
+	envFilePath = path
+}
+
+// envFilePrefixes are the variables an env file may set; anything else in the
+// file is ignored, so a project's own .env doesn't leak into the config
+var envFilePrefixes = []string{"NEXTCLOUD_", "COSPEND_"}
+
+// LoadEnvFile reads the NEXTCLOUD_* and COSPEND_* variables of the env file set
+// with SetEnvFile or COSPEND_ENV_FILE, for Getenv. Env files are opt-in: a
+// .env in the current directory could otherwise point the domain, and with it
+// the user's credentials, at another server.
+func LoadEnvFile() error {
+	envFileVars = nil
+
+	path := envFilePath
+	if path == "" {
+		path = os.Getenv(envFileVar)
+	}
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading env file: %w", err)
+	}
+
+	vars, err := parseEnvFile(data)
+	if err != nil {
+		return fmt.Errorf("parsing env file %s: %w", path, err)
+	}
+
+	envFileVars = make(map[string]string)
+	for key, value := range vars {
+		if hasEnvFilePrefix(key) {
+			envFileVars[key] = value
+		}
+	}
+	return nil
+}
+
+// Getenv returns the environment variable key or, when it's unset, its value
+// from the env file, so real environment variables take precedence
+func Getenv(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return envFileVars[key]
+}
+
+func hasEnvFilePrefix(key string) bool {
+	for _, prefix := range envFilePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEnvFile parses KEY=VALUE lines. Blank lines, # comments, and an
+// "export " prefix are allowed. Values may be single quoted (taken literally)
+// or double quoted (supporting \n, \", and \\ escapes); unquoted values end at
+// an inline " #" comment.
+func parseEnvFile(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+func parseEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch quote := value[0]; quote {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return value[1 : end+1], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			switch {
+			case c == '"':
+				return b.String(), nil
+			case c == '\\' && i+1 < len(value):
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(value[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated quoted value")
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value), nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	data := `# credentials
+NEXTCLOUD_DOMAIN=cloud.example.com
+export NEXTCLOUD_USER = alice
+NEXTCLOUD_PASSWORD="p@ss \"word\"\n2"
+COSPEND_CACHE_DIR='/tmp/my #cache'
+OTHER=value # trailing comment
+EMPTY=
+`
+	vars, err := parseEnvFile([]byte(data))
+	if err != nil {
+		t.Fatalf("parseEnvFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"NEXTCLOUD_DOMAIN":   "cloud.example.com",
+		"NEXTCLOUD_USER":     "alice",
+		"NEXTCLOUD_PASSWORD": "p@ss \"word\"\n2",
+		"COSPEND_CACHE_DIR":  "/tmp/my #cache",
+		"OTHER":              "value",
+		"EMPTY":              "",
+	}
+	if len(vars) != len(want) {
+		t.Errorf("Got %d vars, want %d: %v", len(vars), len(want), vars)
+	}
+	for key, value := range want {
+		if vars[key] != value {
+			t.Errorf("%s = %q, want %q", key, vars[key], value)
+		}
+	}
+}
+
+func TestParseEnvFileErrors(t *testing.T) {
+	tests := []string{
+		"NO_EQUALS",
+		"=value",
+		"BAD KEY=value",
+		`QUOTED="unterminated`,
+		"QUOTED='unterminated",
+	}
+	for _, data := range tests {
+		if _, err := parseEnvFile([]byte("OK=1\n" + data)); err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("parseEnvFile(%q) error = %v, want a line 2 error", data, err)
+		}
+	}
+}
+
+// setupEnvFileTest isolates config and env file lookup, clearing the
+// credential variables (t.Setenv restores them after the test)
+func setupEnvFileTest(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	for _, key := range []string{"NEXTCLOUD_DOMAIN", "NEXTCLOUD_USER", "NEXTCLOUD_PASSWORD", "COSPEND_CACHE_DIR", "COSPEND_ENV_FILE", "UNRELATED_VAR"} {
+		t.Setenv(key, "")
+	}
+	t.Chdir(tempDir)
+	t.Cleanup(func() {
+		SetEnvFile("")
+		envFileVars = nil
+	})
+	return tempDir
+}
+
+func TestLoadFromEnvFile(t *testing.T) {
+	tempDir := setupEnvFileTest(t)
+	t.Setenv("NEXTCLOUD_USER", "envuser")
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configContent := `{"domain": "https://file.example.com", "user": "fileuser", "password": "filepass"}`
+	if err := os.WriteFile(filepath.Join(configDir, "cospend.json"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	envContent := "NEXTCLOUD_DOMAIN=https://dotenv.example.com\nNEXTCLOUD_USER=dotenvuser\nCOSPEND_CACHE_DIR=/tmp/cospend-cache\nUNRELATED_VAR=x\n"
+	if err := os.WriteFile(filepath.Join(tempDir, ".env"), []byte(envContent), 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	// A .env in the current directory isn't read unless asked for
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Domain != "https://file.example.com" {
+		t.Errorf("Domain = %v, want the config file's, ./.env must be opt-in", cfg.Domain)
+	}
+
+	SetEnvFile(".env")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// .env overrides the config file
+	if cfg.Domain != "https://dotenv.example.com" {
+		t.Errorf("Domain = %v, want https://dotenv.example.com", cfg.Domain)
+	}
+	// Real environment variables override .env
+	if cfg.User != "envuser" {
+		t.Errorf("User = %v, want envuser", cfg.User)
+	}
+	// Values missing from .env still come from the config file
+	if cfg.Password != "filepass" {
+		t.Errorf("Password = %v, want filepass", cfg.Password)
+	}
+	if got := Getenv("COSPEND_CACHE_DIR"); got != "/tmp/cospend-cache" {
+		t.Errorf("COSPEND_CACHE_DIR = %q, want /tmp/cospend-cache", got)
+	}
+	if got := Getenv("UNRELATED_VAR"); got != "" {
+		t.Errorf("UNRELATED_VAR = %q, want it ignored", got)
+	}
+	// The process environment is left alone
+	if got := os.Getenv("NEXTCLOUD_DOMAIN"); got != "" {
+		t.Errorf("NEXTCLOUD_DOMAIN = %q in the environment, want it unset", got)
+	}
+
+	// COSPEND_ENV_FILE enables an env file without the flag
+	SetEnvFile("")
+	t.Setenv("COSPEND_ENV_FILE", filepath.Join(tempDir, ".env"))
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Domain != "https://dotenv.example.com" {
+		t.Errorf("Domain = %v, want https://dotenv.example.com from COSPEND_ENV_FILE", cfg.Domain)
+	}
+}
+
+func TestLoadFromExplicitEnvFile(t *testing.T) {
+	tempDir := setupEnvFileTest(t)
+
+	envPath := filepath.Join(tempDir, "secrets.env")
+	envContent := "NEXTCLOUD_DOMAIN=https://cloud.example.com\nNEXTCLOUD_USER=alice\nNEXTCLOUD_PASSWORD=secret\n"
+	if err := os.WriteFile(envPath, []byte(envContent), 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	SetEnvFile(envPath)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.User != "alice" || cfg.Password != "secret" {
+		t.Errorf("Got user %q, password %q from env file", cfg.User, cfg.Password)
+	}
+
+	SetEnvFile(filepath.Join(tempDir, "missing.env"))
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "reading env file") {
+		t.Errorf("Load() error = %v, want a missing env file error", err)
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeyringPassword is stored in the config file in place of the password when
+// the real password is kept in the OS keyring.
+const KeyringPassword = "keyring:"
+
+// keyringService is the service name under which passwords are stored
+const keyringService = "cospend-cli"
+
+// keyringAccount returns the keyring account name for a config (user@server)
+func keyringAccount(cfg *Config) string {
+	return cfg.User + "@" + NormalizeURL(cfg.Domain)
+}
+
+// StorePassword saves the password in the OS keyring for the config's user and domain
+func StorePassword(cfg *Config, password string) error {
+	if err := keyring.Set(keyringService, keyringAccount(cfg), password); err != nil {
+		return fmt.Errorf("storing password in keyring: %w", err)
+	}
+	return nil
+}
+
+// DeletePassword removes the config's password from the OS keyring, if present
+func DeletePassword(cfg *Config) error {
+	err := keyring.Delete(keyringService, keyringAccount(cfg))
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("removing password from keyring: %w", err)
+	}
+	return nil
+}
+
+// ResolvePassword replaces a KeyringPassword placeholder with the password
+// stored in the OS keyring. Other passwords are left unchanged.
+func ResolvePassword(cfg *Config) error {
+	if cfg.Password != KeyringPassword {
+		return nil
+	}
+	password, err := keyring.Get(keyringService, keyringAccount(cfg))
+	if err != nil {
+		return fmt.Errorf("reading password from keyring: %w", err)
+	}
+	cfg.Password = password
+	return nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func writeKeyringConfig(t *testing.T) {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("NEXTCLOUD_DOMAIN", "")
+	t.Setenv("NEXTCLOUD_USER", "")
+	t.Setenv("NEXTCLOUD_PASSWORD", "")
+
+	configDir := filepath.Join(tempDir, "cospend")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configContent := `{
+  "domain": "https://cloud.example.com",
+  "user": "alice",
+  "password": "keyring:"
+}`
+	if err := os.WriteFile(filepath.Join(configDir, "cospend.json"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+}
+
+func TestLoadResolvesKeyringPassword(t *testing.T) {
+	keyring.MockInit()
+	writeKeyringConfig(t)
+
+	cfg := &Config{Domain: "https://cloud.example.com", User: "alice"}
+	if err := StorePassword(cfg, "secret"); err != nil {
+		t.Fatalf("StorePassword() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Password != "secret" {
+		t.Errorf("Password = %q, want %q", loaded.Password, "secret")
+	}
+}
+
+func TestLoadKeyringMissingEntry(t *testing.T) {
+	keyring.MockInit()
+	writeKeyringConfig(t)
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error when keyring entry is missing")
+	}
+}
+
+func TestLoadEnvPasswordOverridesKeyring(t *testing.T) {
+	keyring.MockInit()
+	writeKeyringConfig(t)
+	t.Setenv("NEXTCLOUD_PASSWORD", "envpass")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Password != "envpass" {
+		t.Errorf("Password = %q, want %q", cfg.Password, "envpass")
+	}
+}
+
+func TestDeletePassword(t *testing.T) {
+	keyring.MockInit()
+	cfg := &Config{Domain: "cloud.example.com", User: "alice", Password: KeyringPassword}
+
+	if err := StorePassword(cfg, "secret"); err != nil {
+		t.Fatalf("StorePassword() error = %v", err)
+	}
+	if err := DeletePassword(cfg); err != nil {
+		t.Fatalf("DeletePassword() error = %v", err)
+	}
+	if err := ResolvePassword(cfg); err == nil {
+		t.Error("Expected error after password was deleted")
+	}
+	// Deleting a missing entry is not an error
+	if err := DeletePassword(cfg); err != nil {
+		t.Errorf("DeletePassword() on missing entry error = %v", err)
+	}
+}
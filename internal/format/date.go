@@ -0,0 +1,97 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// ISODate is the layout bill dates are stored and parsed in.
+const ISODate = "2006-01-02"
+
+// datePresets are the named date formats accepted by DateLayout.
+var datePresets = map[string]string{
+	"iso": ISODate,
+	"us":  "01/02/2006",
+	"eu":  "02/01/2006",
+}
+
+// DateLayout returns the Go time layout for a date format: one of the presets
+// "iso" (2026-01-31), "us" (01/31/2026) or "eu" (31/01/2026), or a Go
+// reference layout such as "Jan 2, 2006". An empty format is "iso".
+func DateLayout(name string) (string, error) {
+	if name == "" {
+		return ISODate, nil
+	}
+	if layout, ok := datePresets[strings.ToLower(name)]; ok {
+		return layout, nil
+	}
+
+	// A layout without any reference components would print the same text for every date
+	if time.Date(2001, 3, 4, 0, 0, 0, 0, time.UTC).Format(name) == name {
+		return "", fmt.Errorf("invalid date format: %s (use iso, us, eu, or a Go layout like 02.01.2006)", name)
+	}
+	return name, nil
+}
+
+// FormatDate reformats a YYYY-MM-DD date with layout. Dates that can't be
+// parsed are returned unchanged.
+func FormatDate(date, layout string) string {
+	if layout == ISODate {
+		return date
+	}
+	t, err := time.Parse(ISODate, date)
+	if err != nil {
+		return date
+	}
+	return t.Format(layout)
+}
+
+// monthNames are the standalone month names, January first, of the languages
+// MonthName knows. golang.org/x/text has no calendar data, so they're listed
+// here.
+var monthNames = map[string][12]string{
+	"cs": {"leden", "únor", "březen", "duben", "květen", "červen", "červenec", "srpen", "září", "říjen", "listopad", "prosinec"},
+	"da": {"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september", "oktober", "november", "december"},
+	"de": {"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	"en": {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	"es": {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	"fi": {"tammikuu", "helmikuu", "maaliskuu", "huhtikuu", "toukokuu", "kesäkuu", "heinäkuu", "elokuu", "syyskuu", "lokakuu", "marraskuu", "joulukuu"},
+	"fr": {"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	"he": {"ינואר", "פברואר", "מרץ", "אפריל", "מאי", "יוני", "יולי", "אוגוסט", "ספטמבר", "אוקטובר", "נובמבר", "דצמבר"},
+	"it": {"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+	"nb": {"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "desember"},
+	"nl": {"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+	"pl": {"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+	"pt": {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+	"ru": {"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+	"sv": {"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+	"tr": {"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+	"uk": {"січень", "лютий", "березень", "квітень", "травень", "червень", "липень", "серпень", "вересень", "жовтень", "листопад", "грудень"},
+}
+
+// MonthName returns a YYYY-MM month as its name and year in the language of
+// locale (e.g. "en_US" gives "March 2026" and "fr_FR" gives "mars 2026").
+// Months in other languages, or that can't be parsed, are returned unchanged.
+func MonthName(month, locale string) string {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return month
+	}
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return month
+	}
+	base, _ := tag.Base()
+	lang := base.String()
+	if lang == "no" {
+		lang = "nb"
+	}
+	names, ok := monthNames[lang]
+	if !ok {
+		return month
+	}
+	return fmt.Sprintf("%s %d", names[t.Month()-1], t.Year())
+}
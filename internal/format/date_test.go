@@ -0,0 +1,79 @@
+package format
+
+import (
+	"testing"
+)
+
+func TestDateLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"", "2006-01-02", false},
+		{"iso", "2006-01-02", false},
+		{"US", "01/02/2006", false},
+		{"eu", "02/01/2006", false},
+		{"02.01.2006", "02.01.2006", false},
+		{"Jan 2, 2006", "Jan 2, 2006", false},
+		{"dd/mm/yyyy", "", true},
+		{"european", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DateLayout(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DateLayout(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DateLayout(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		date   string
+		layout string
+		want   string
+	}{
+		{"2026-01-31", ISODate, "2026-01-31"},
+		{"2026-01-31", "01/02/2006", "01/31/2026"},
+		{"2026-01-31", "02/01/2006", "31/01/2026"},
+		{"2026-01-31", "Jan 2, 2006", "Jan 31, 2026"},
+		{"not-a-date", "02/01/2006", "not-a-date"},
+		{"", "02/01/2006", ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDate(tt.date, tt.layout); got != tt.want {
+			t.Errorf("FormatDate(%q, %q) = %q, want %q", tt.date, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestMonthName(t *testing.T) {
+	tests := []struct {
+		month  string
+		locale string
+		want   string
+	}{
+		{"2026-03", "en_US", "March 2026"},
+		{"2026-03", "fr_FR", "mars 2026"},
+		{"2026-03", "de", "März 2026"},
+		{"2026-12", "he_IL", "דצמבר 2026"},
+		{"2026-08", "no_NO", "august 2026"},
+		{"2026-03", "ja_JP", "2026-03"},
+		{"2026-03", "", "2026-03"},
+		{"2026-03", "not a locale", "2026-03"},
+		{"2026-13", "en_US", "2026-13"},
+	}
+
+	for _, tt := range tests {
+		if got := MonthName(tt.month, tt.locale); got != tt.want {
+			t.Errorf("MonthName(%q, %q) = %q, want %q", tt.month, tt.locale, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package format
 
 import (
+	"math"
 	"strings"
 
 	"github.com/chenasraf/cospend-cli/internal/cache"
@@ -9,16 +10,30 @@ import (
 	"golang.org/x/text/message"
 )
 
+// NegativeStyle controls how negative amounts are rendered.
+type NegativeStyle int
+
+const (
+	// NegativeMinus renders negative amounts with a leading minus, e.g. "-$ 12.50".
+	NegativeMinus NegativeStyle = iota
+	// NegativeParens renders negative amounts in accounting style, e.g. "($ 12.50)".
+	NegativeParens
+)
+
 // AmountFormatter formats monetary amounts with locale-aware formatting.
 type AmountFormatter struct {
-	printer *message.Printer
-	unit    currency.Unit
-	hasUnit bool
+	printer      *message.Printer
+	unit         currency.Unit
+	hasUnit      bool
+	currencyName string // original name, used as a prefix when it can't be resolved
+	negative     NegativeStyle
 }
 
 // NewAmountFormatter creates a formatter for the given locale and currency name.
 // locale is a string like "en_US" or "he_IL". currencyName is the project's
 // currency name, which may be an ISO code (e.g. "EUR") or a symbol (e.g. "₪").
+// Symbols shared by several currencies (e.g. "$" or "kr") are resolved using
+// the locale's region when it uses one of them, so "$" is CAD for en_CA.
 func NewAmountFormatter(locale, currencyName string) *AmountFormatter {
 	// Parse locale to language tag
 	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
@@ -27,7 +42,8 @@ func NewAmountFormatter(locale, currencyName string) *AmountFormatter {
 	}
 
 	f := &AmountFormatter{
-		printer: message.NewPrinter(tag),
+		printer:      message.NewPrinter(tag),
+		currencyName: strings.TrimSpace(currencyName),
 	}
 
 	// Try to resolve currency
@@ -40,8 +56,8 @@ func NewAmountFormatter(locale, currencyName string) *AmountFormatter {
 			return f
 		}
 
-		// Try reverse-mapping from symbol
-		if iso := cache.SymbolToISO(currencyName); iso != "" {
+		// Try reverse-mapping from symbol, preferring the locale's currency
+		if iso := cache.SymbolToISOWithHint(currencyName, regionCurrency(tag)); iso != "" {
 			unit, err = currency.ParseISO(iso)
 			if err == nil {
 				f.unit = unit
@@ -54,10 +70,48 @@ func NewAmountFormatter(locale, currencyName string) *AmountFormatter {
 	return f
 }
 
+// regionCurrency returns the ISO code of the currency used in the locale's
+// region, or "" if the locale doesn't name a region
+func regionCurrency(tag language.Tag) string {
+	region, confidence := tag.Region()
+	if confidence != language.Exact {
+		return ""
+	}
+	unit, ok := currency.FromRegion(region)
+	if !ok {
+		return ""
+	}
+	return unit.String()
+}
+
+// SetNegativeStyle sets how negative amounts are rendered. The default is NegativeMinus.
+func (f *AmountFormatter) SetNegativeStyle(style NegativeStyle) {
+	f.negative = style
+}
+
 // Format formats a monetary amount using locale-aware formatting.
+// Negative amounts are rendered according to the formatter's NegativeStyle,
+// with the sign placed outside the currency symbol.
 func (f *AmountFormatter) Format(amount float64) string {
+	s := f.formatAbs(math.Abs(amount))
+	// Amounts that round to zero are never shown as negative
+	if amount >= 0 || s == f.formatAbs(0) {
+		return s
+	}
+	if f.negative == NegativeParens {
+		return "(" + s + ")"
+	}
+	return "-" + s
+}
+
+// formatAbs formats a non-negative amount
+func (f *AmountFormatter) formatAbs(amount float64) string {
 	if f.hasUnit {
 		return f.printer.Sprintf("%v", currency.Symbol(f.unit.Amount(amount)))
 	}
+	// Unknown currency: keep the raw name as a prefix so the unit isn't lost
+	if f.currencyName != "" {
+		return f.currencyName + " " + f.printer.Sprintf("%.2f", amount)
+	}
 	return f.printer.Sprintf("%.2f", amount)
 }
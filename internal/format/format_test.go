@@ -68,6 +68,33 @@ func TestFormatWithoutCurrency(t *testing.T) {
 	}
 }
 
+func TestFormatUnknownCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		currency string
+		amount   float64
+		want     string
+	}{
+		{"unknown code", "en_US", "XYZ123", 1234.50, "XYZ123 1,234.50"},
+		{"unknown code de_DE grouping", "de_DE", "XYZ123", 1234.50, "XYZ123 1.234,50"},
+		{"custom symbol", "en_US", "pts", 10, "pts 10.00"},
+		{"negative", "en_US", "XYZ123", -5, "-XYZ123 5.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewAmountFormatter(tt.locale, tt.currency)
+			if f.currencyName != tt.currency {
+				t.Errorf("currencyName = %q, want %q", f.currencyName, tt.currency)
+			}
+			if got := f.Format(tt.amount); got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatFallbackLocale(t *testing.T) {
 	// Invalid locale should fall back to en-US
 	f := NewAmountFormatter("invalid!!!", "")
@@ -89,3 +116,62 @@ func containsSubstr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFormatNegative(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		currency string
+		style    NegativeStyle
+		amount   float64
+		want     string
+	}{
+		{"minus USD", "en_US", "USD", NegativeMinus, -12.50, "-$ 12.50"},
+		{"parens USD", "en_US", "USD", NegativeParens, -12.50, "($ 12.50)"},
+		{"minus EUR de_DE", "de_DE", "EUR", NegativeMinus, -1234.50, "-€ 1.234,50"},
+		{"parens EUR de_DE", "de_DE", "EUR", NegativeParens, -1234.50, "(€ 1.234,50)"},
+		{"minus no currency", "en_US", "", NegativeMinus, -1234.50, "-1,234.50"},
+		{"parens no currency", "en_US", "", NegativeParens, -1234.50, "(1,234.50)"},
+		{"positive unaffected by parens", "en_US", "USD", NegativeParens, 12.50, "$ 12.50"},
+		{"rounds to zero", "en_US", "USD", NegativeParens, -0.001, "$ 0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewAmountFormatter(tt.locale, tt.currency)
+			f.SetNegativeStyle(tt.style)
+			if got := f.Format(tt.amount); got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAmountFormatterLocaleHint(t *testing.T) {
+	tests := []struct {
+		locale   string
+		currency string
+		want     string
+	}{
+		{"en_US", "$", "USD"},
+		{"en_CA", "$", "CAD"},
+		{"en_AU", "$", "AUD"},
+		{"en", "$", "USD"},    // no region, use the preferred currency
+		{"de_DE", "$", "USD"}, // region currency doesn't use the symbol
+		{"sv_SE", "kr", "SEK"},
+		{"nb_NO", "kr", "NOK"},
+		{"da_DK", "kr", "DKK"},
+		{"is_IS", "kr", "ISK"},
+	}
+
+	for _, tt := range tests {
+		f := NewAmountFormatter(tt.locale, tt.currency)
+		if !f.hasUnit {
+			t.Errorf("NewAmountFormatter(%q, %q) didn't resolve a currency", tt.locale, tt.currency)
+			continue
+		}
+		if got := f.unit.String(); got != tt.want {
+			t.Errorf("NewAmountFormatter(%q, %q) currency = %s, want %s", tt.locale, tt.currency, got, tt.want)
+		}
+	}
+}
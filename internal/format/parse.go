@@ -0,0 +1,212 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// errInvalidAmount is wrapped by the errors ParseAmount returns for input that
+// isn't a number, which say what's wrong with it.
+var errInvalidAmount = errors.New("invalid amount")
+
+// groupSeparators are the thousands separators accepted in amounts: period,
+// comma, apostrophes (Swiss), and regular, non-breaking and narrow
+// non-breaking spaces.
+var groupSeparators = []string{".", ",", "'", "\u2019", " ", "\u00a0", "\u202f"}
+
+// ParseAmount parses an amount the way users type or paste it. Currency
+// symbols and ISO codes around the number are ignored ("$25", "25.50 €",
+// "EUR 10"), as are thousands separators ("1,234.50", "1 234.50"). A sign
+// may come before or after a leading symbol ("-$5", "$-5").
+//
+// Either "." or "," may be the decimal separator. When both appear, the last
+// one is ("1.234,50" and "1,234.50" are both 1234.5), and a separator that
+// appears more than once groups thousands ("1.234.567"). A lone separator
+// followed by exactly three digits ("1,234") is ambiguous and is read using
+// the decimal separator of locale (e.g. "en_US" or "de_DE"); any other lone
+// separator is the decimal one ("45,50").
+func ParseAmount(s, locale string) (float64, error) {
+	input := s
+	s = trimCurrency(s)
+
+	negative := false
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		negative = true
+		s = trimCurrency(rest)
+	} else if rest, ok := strings.CutPrefix(s, "+"); ok {
+		s = trimCurrency(rest)
+	}
+
+	number, ok := normalizeAmount(s, decimalSeparator(locale))
+	if !ok {
+		return 0, amountError(input, s)
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, amountError(input, s)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// amountError explains why input isn't an amount, given what's left of it (s)
+// once the currency and sign are removed
+func amountError(input, s string) error {
+	if s == "" {
+		return fmt.Errorf("%w %q: no number found", errInvalidAmount, input)
+	}
+
+	// Letters are most likely an unknown currency code or a typo
+	if i := strings.IndexFunc(s, unicode.IsLetter); i >= 0 {
+		word := strings.FieldsFunc(s[i:], func(r rune) bool { return !unicode.IsLetter(r) })[0]
+		return fmt.Errorf("%w %q: unrecognized currency or text %q", errInvalidAmount, input, word)
+	}
+
+	// Otherwise, digits with separators that don't fit together
+	if strings.ContainsAny(s, "0123456789") && strings.ContainsAny(s, ".,' \u2019\u00a0\u202f") {
+		return fmt.Errorf("%w %q: misplaced decimal or thousands separator", errInvalidAmount, input)
+	}
+	return fmt.Errorf("%w %q: not a number", errInvalidAmount, input)
+}
+
+// normalizeAmount rewrites an unsigned amount without grouping and with "."
+// as the decimal separator, reporting false if s isn't a number.
+func normalizeAmount(s string, decimal byte) (string, bool) {
+	lastDot := strings.LastIndexByte(s, '.')
+	lastComma := strings.LastIndexByte(s, ',')
+
+	// Find the decimal separator, if any
+	point := -1
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		point = max(lastDot, lastComma)
+	case lastDot >= 0 || lastComma >= 0:
+		idx := max(lastDot, lastComma)
+		if strings.Count(s, s[idx:idx+1]) == 1 && (s[idx] == decimal || !maybeGrouped(s, idx)) {
+			point = idx
+		}
+	}
+
+	integer, fraction := s, ""
+	if point >= 0 {
+		integer, fraction = s[:point], s[point+1:]
+		if !isDigits(fraction) {
+			return "", false
+		}
+		if integer == "" {
+			integer = "0"
+		}
+	}
+
+	if !isDigits(integer) {
+		if !isGroupedInteger(integer) {
+			return "", false
+		}
+		integer = strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, integer)
+	}
+
+	if fraction == "" {
+		return integer, true
+	}
+	return integer + "." + fraction, true
+}
+
+// maybeGrouped reports whether the separator at idx could be a thousands
+// separator: it follows one to three digits other than a lone zero and is
+// followed by exactly three digits.
+func maybeGrouped(s string, idx int) bool {
+	integer, rest := s[:idx], s[idx+1:]
+	return len(rest) == 3 && len(integer) <= 3 && isDigits(integer) && integer != "0"
+}
+
+// isGroupedInteger reports whether s is an integer split into groups of three
+// digits by a single kind of separator, e.g. "1,234,567".
+func isGroupedInteger(s string) bool {
+	for _, sep := range groupSeparators {
+		groups := strings.Split(s, sep)
+		if len(groups) < 2 || len(groups[0]) > 3 {
+			continue
+		}
+		valid := true
+		for i, g := range groups {
+			if !isDigits(g) || (i > 0 && len(g) != 3) {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return true
+		}
+	}
+	return false
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// decimalSeparator returns the decimal separator used by locale, "." or ",".
+// Unknown locales use ".".
+func decimalSeparator(locale string) byte {
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return '.'
+	}
+	if strings.Contains(message.NewPrinter(tag).Sprintf("%.1f", 0.5), ",") {
+		return ','
+	}
+	return '.'
+}
+
+// trimCurrency removes whitespace, currency symbols and a leading or
+// trailing ISO currency code from s.
+func trimCurrency(s string) string {
+	trim := func(s string) string {
+		return strings.TrimFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.Is(unicode.Sc, r)
+		})
+	}
+
+	s = trim(s)
+	if len(s) > 3 && isISOCode(s[:3]) {
+		s = trim(s[3:])
+	}
+	if len(s) > 3 && isISOCode(s[len(s)-3:]) {
+		s = trim(s[:len(s)-3])
+	}
+	return s
+}
+
+// isISOCode reports whether code is a known ISO 4217 currency code.
+func isISOCode(code string) bool {
+	for _, r := range code {
+		if r < 'A' || r > 'z' || (r > 'Z' && r < 'a') {
+			return false
+		}
+	}
+	_, err := currency.ParseISO(strings.ToUpper(code))
+	return err == nil
+}
@@ -0,0 +1,185 @@
+package format
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"25", 25},
+		{"25.50", 25.5},
+		{".5", 0.5},
+		{"1,234.50", 1234.5},
+		{"1,234,567", 1234567},
+		{"1 234.50", 1234.5},
+		{"1 234.50", 1234.5},
+		{"1'234.50", 1234.5},
+		{"$25", 25},
+		{"25€", 25},
+		{"25.50 €", 25.5},
+		{"₪ 1,000", 1000},
+		{"  12.30  ", 12.3},
+		{"25 USD", 25},
+		{"eur 10.5", 10.5},
+		{"-$5", -5},
+		{"$-5", -5},
+		{"+7", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAmount(tt.input, "en_US")
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmountDecimalComma(t *testing.T) {
+	tests := []struct {
+		input  string
+		locale string
+		want   float64
+	}{
+		// A lone comma not followed by three digits is always a decimal
+		{"45,50", "en_US", 45.5},
+		{"45,5", "en_US", 45.5},
+		{"45,50", "de_DE", 45.5},
+		{"0,99", "en_US", 0.99},
+		{",5", "de_DE", 0.5},
+		{"45,50 €", "fr_FR", 45.5},
+		{"-45,50", "de_DE", -45.5},
+
+		// With both separators, the last one is the decimal
+		{"1.234,50", "en_US", 1234.5},
+		{"1.234,50", "de_DE", 1234.5},
+		{"1,234.50", "de_DE", 1234.5},
+		{"1.234.567,89", "de_DE", 1234567.89},
+		{"1,234,567.89", "de_DE", 1234567.89},
+		{"1 234,50", "fr_FR", 1234.5},
+		{"1 234,50", "fr_FR", 1234.5},
+		{"1’234.50", "de_CH", 1234.5},
+
+		// A separator that appears more than once groups thousands
+		{"1.234.567", "en_US", 1234567},
+		{"1,234,567", "de_DE", 1234567},
+
+		// A lone separator followed by three digits follows the locale
+		{"1,234", "en_US", 1234},
+		{"1,234", "de_DE", 1.234},
+		{"1.234", "en_US", 1.234},
+		{"1.234", "de_DE", 1234},
+		{"123,456", "pt_BR", 123.456},
+		{"123.456", "pt_BR", 123456},
+
+		// ...unless it can't be grouping
+		{"0,500", "en_US", 0.5},
+		{"1234,567", "en_US", 1234.567},
+		{"1234.567", "de_DE", 1234.567},
+
+		// Unknown or empty locales use a period
+		{"1,234", "", 1234},
+		{"1.234", "not a locale", 1.234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input+" "+tt.locale, func(t *testing.T) {
+			got, err := ParseAmount(tt.input, tt.locale)
+			if err != nil {
+				t.Fatalf("ParseAmount(%q, %q) error: %v", tt.input, tt.locale, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q, %q) = %v, want %v", tt.input, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmountInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"abc",
+		"$",
+		"12abc",
+		"1.2.3",
+		"1,2,3",
+		"1,23.50",
+		"1.23,50",
+		"1,234 567",
+		"1.234,567.89",
+		"1,234.",
+		"25,",
+		"1,,234",
+		"--5",
+		"5-",
+		"1e5",
+		"NaN",
+		"Inf",
+		"0x10",
+	}
+
+	for _, input := range tests {
+		for _, locale := range []string{"en_US", "de_DE"} {
+			t.Run(input+" "+locale, func(t *testing.T) {
+				if got, err := ParseAmount(input, locale); err == nil {
+					t.Errorf("ParseAmount(%q, %q) = %v, want error", input, locale, got)
+				}
+			})
+		}
+	}
+}
+
+func TestParseAmountErrorReason(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", `invalid amount "": no number found`},
+		{"$", `invalid amount "$": no number found`},
+		{"not-a-number", `invalid amount "not-a-number": unrecognized currency or text "not"`},
+		{"25 bucks", `invalid amount "25 bucks": unrecognized currency or text "bucks"`},
+		{"1,2,3", `invalid amount "1,2,3": misplaced decimal or thousands separator`},
+		{"€1.234,567.89", `invalid amount "€1.234,567.89": misplaced decimal or thousands separator`},
+		{"--5", `invalid amount "--5": not a number`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseAmount(tt.input, "en_US")
+			if !errors.Is(err, errInvalidAmount) {
+				t.Fatalf("ParseAmount(%q) error = %v, want errInvalidAmount", tt.input, err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("ParseAmount(%q) error = %q, want %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalSeparator(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   byte
+	}{
+		{"en_US", '.'},
+		{"he_IL", '.'},
+		{"de_DE", ','},
+		{"fr_FR", ','},
+		{"pt-BR", ','},
+		{"", '.'},
+	}
+
+	for _, tt := range tests {
+		if got := decimalSeparator(tt.locale); got != tt.want {
+			t.Errorf("decimalSeparator(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrCancelled is returned when the user cancels a select prompt with Ctrl+C.
+var ErrCancelled = errors.New("cancelled")
+
+// Option is an entry in a select prompt.
+type Option struct {
+	Label       string
+	Description string
+}
+
+// String formats the option as "label - description", or just the label.
+func (o Option) String() string {
+	if o.Description == "" {
+		return o.Label
+	}
+	return o.Label + " - " + o.Description
+}
+
+// Prompter asks questions on an input and output pair. Line input is read
+// through one buffered reader, so consecutive prompts don't lose input.
+type Prompter struct {
+	Out io.Writer
+
+	in     io.Reader
+	reader *bufio.Reader
+}
+
+// New creates a Prompter reading from in and writing to out.
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{Out: out, in: in, reader: bufio.NewReader(in)}
+}
+
+// terminal returns the input as a file when it is a terminal.
+func (p *Prompter) terminal() (*os.File, bool) {
+	f, ok := p.in.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil, false
+	}
+	return f, true
+}
+
+// readLine reads one line of input without its surrounding whitespace.
+func (p *Prompter) readLine() (string, error) {
+	input, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(input), nil
+}
+
+// String prompts for a line of text.
+func (p *Prompter) String(prompt string) (string, error) {
+	_, _ = fmt.Fprintf(p.Out, "%s: ", prompt)
+	return p.readLine()
+}
+
+// Default prompts for a line of text, returning def when the input is empty.
+func (p *Prompter) Default(prompt, def string) (string, error) {
+	if def != "" {
+		prompt = fmt.Sprintf("%s [%s]", prompt, def)
+	}
+	input, err := p.String(prompt)
+	if err != nil {
+		return "", err
+	}
+	if input == "" {
+		return def, nil
+	}
+	return input, nil
+}
+
+// Password prompts for a secret, hiding the input on a terminal.
+func (p *Prompter) Password(prompt string) (string, error) {
+	_, _ = fmt.Fprintf(p.Out, "%s: ", prompt)
+
+	if f, ok := p.terminal(); ok {
+		password, err := term.ReadPassword(int(f.Fd()))
+		_, _ = fmt.Fprintln(p.Out) // Print newline after hidden input
+		if err != nil {
+			return "", err
+		}
+		return string(password), nil
+	}
+
+	// Fallback to regular input (for non-terminal/testing)
+	return p.readLine()
+}
+
+// YesNo asks a yes/no question that defaults to no.
+func (p *Prompter) YesNo(prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(p.Out, "%s [y/N]: ", prompt)
+	input, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	input = strings.ToLower(input)
+	return input == "y" || input == "yes", nil
+}
+
+// Confirm asks a yes/no question that defaults to yes. Unreadable input
+// counts as no.
+func (p *Prompter) Confirm(prompt string) bool {
+	_, _ = fmt.Fprintf(p.Out, "%s [Y/n] ", prompt)
+	input, err := p.reader.ReadString('\n')
+	if err != nil && input == "" {
+		return false
+	}
+	answer := strings.TrimSpace(strings.ToLower(input))
+	return answer == "" || answer == "y" || answer == "yes"
+}
+
+// Select shows a menu starting at the initial index and returns the chosen
+// index. On a terminal the menu is navigated with the arrow keys or j/k;
+// otherwise the options are numbered and a number is read.
+func (p *Prompter) Select(options []Option, initial int) (int, error) {
+	f, ok := p.terminal()
+	if !ok {
+		return p.selectFallback(options, initial)
+	}
+
+	// Save terminal state and set raw mode
+	oldState, err := term.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return p.selectFallback(options, initial)
+	}
+	defer func() { _ = term.Restore(int(f.Fd()), oldState) }()
+
+	// Hide cursor
+	_, _ = fmt.Fprint(p.Out, "\033[?25l")
+	defer func() { _, _ = fmt.Fprint(p.Out, "\033[?25h") }() // Show cursor on exit
+
+	s := &selectState{count: len(options), cursor: initial}
+	render := func() {
+		for i, opt := range options {
+			if i == s.cursor {
+				_, _ = fmt.Fprintf(p.Out, "\r\033[K  \033[36m>\033[0m \033[1m%s\033[0m\n", opt)
+			} else {
+				_, _ = fmt.Fprintf(p.Out, "\r\033[K    %s\n", opt)
+			}
+		}
+	}
+
+	render()
+	done, err := p.readKeys(f, s, render)
+	if err != nil {
+		return 0, err
+	}
+	if !done {
+		return 0, ErrCancelled
+	}
+	return s.cursor, nil
+}
+
+// selectFallback lists numbered options and reads a choice.
+func (p *Prompter) selectFallback(options []Option, initial int) (int, error) {
+	p.listOptions(options)
+
+	choice, err := p.String(fmt.Sprintf("Enter choice [%d]", initial+1))
+	if err != nil {
+		return 0, err
+	}
+	if choice == "" {
+		return initial, nil
+	}
+
+	idx, err := parseChoice(choice, len(options))
+	if err != nil {
+		return 0, err
+	}
+	return idx, nil
+}
+
+// MultiSelect shows a checklist and returns the indexes of the checked
+// options. Options set in checked start out checked. On a terminal, space
+// toggles an option; otherwise comma-separated numbers are read.
+func (p *Prompter) MultiSelect(options []Option, checked []bool) ([]int, error) {
+	f, ok := p.terminal()
+	if !ok {
+		return p.multiSelectFallback(options, checked)
+	}
+
+	oldState, err := term.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return p.multiSelectFallback(options, checked)
+	}
+	defer func() { _ = term.Restore(int(f.Fd()), oldState) }()
+
+	_, _ = fmt.Fprint(p.Out, "\033[?25l")
+	defer func() { _, _ = fmt.Fprint(p.Out, "\033[?25h") }()
+
+	s := &selectState{count: len(options), checked: make([]bool, len(options))}
+	copy(s.checked, checked)
+	render := func() {
+		for i, opt := range options {
+			box := "[ ]"
+			if s.checked[i] {
+				box = "[x]"
+			}
+			if i == s.cursor {
+				_, _ = fmt.Fprintf(p.Out, "\r\033[K  \033[36m>\033[0m %s \033[1m%s\033[0m\n", box, opt)
+			} else {
+				_, _ = fmt.Fprintf(p.Out, "\r\033[K    %s %s\n", box, opt)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprint(p.Out, "\r\033[K  (space to toggle, enter to confirm)\n")
+	render()
+	done, err := p.readKeys(f, s, render)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		return nil, ErrCancelled
+	}
+	return checkedIndexes(s.checked), nil
+}
+
+// multiSelectFallback lists numbered options and reads comma-separated
+// choices, defaulting to the checked ones.
+func (p *Prompter) multiSelectFallback(options []Option, checked []bool) ([]int, error) {
+	p.listOptions(options)
+
+	var defaults []string
+	for _, i := range checkedIndexes(checked) {
+		defaults = append(defaults, strconv.Itoa(i+1))
+	}
+
+	choice, err := p.String(fmt.Sprintf("Enter choices, comma-separated [%s]", strings.Join(defaults, ",")))
+	if err != nil {
+		return nil, err
+	}
+	if choice == "" {
+		return checkedIndexes(checked), nil
+	}
+
+	state := make([]bool, len(options))
+	for _, part := range strings.Split(choice, ",") {
+		idx, err := parseChoice(strings.TrimSpace(part), len(options))
+		if err != nil {
+			return nil, err
+		}
+		state[idx] = true
+	}
+	return checkedIndexes(state), nil
+}
+
+func (p *Prompter) listOptions(options []Option) {
+	for i, opt := range options {
+		_, _ = fmt.Fprintf(p.Out, "  %d. %s\n", i+1, opt)
+	}
+	_, _ = fmt.Fprintln(p.Out)
+}
+
+// readKeys feeds raw keypresses to s, re-rendering the menu after each one,
+// until the user confirms (true) or cancels (false).
+func (p *Prompter) readKeys(f *os.File, s *selectState, render func()) (bool, error) {
+	buf := make([]byte, 3)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			return false, err
+		}
+
+		switch k := decodeKey(buf[:n]); k {
+		case keyEnter:
+			_, _ = fmt.Fprintln(p.Out)
+			return true, nil
+		case keyCancel:
+			_, _ = fmt.Fprintln(p.Out)
+			return false, nil
+		default:
+			s.handle(k)
+		}
+
+		_, _ = fmt.Fprintf(p.Out, "\033[%dA", s.count)
+		render()
+	}
+}
+
+// key is a decoded keypress in a select menu.
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyToggle
+	keyEnter
+	keyCancel
+)
+
+// decodeKey maps the bytes of one raw-mode read to a key.
+func decodeKey(b []byte) key {
+	if len(b) == 1 {
+		switch b[0] {
+		case 13, 10: // Enter
+			return keyEnter
+		case 3: // Ctrl+C
+			return keyCancel
+		case ' ':
+			return keyToggle
+		case 'j', 'J': // vim down
+			return keyDown
+		case 'k', 'K': // vim up
+			return keyUp
+		}
+	} else if len(b) == 3 && b[0] == 27 && b[1] == 91 {
+		// Arrow keys: ESC [ A/B
+		switch b[2] {
+		case 65:
+			return keyUp
+		case 66:
+			return keyDown
+		}
+	}
+	return keyNone
+}
+
+// selectState is the cursor position, and for multi-select the checked
+// options, of a select menu.
+type selectState struct {
+	count   int
+	cursor  int
+	checked []bool
+}
+
+// handle applies a navigation key. The cursor wraps around at either end;
+// toggling only applies to multi-select menus.
+func (s *selectState) handle(k key) {
+	switch k {
+	case keyUp:
+		s.cursor = (s.cursor - 1 + s.count) % s.count
+	case keyDown:
+		s.cursor = (s.cursor + 1) % s.count
+	case keyToggle:
+		if s.checked != nil {
+			s.checked[s.cursor] = !s.checked[s.cursor]
+		}
+	}
+}
+
+// parseChoice parses a 1-based choice into an index.
+func parseChoice(choice string, count int) (int, error) {
+	idx, err := strconv.Atoi(choice)
+	if err != nil || idx < 1 || idx > count {
+		return 0, fmt.Errorf("invalid choice: %s", choice)
+	}
+	return idx - 1, nil
+}
+
+// checkedIndexes returns the indexes of the true values in state.
+func checkedIndexes(state []bool) []int {
+	var indexes []int
+	for i, ok := range state {
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
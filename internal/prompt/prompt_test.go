@@ -0,0 +1,258 @@
+package prompt
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	var out bytes.Buffer
+	p := New(strings.NewReader("test input\n"), &out)
+
+	result, err := p.String("Enter value")
+	if err != nil {
+		t.Fatalf("String error: %v", err)
+	}
+	if result != "test input" {
+		t.Errorf("Result = %s, want 'test input'", result)
+	}
+	if !strings.Contains(out.String(), "Enter value:") {
+		t.Errorf("Prompt not shown: %s", out.String())
+	}
+}
+
+func TestSharedInput(t *testing.T) {
+	// Consecutive prompts must not lose input buffered by an earlier one
+	p := New(strings.NewReader("first\nsecond\n\n"), new(bytes.Buffer))
+
+	for _, want := range []string{"first", "second"} {
+		got, err := p.String("Value")
+		if err != nil {
+			t.Fatalf("String error: %v", err)
+		}
+		if got != want {
+			t.Errorf("String = %q, want %q", got, want)
+		}
+	}
+	if got, _ := p.Default("Value", "fallback"); got != "fallback" {
+		t.Errorf("Default = %q, want fallback", got)
+	}
+}
+
+func TestPassword(t *testing.T) {
+	// Non-terminal input falls back to a regular line read
+	p := New(strings.NewReader("secretpass\n"), new(bytes.Buffer))
+
+	result, err := p.Password("Enter password")
+	if err != nil {
+		t.Fatalf("Password error: %v", err)
+	}
+	if result != "secretpass" {
+		t.Errorf("Result = %s, want secretpass", result)
+	}
+}
+
+func TestYesNo(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"y\n", true},
+		{"Y\n", true},
+		{"yes\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"N\n", false},
+		{"no\n", false},
+		{"\n", false},
+		{"anything\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := New(strings.NewReader(tt.input), new(bytes.Buffer))
+			result, err := p.YesNo("Confirm?")
+			if err != nil {
+				t.Fatalf("YesNo error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Result = %v, want %v for input %q", result, tt.expected, tt.input)
+			}
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"\n", true},
+		{"y\n", true},
+		{"yes", true},
+		{"n\n", false},
+		{"nope\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := New(strings.NewReader(tt.input), new(bytes.Buffer))
+			if got := p.Confirm("Continue?"); got != tt.expected {
+				t.Errorf("Confirm(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectFallback(t *testing.T) {
+	options := []Option{
+		{Label: "Option A", Description: "First option"},
+		{Label: "Option B", Description: "Second option"},
+		{Label: "Option C"},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		initial  int
+		expected int
+		wantErr  bool
+	}{
+		{"default selection", "\n", 0, 0, false},
+		{"initial selection", "\n", 2, 2, false},
+		{"select first", "1\n", 2, 0, false},
+		{"select second", "2\n", 0, 1, false},
+		{"invalid choice", "5\n", 0, 0, true},
+		{"invalid input", "abc\n", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := New(strings.NewReader(tt.input), &out)
+
+			selected, err := p.Select(options, tt.initial)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if selected != tt.expected {
+				t.Errorf("Selected = %d, want %d", selected, tt.expected)
+			}
+			if !strings.Contains(out.String(), "1. Option A - First option") {
+				t.Errorf("Options not listed: %s", out.String())
+			}
+			if !strings.Contains(out.String(), "3. Option C\n") {
+				t.Errorf("Option without description should have no separator: %s", out.String())
+			}
+		})
+	}
+}
+
+func TestMultiSelectFallback(t *testing.T) {
+	options := []Option{{Label: "Alice"}, {Label: "Bob"}, {Label: "Charlie"}}
+
+	tests := []struct {
+		name     string
+		input    string
+		checked  []bool
+		expected []int
+		wantErr  bool
+	}{
+		{"defaults", "\n", []bool{false, true, false}, []int{1}, false},
+		{"no defaults", "\n", nil, nil, false},
+		{"choices", "1, 3\n", []bool{false, true, false}, []int{0, 2}, false},
+		{"duplicates", "2,2\n", nil, []int{1}, false},
+		{"out of range", "4\n", nil, nil, true},
+		{"not a number", "1,x\n", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(strings.NewReader(tt.input), new(bytes.Buffer))
+
+			got, err := p.MultiSelect(options, tt.checked)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MultiSelect = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  key
+	}{
+		{"enter", []byte{13}, keyEnter},
+		{"newline", []byte{10}, keyEnter},
+		{"ctrl+c", []byte{3}, keyCancel},
+		{"space", []byte{' '}, keyToggle},
+		{"j", []byte{'j'}, keyDown},
+		{"K", []byte{'K'}, keyUp},
+		{"arrow up", []byte{27, 91, 65}, keyUp},
+		{"arrow down", []byte{27, 91, 66}, keyDown},
+		{"arrow right", []byte{27, 91, 67}, keyNone},
+		{"escape", []byte{27}, keyNone},
+		{"other", []byte{'x'}, keyNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeKey(tt.input); got != tt.want {
+				t.Errorf("decodeKey(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectStateHandle(t *testing.T) {
+	s := &selectState{count: 3}
+
+	// Moving up from the top wraps to the bottom, and down from there wraps back
+	steps := []struct {
+		key    key
+		cursor int
+	}{
+		{keyUp, 2},
+		{keyDown, 0},
+		{keyDown, 1},
+		{keyNone, 1},
+		{keyToggle, 1}, // no effect on a single select
+	}
+	for i, step := range steps {
+		s.handle(step.key)
+		if s.cursor != step.cursor {
+			t.Errorf("step %d: cursor = %d, want %d", i, s.cursor, step.cursor)
+		}
+	}
+
+	m := &selectState{count: 3, checked: []bool{true, false, false}}
+	m.handle(keyToggle)
+	m.handle(keyDown)
+	m.handle(keyToggle)
+	if !reflect.DeepEqual(m.checked, []bool{false, true, false}) {
+		t.Errorf("checked = %v, want [false true false]", m.checked)
+	}
+	if got := checkedIndexes(m.checked); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("checkedIndexes = %v, want [1]", got)
+	}
+}
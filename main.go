@@ -13,6 +13,12 @@ import (
 //go:embed version.txt
 var version string
 
+// Set at build time via -ldflags "-X main.commit=... -X main.buildDate=..."
+var (
+	commit    string
+	buildDate string
+)
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:              "cospend",
@@ -21,6 +27,9 @@ func main() {
 		Version:          strings.TrimSpace(version),
 		TraverseChildren: true,
 		PersistentPreRun: func(c *cobra.Command, args []string) {
+			config.SetConfigPath(cmd.ConfigFile)
+			config.SetEnvFile(cmd.EnvFile)
+
 			// Apply default project from config if -p not explicitly set
 			if cmd.ProjectID == "" {
 				if raw := config.LoadRaw(); raw.DefaultProject != "" {
@@ -33,20 +42,51 @@ func main() {
 	rootCmd.AddCommand(cmd.NewAddCommand())
 	rootCmd.AddCommand(cmd.NewInitCommand())
 	rootCmd.AddCommand(cmd.NewListCommand())
+	rootCmd.AddCommand(cmd.NewLastCommand())
 	rootCmd.AddCommand(cmd.NewDeleteCommand())
+	rootCmd.AddCommand(cmd.NewUndoCommand())
 	rootCmd.AddCommand(cmd.NewEditCommand())
 	rootCmd.AddCommand(cmd.NewProjectsCommand())
 	rootCmd.AddCommand(cmd.NewInfoCommand())
+	rootCmd.AddCommand(cmd.NewOpenCommand())
 	rootCmd.AddCommand(cmd.NewConfigCommand())
 	rootCmd.AddCommand(cmd.NewLogoutCommand())
 	rootCmd.AddCommand(cmd.NewDoctorCommand())
+	rootCmd.AddCommand(cmd.NewStatsCommand())
+	rootCmd.AddCommand(cmd.NewExportCommand())
+	rootCmd.AddCommand(cmd.NewImportCommand())
+	rootCmd.AddCommand(cmd.NewCopyCommand())
+	rootCmd.AddCommand(cmd.NewCategoriesCommand())
+	rootCmd.AddCommand(cmd.NewMethodsCommand())
+	rootCmd.AddCommand(cmd.NewMembersCommand())
+	rootCmd.AddCommand(cmd.NewVersionCommand(strings.TrimSpace(version), commit, buildDate))
 
-	rootCmd.PersistentFlags().BoolVarP(&cmd.Debug, "debug", "D", false, "Enable debug output")
+	rootCmd.PersistentFlags().BoolVarP(&cmd.Debug, "debug", "D", false, "Enable full debug output (same as -vv)")
+	rootCmd.PersistentFlags().CountVarP(&cmd.Verbosity, "verbose", "v", "Increase debug output (-v for requests, -vv to include bodies)")
 	rootCmd.PersistentFlags().StringVarP(&cmd.ProjectID, "project", "p", "", "Project ID")
-	rootCmd.Flags().BoolP("version", "v", false, "Print version information")
+	_ = rootCmd.RegisterFlagCompletionFunc("project", cmd.CompleteProjectIDs)
+	rootCmd.PersistentFlags().StringVar(&cmd.Locale, "locale", "", "Locale for amount formatting (e.g., de_DE; defaults to your Nextcloud locale)")
+	rootCmd.PersistentFlags().StringVar(&cmd.DateFormat, "date-format", "", "Format for displayed dates: iso, us, eu, or a Go layout like 02.01.2006 (default iso)")
+	rootCmd.PersistentFlags().StringVar(&cmd.Timezone, "timezone", "", "Time zone for \"today\" and relative dates, like Europe/Berlin or UTC (defaults to the local zone)")
+	rootCmd.PersistentFlags().StringVar(&cmd.Currency, "currency", "", "Currency for amount formatting, as an ISO code or symbol (overrides the project currency)")
+	rootCmd.PersistentFlags().StringVar(&cmd.ConfigFile, "config", "", "Path to config file (overrides the default location)")
+	rootCmd.PersistentFlags().StringVar(&cmd.EnvFile, "env-file", "", "Path to an env file with NEXTCLOUD_* and COSPEND_* variables (not read unless given, or set in COSPEND_ENV_FILE)")
+	rootCmd.PersistentFlags().BoolVar(&cmd.NoInteractive, "no-interactive", false, "Never prompt; fail instead (e.g., on ambiguous category or payment method names)")
+	rootCmd.PersistentFlags().BoolVar(&cmd.NoCache, "no-cache", false, "Always fetch from the API, without reading or writing the cache")
+	rootCmd.PersistentFlags().BoolVarP(&cmd.Quiet, "quiet", "q", false, "Don't show progress spinners while fetching")
+	rootCmd.PersistentFlags().StringVar(&cmd.RawResponse, "raw-response", "", "Dump every API response, pretty-printed, to a file (or stderr when given without a file)")
+	rootCmd.PersistentFlags().Lookup("raw-response").NoOptDefVal = "-"
+	rootCmd.Flags().Bool("version", false, "Print version information")
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	cmd.SetupErrors(rootCmd)
+
+	c, err := rootCmd.ExecuteC()
+	if closeErr := cmd.CloseRawResponse(); closeErr != nil {
+		c.PrintErrf("Warning: failed to save raw responses: %v\n", closeErr)
+	}
+	if err != nil {
+		cmd.PrintError(c, err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }